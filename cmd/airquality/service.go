@@ -0,0 +1,91 @@
+package airquality
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/netclient"
+)
+
+// Reading is the current US AQI at a location.
+type Reading struct {
+	Time time.Time
+	AQI  int
+	PM25 float64 // µg/m³
+}
+
+// Category returns the EPA category name for the AQI value.
+func (r Reading) Category() string {
+	switch {
+	case r.AQI <= 50:
+		return "good"
+	case r.AQI <= 100:
+		return "moderate"
+	case r.AQI <= 150:
+		return "unhealthy for sensitive groups"
+	case r.AQI <= 200:
+		return "unhealthy"
+	case r.AQI <= 300:
+		return "very unhealthy"
+	default:
+		return "hazardous"
+	}
+}
+
+// Service fetches air quality readings.
+type Service interface {
+	Current(ctx context.Context, lat, lon float64) (Reading, error)
+}
+
+var _ Service = (*openMeteoService)(nil)
+
+// openMeteoService queries the free Open-Meteo Air Quality API.
+type openMeteoService struct {
+	client *http.Client
+}
+
+// NewService returns an Open-Meteo backed air quality service.
+func NewService() Service {
+	return &openMeteoService{client: netclient.Shared()}
+}
+
+func (s *openMeteoService) Current(ctx context.Context, lat, lon float64) (Reading, error) {
+	url := fmt.Sprintf("https://air-quality-api.open-meteo.com/v1/air-quality?latitude=%.4f&longitude=%.4f&current=us_aqi,pm2_5&timezone=GMT", lat, lon)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Reading{}, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return Reading{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Reading{}, errors.New("unexpected status code: " + resp.Status)
+	}
+	var parsed struct {
+		Current struct {
+			Time  string   `json:"time"`
+			USAQI *float64 `json:"us_aqi"`
+			PM25  *float64 `json:"pm2_5"`
+		} `json:"current"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return Reading{}, err
+	}
+	if parsed.Current.USAQI == nil {
+		return Reading{}, errors.New("no AQI for location")
+	}
+	r := Reading{AQI: int(*parsed.Current.USAQI + 0.5)}
+	if parsed.Current.PM25 != nil {
+		r.PM25 = *parsed.Current.PM25
+	}
+	if t, err := time.ParseInLocation("2006-01-02T15:04", parsed.Current.Time, time.UTC); err == nil {
+		r.Time = t
+	}
+	return r, nil
+}
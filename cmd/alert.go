@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/sumwatshade/surflog/cmd/alerts"
+	"github.com/sumwatshade/surflog/cmd/config"
+)
+
+// alertsCmd groups alert notification controls.
+var alertsCmd = &cobra.Command{
+	Use:   "alerts",
+	Short: "Manage alert snoozes and quiet hours",
+}
+
+var alertsSnoozeCmd = &cobra.Command{
+	Use:   "snooze <rule>",
+	Short: "Mute an alert rule for a while",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := alerts.NewSnoozeStore(config.StateDir())
+		if err != nil {
+			return err
+		}
+		d, _ := cmd.Flags().GetDuration("for")
+		until, err := store.Snooze(args[0], d)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s snoozed until %s\n", args[0], until.Format("2006-01-02 15:04"))
+		return nil
+	},
+}
+
+var alertsUnsnoozeCmd = &cobra.Command{
+	Use:   "unsnooze <rule>",
+	Short: "Clear a rule's snooze",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := alerts.NewSnoozeStore(config.StateDir())
+		if err != nil {
+			return err
+		}
+		return store.Unsnooze(args[0])
+	},
+}
+
+var alertsStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show quiet hours and active snoozes",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		now := time.Now()
+		if q, ok := alerts.ConfiguredQuietHours(); ok {
+			state := "inactive"
+			if q.Contains(now) {
+				state = "active"
+			}
+			fmt.Fprintf(out, "quiet hours: %s (%s)\n", config.String("alerts.quiet_hours"), state)
+		} else {
+			fmt.Fprintln(out, "quiet hours: none")
+		}
+		store, err := alerts.NewSnoozeStore(config.StateDir())
+		if err != nil {
+			return err
+		}
+		active, err := store.Active(now)
+		if err != nil {
+			return err
+		}
+		names := make([]string, 0, len(active))
+		for k := range active {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		for _, n := range names {
+			fmt.Fprintf(out, "snoozed: %s until %s\n", n, active[n].Format("2006-01-02 15:04"))
+		}
+		return nil
+	},
+}
+
+func init() {
+	alertsSnoozeCmd.Flags().Duration("for", 24*time.Hour, "how long to mute the rule")
+	alertsCmd.AddCommand(alertsSnoozeCmd, alertsUnsnoozeCmd, alertsStatusCmd)
+	rootCmd.AddCommand(alertsCmd)
+}
@@ -0,0 +1,19 @@
+package alerts
+
+import (
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/config"
+)
+
+// ShouldNotify reports whether an alert for rule may fire at now, honouring
+// global quiet hours and per-rule snoozes.
+func ShouldNotify(rule string, now time.Time) bool {
+	if q, ok := ConfiguredQuietHours(); ok && q.Contains(now) {
+		return false
+	}
+	if store, err := NewSnoozeStore(config.StateDir()); err == nil && store.Snoozed(rule, now) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// hookTimeout bounds how long a rule's shell hook may run.
+const hookTimeout = time.Minute
+
+// Notify raises a desktop notification with the platform's notifier
+// (notify-send or osascript). Where there is none it writes an OSC 9
+// notification and a bell to w, which many terminals turn into one.
+func Notify(w io.Writer, title, body string) {
+	if DesktopNotify(title, body) {
+		return
+	}
+	fmt.Fprintf(w, "\x1b]9;%s: %s\x07", title, body)
+}
+
+// DesktopNotify is Notify without the terminal fallback, for callers that
+// own the screen (the TUI). It reports whether a notifier ran.
+func DesktopNotify(title, body string) bool {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("osascript", "-e", "display notification "+strconv.Quote(body)+" with title "+strconv.Quote(title))
+	case "linux", "freebsd", "openbsd", "netbsd":
+		if _, err := exec.LookPath("notify-send"); err == nil {
+			cmd = exec.Command("notify-send", title, body)
+		}
+	}
+	return cmd != nil && cmd.Run() == nil
+}
+
+// RunHook runs a rule's hook with sh -c, passing env (e.g. SURFLOG_RULE) on
+// top of the current environment. Its output goes to out.
+func RunHook(ctx context.Context, hook string, env []string, out io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, hookTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", hook)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout, cmd.Stderr = out, out
+	return cmd.Run()
+}
@@ -0,0 +1,66 @@
+package alerts
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// QuietHours is a daily window (local time) during which notifications are
+// suppressed. The window may wrap past midnight, e.g. 22:00-06:30.
+type QuietHours struct {
+	start, end time.Duration // offsets from local midnight
+}
+
+// ParseQuietHours parses "HH:MM-HH:MM".
+func ParseQuietHours(s string) (QuietHours, error) {
+	from, to, ok := strings.Cut(strings.TrimSpace(s), "-")
+	if !ok {
+		return QuietHours{}, errors.New("quiet hours must look like 22:00-06:30")
+	}
+	start, err := clockOffset(from)
+	if err != nil {
+		return QuietHours{}, err
+	}
+	end, err := clockOffset(to)
+	if err != nil {
+		return QuietHours{}, err
+	}
+	return QuietHours{start: start, end: end}, nil
+}
+
+func clockOffset(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains reports whether t falls inside the quiet window.
+func (q QuietHours) Contains(t time.Time) bool {
+	if q.start == q.end {
+		return false
+	}
+	off := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if q.start < q.end {
+		return off >= q.start && off < q.end
+	}
+	return off >= q.start || off < q.end // wraps midnight
+}
+
+// ConfiguredQuietHours reads `alerts.quiet_hours` from config; ok is false when
+// unset or invalid.
+func ConfiguredQuietHours() (QuietHours, bool) {
+	raw := strings.TrimSpace(viper.GetString("alerts.quiet_hours"))
+	if raw == "" {
+		return QuietHours{}, false
+	}
+	q, err := ParseQuietHours(raw)
+	if err != nil {
+		return QuietHours{}, false
+	}
+	return q, true
+}
@@ -0,0 +1,167 @@
+package alerts
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/direction"
+)
+
+// Rule is a user-defined condition alert from `alerts.rules`:
+//
+//	alerts:
+//	  rules:
+//	    - name: long-period-west
+//	      when: period > 13 AND wvht > 1.5m AND swell_dir in W-NW
+//	      hook: notify-send surf "$SURFLOG_MESSAGE"   # optional
+type Rule struct {
+	Name string `mapstructure:"name"`
+	When string `mapstructure:"when"`
+	Hook string `mapstructure:"hook"`
+	cond []term
+}
+
+// Reading is one set of observations to evaluate rules against, keyed by
+// field name (see fields). Missing fields never match.
+type Reading map[string]float64
+
+// fields are the names a rule can test. Heights are meters (a value may say
+// "ft"), periods seconds (period is the swell's), speeds knots, temperatures
+// °C and directions degrees true.
+var fields = []string{"wvht", "swell_height", "period", "avg_period", "swell_dir",
+	"wind_speed", "wind_gust", "wind_dir", "water_temp"}
+
+// Fields lists the names rule conditions can test, for help text.
+func Fields() []string { return slices.Clone(fields) }
+
+// term is one comparison: field op value, or field in from-to for
+// directions (clockwise from from to to).
+type term struct {
+	field    string
+	op       string
+	value    float64
+	from, to float64 // for "in"
+}
+
+var (
+	andRE  = regexp.MustCompile(`(?i)\s+and\s+`)
+	termRE = regexp.MustCompile(`^([a-z_]+)\s*(>=|<=|>|<|=|\s+in\s+)\s*(.+)$`)
+)
+
+// parseCondition parses "a > 1 AND b in W-NW" into terms.
+func parseCondition(s string) ([]term, error) {
+	var terms []term
+	for _, part := range andRE.Split(strings.TrimSpace(s), -1) {
+		m := termRE.FindStringSubmatch(strings.ToLower(strings.TrimSpace(part)))
+		if m == nil {
+			return nil, fmt.Errorf("can't parse %q (want e.g. period > 13 or swell_dir in W-NW)", part)
+		}
+		t := term{field: m[1], op: strings.TrimSpace(m[2])}
+		if !slices.Contains(fields, t.field) {
+			return nil, fmt.Errorf("unknown field %q", t.field)
+		}
+		val := strings.TrimSpace(m[3])
+		if t.op == "in" {
+			from, to, ok := strings.Cut(strings.ReplaceAll(val, "–", "-"), "-")
+			a, okA := direction.Degrees(from)
+			b, okB := direction.Degrees(to)
+			if !ok || !okA || !okB {
+				return nil, fmt.Errorf("invalid direction range %q (want e.g. W-NW or 250-300)", val)
+			}
+			t.from, t.to = a, b
+		} else {
+			v, err := parseValue(val)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", t.field, err)
+			}
+			t.value = v
+		}
+		terms = append(terms, t)
+	}
+	return terms, nil
+}
+
+// parseValue reads a number with an optional unit: "ft" converts feet to
+// meters; "m", "s" and "kt" are accepted as-is.
+func parseValue(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	scale := 1.0
+	for _, u := range []string{"ft", "kt", "m", "s"} {
+		if rest, ok := strings.CutSuffix(s, u); ok {
+			s = strings.TrimSpace(rest)
+			if u == "ft" {
+				scale = 0.3048
+			}
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	return v * scale, nil
+}
+
+func (t term) match(r Reading) bool {
+	v, ok := r[t.field]
+	if !ok {
+		return false
+	}
+	switch t.op {
+	case "in":
+		return direction.Normalize(v-t.from) <= direction.Normalize(t.to-t.from)
+	case ">":
+		return v > t.value
+	case ">=":
+		return v >= t.value
+	case "<":
+		return v < t.value
+	case "<=":
+		return v <= t.value
+	}
+	return v == t.value
+}
+
+// Match reports whether every term of the rule holds for r.
+func (rule Rule) Match(r Reading) bool {
+	for _, t := range rule.cond {
+		if !t.match(r) {
+			return false
+		}
+	}
+	return len(rule.cond) > 0
+}
+
+// ConfiguredRules loads and validates `alerts.rules`.
+func ConfiguredRules() ([]Rule, error) {
+	var rules []Rule
+	if err := viper.UnmarshalKey("alerts.rules", &rules); err != nil {
+		return nil, fmt.Errorf("alerts.rules: %w", err)
+	}
+	seen := map[string]bool{}
+	for i := range rules {
+		r := &rules[i]
+		r.Name = strings.TrimSpace(r.Name)
+		if r.Name == "" {
+			return nil, fmt.Errorf("alerts.rules[%d]: missing name", i)
+		}
+		if seen[r.Name] {
+			return nil, fmt.Errorf("alerts.rules: duplicate name %q", r.Name)
+		}
+		seen[r.Name] = true
+		if strings.TrimSpace(r.When) == "" {
+			return nil, errors.New("alerts.rules." + r.Name + ": missing when")
+		}
+		cond, err := parseCondition(r.When)
+		if err != nil {
+			return nil, fmt.Errorf("alerts.rules.%s: %w", r.Name, err)
+		}
+		r.cond = cond
+	}
+	return rules, nil
+}
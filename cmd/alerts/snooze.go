@@ -0,0 +1,107 @@
+package alerts
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SnoozeStore persists per-rule snoozes ("mute this swell alert for 24h") as a
+// single JSON map of rule name to expiry time.
+type SnoozeStore struct {
+	path string
+}
+
+// NewSnoozeStore creates a snooze store under dir (created if missing).
+func NewSnoozeStore(dir string) (*SnoozeStore, error) {
+	if dir == "" {
+		return nil, errors.New("empty state dir")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &SnoozeStore{path: filepath.Join(dir, "snoozes.json")}, nil
+}
+
+func (s *SnoozeStore) load() (map[string]time.Time, error) {
+	out := map[string]time.Time{}
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return out, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *SnoozeStore) save(m map[string]time.Time) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Snooze mutes rule until now+d.
+func (s *SnoozeStore) Snooze(rule string, d time.Duration) (time.Time, error) {
+	if rule == "" {
+		return time.Time{}, errors.New("empty rule name")
+	}
+	m, err := s.load()
+	if err != nil {
+		return time.Time{}, err
+	}
+	until := time.Now().Add(d)
+	m[rule] = until
+	return until, s.save(m)
+}
+
+// Unsnooze clears any snooze on rule.
+func (s *SnoozeStore) Unsnooze(rule string) error {
+	m, err := s.load()
+	if err != nil {
+		return err
+	}
+	delete(m, rule)
+	return s.save(m)
+}
+
+// Active returns unexpired snoozes, pruning expired ones.
+func (s *SnoozeStore) Active(now time.Time) (map[string]time.Time, error) {
+	m, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	pruned := false
+	for k, until := range m {
+		if !until.After(now) {
+			delete(m, k)
+			pruned = true
+		}
+	}
+	if pruned {
+		_ = s.save(m)
+	}
+	return m, nil
+}
+
+// Snoozed reports whether rule is muted at now.
+func (s *SnoozeStore) Snoozed(rule string, now time.Time) bool {
+	m, err := s.Active(now)
+	if err != nil {
+		return false
+	}
+	_, ok := m[rule]
+	return ok
+}
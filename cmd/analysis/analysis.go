@@ -0,0 +1,177 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/sumwatshade/surflog/cmd/create"
+)
+
+// perceivedMeters approximates each perceived height option in meters of face
+// height so it can be compared against buoy readings.
+var perceivedMeters = map[string]float64{
+	"ankle":    0.2,
+	"knee":     0.5,
+	"waist":    0.8,
+	"chest":    1.2,
+	"shoulder": 1.4,
+	"head":     1.8,
+	"overhead": 2.4,
+}
+
+// PerceivedMeters returns the approximate face height for a perceived option.
+func PerceivedMeters(option string) (float64, bool) {
+	m, ok := perceivedMeters[strings.ToLower(strings.TrimSpace(option))]
+	return m, ok
+}
+
+// Bucket aggregates buoy readings for one perceived height at a spot.
+type Bucket struct {
+	Perceived string
+	Sessions  int
+	AvgWVHT   float64 // meters
+	AvgPeriod float64 // seconds (primary swell)
+}
+
+// PeriodBand aggregates sessions whose swell period falls in [Min, Max).
+type PeriodBand struct {
+	Label    string
+	Min, Max float64
+	Sessions int
+	AvgWVHT  float64
+	// Ratio is mean perceived face height over buoy WVHT: above 1 the spot
+	// amplifies that swell, below 1 it shadows it.
+	Ratio float64
+}
+
+// SpotReport compares observed buoy conditions with logged perceptions.
+type SpotReport struct {
+	Spot      string
+	Sessions  int
+	Buckets   []Bucket     // ordered by perceived height
+	Bands     []PeriodBand // only bands with sessions
+	SweetSpot *PeriodBand  // band with the highest Ratio (needs >= 2 sessions)
+	// Correlation is Pearson's r between perceived face height and buoy
+	// WVHT; nil with fewer than 3 sessions or no spread in either.
+	Correlation *float64
+}
+
+var bandEdges = []struct {
+	label    string
+	min, max float64
+}{
+	{"<8s", 0, 8}, {"8-10s", 8, 10}, {"10-12s", 10, 12}, {"12-14s", 12, 14}, {"14-16s", 14, 16}, {"16s+", 16, 1e9},
+}
+
+// BySpot builds a report for every spot with at least one session that has
+// both a recorded wave summary and a recognised perceived height.
+func BySpot(entries []create.Entry) []SpotReport {
+	type acc struct {
+		name    string
+		buckets map[string]*[3]float64 // wvht sum, period sum, n
+		bands   []([3]float64)         // wvht sum, ratio sum, n
+		n       int
+		corr    [5]float64 // sums of x, y, x², y², xy for x = face, y = WVHT
+	}
+	spots := map[string]*acc{}
+	for _, e := range entries {
+		ws := e.WaveSummary
+		face, ok := PerceivedMeters(e.WaveHeight)
+		if !ok || ws.IsZero() || ws.SignificantHeight() <= 0 {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(e.Spot))
+		a := spots[key]
+		if a == nil {
+			a = &acc{name: strings.TrimSpace(e.Spot), buckets: map[string]*[3]float64{}, bands: make([][3]float64, len(bandEdges))}
+			spots[key] = a
+		}
+		a.n++
+		x, y := face, ws.SignificantHeight()
+		a.corr[0] += x
+		a.corr[1] += y
+		a.corr[2] += x * x
+		a.corr[3] += y * y
+		a.corr[4] += x * y
+		bk := strings.ToLower(e.WaveHeight)
+		b := a.buckets[bk]
+		if b == nil {
+			b = &[3]float64{}
+			a.buckets[bk] = b
+		}
+		b[0] += ws.SignificantHeight()
+		b[1] += ws.SwellPeriod()
+		b[2]++
+		for i, edge := range bandEdges {
+			if ws.SwellPeriod() >= edge.min && ws.SwellPeriod() < edge.max {
+				a.bands[i][0] += ws.SignificantHeight()
+				a.bands[i][1] += face / ws.SignificantHeight()
+				a.bands[i][2]++
+				break
+			}
+		}
+	}
+
+	var out []SpotReport
+	for _, a := range spots {
+		r := SpotReport{Spot: a.name, Sessions: a.n, Correlation: pearson(a.corr, a.n)}
+		for _, opt := range create.HeightOptions {
+			if b, ok := a.buckets[strings.ToLower(opt)]; ok {
+				r.Buckets = append(r.Buckets, Bucket{Perceived: opt, Sessions: int(b[2]), AvgWVHT: b[0] / b[2], AvgPeriod: b[1] / b[2]})
+			}
+		}
+		for i, edge := range bandEdges {
+			bd := a.bands[i]
+			if bd[2] == 0 {
+				continue
+			}
+			r.Bands = append(r.Bands, PeriodBand{Label: edge.label, Min: edge.min, Max: edge.max, Sessions: int(bd[2]), AvgWVHT: bd[0] / bd[2], Ratio: bd[1] / bd[2]})
+		}
+		for i := range r.Bands {
+			if r.Bands[i].Sessions < 2 {
+				continue
+			}
+			if r.SweetSpot == nil || r.Bands[i].Ratio > r.SweetSpot.Ratio {
+				r.SweetSpot = &r.Bands[i]
+			}
+		}
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Sessions != out[j].Sessions {
+			return out[i].Sessions > out[j].Sessions
+		}
+		return out[i].Spot < out[j].Spot
+	})
+	return out
+}
+
+// Overall pools every spot into one report named "All spots".
+func Overall(entries []create.Entry) (SpotReport, bool) {
+	pooled := make([]create.Entry, len(entries))
+	for i, e := range entries {
+		e.Spot = "All spots"
+		pooled[i] = e
+	}
+	reports := BySpot(pooled)
+	if len(reports) == 0 {
+		return SpotReport{}, false
+	}
+	return reports[0], true
+}
+
+// pearson computes r from running sums (see BySpot's acc.corr).
+func pearson(s [5]float64, n int) *float64 {
+	if n < 3 {
+		return nil
+	}
+	fn := float64(n)
+	vx := fn*s[2] - s[0]*s[0]
+	vy := fn*s[3] - s[1]*s[1]
+	if vx <= 0 || vy <= 0 {
+		return nil
+	}
+	r := (fn*s[4] - s[0]*s[1]) / math.Sqrt(vx*vy)
+	return &r
+}
@@ -0,0 +1,142 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/units"
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("44"))
+	spotStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("51")).Underline(true)
+	faintStyle = lipgloss.NewStyle().Faint(true).Foreground(lipgloss.Color("245"))
+	goodStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("159")).Bold(true)
+	infoStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
+)
+
+// Model is the per-spot "observed vs perceived" right-pane view.
+type Model struct {
+	reports []SpotReport
+	idx     int
+	status  string // outcome of the last browser open
+	open    func(SpotReport) (string, error)
+}
+
+// openedMsg reports the result of opening a report in the browser.
+type openedMsg struct {
+	path string
+	err  error
+}
+
+// NewModel builds reports from existing entries.
+func NewModel(entries []create.Entry) *Model {
+	return &Model{reports: BySpot(entries)}
+}
+
+// SetOpener sets how a report is opened in the browser. It is injected
+// because the HTML renderer depends on this package.
+func (m *Model) SetOpener(open func(SpotReport) (string, error)) {
+	if m != nil {
+		m.open = open
+	}
+}
+
+// SetEntries rebuilds reports, keeping the selected spot when still present.
+func (m *Model) SetEntries(entries []create.Entry) {
+	if m == nil {
+		return
+	}
+	var current string
+	if m.idx < len(m.reports) {
+		current = m.reports[m.idx].Spot
+	}
+	m.reports = BySpot(entries)
+	m.idx = 0
+	for i, r := range m.reports {
+		if r.Spot == current {
+			m.idx = i
+		}
+	}
+}
+
+// Update cycles between spots with the left/right (or [/]) keys.
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	if m == nil || len(m.reports) == 0 {
+		return nil
+	}
+	switch msg := msg.(type) {
+	case openedMsg:
+		if msg.err != nil {
+			m.status = i18n.T("Could not open browser: %v", msg.err)
+		} else {
+			m.status = i18n.T("Opened %s", msg.path)
+		}
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "right", "]", "l":
+			m.idx = (m.idx + 1) % len(m.reports)
+			m.status = ""
+		case "left", "[", "h":
+			m.idx = (m.idx - 1 + len(m.reports)) % len(m.reports)
+			m.status = ""
+		case "o": // render the report as HTML in the browser
+			if m.open == nil {
+				return nil
+			}
+			r, open := m.reports[m.idx], m.open
+			return func() tea.Msg {
+				path, err := open(r)
+				return openedMsg{path: path, err: err}
+			}
+		}
+	}
+	return nil
+}
+
+// View renders the selected spot's report.
+func (m *Model) View() string {
+	b := &strings.Builder{}
+	fmt.Fprintln(b, titleStyle.Render(i18n.T("Observed vs Perceived")))
+	if m == nil || len(m.reports) == 0 {
+		fmt.Fprintln(b, faintStyle.Render(i18n.T("Not enough entries with buoy data yet.")))
+		return b.String()
+	}
+	r := m.reports[m.idx]
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, spotStyle.Render(r.Spot)+" "+faintStyle.Render(i18n.T("(%d/%d, ←/→ to switch, o to open in browser)", m.idx+1, len(m.reports))))
+	fmt.Fprintln(b, infoStyle.Render(i18n.T("%d sessions with buoy data", r.Sessions)))
+
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, titleStyle.Render(i18n.T("Perceived height")))
+	for _, bk := range r.Buckets {
+		fmt.Fprintf(b, "  %-9s %s\n", create.HeightLabel(bk.Perceived), infoStyle.Render(i18n.T("%s @ %.0fs avg (%d)", units.Height(bk.AvgWVHT), bk.AvgPeriod, bk.Sessions)))
+	}
+
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, titleStyle.Render(i18n.T("Swell period")))
+	for _, bd := range r.Bands {
+		line := fmt.Sprintf("  %-7s %s", bd.Label, i18n.T("%s avg, %.1fx face/buoy (%d)", units.Height(bd.AvgWVHT), bd.Ratio, bd.Sessions))
+		if r.SweetSpot != nil && bd.Label == r.SweetSpot.Label {
+			fmt.Fprintln(b, goodStyle.Render(line+" ★"))
+			continue
+		}
+		fmt.Fprintln(b, infoStyle.Render(line))
+	}
+
+	fmt.Fprintln(b)
+	if r.SweetSpot != nil {
+		fmt.Fprintln(b, goodStyle.Render(i18n.T("Sweet spot: %s swell (%.1fx buoy height)", r.SweetSpot.Label, r.SweetSpot.Ratio)))
+	} else {
+		fmt.Fprintln(b, faintStyle.Render(i18n.T("Log more sessions to find this spot's sweet spot.")))
+	}
+	if m.status != "" {
+		fmt.Fprintln(b)
+		fmt.Fprintln(b, faintStyle.Render(m.status))
+	}
+	return b.String()
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/journal"
+	"github.com/sumwatshade/surflog/cmd/spots"
+)
+
+var journalAttachCmd = &cobra.Command{
+	Use:   "attach <entry-id> <file>...",
+	Short: "Attach files (photos, tracks) to an entry",
+	Long: `Copies files into attachments/<entry-id>/ in the journal directory, or with
+--reference records their current path without copying. The entry ID may be
+abbreviated to any unique prefix.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := journal.NewDefaultService()
+		if err != nil {
+			return err
+		}
+		entries, err := svc.List()
+		if err != nil {
+			return err
+		}
+		e, err := findEntry(entries, args[0])
+		if err != nil {
+			return err
+		}
+		reference, _ := cmd.Flags().GetBool("reference")
+		for _, src := range args[1:] {
+			a, err := journal.Attach(svc, config.JournalDir(), e.ID, src, !reference)
+			if err != nil {
+				return err
+			}
+			verb := "copied"
+			if !a.Copied {
+				verb = "referenced"
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s %s -> %s\n", verb, src, journal.AttachmentPath(config.JournalDir(), e.ID, a))
+		}
+		return nil
+	},
+}
+
+var journalDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check entry files and list orphaned attachments",
+	Long: `Validates every entry file and checks attachments: copies whose entry is
+gone (orphans) and recorded attachments whose file is missing. Orphans are
+only listed; --fix removes them. Nothing is removed while any entry file is
+invalid, since its attachments would look orphaned.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := config.JournalDir()
+		out := cmd.OutOrStdout()
+		invalid, err := journal.ValidateDir(dir)
+		if err != nil {
+			return err
+		}
+		for _, v := range invalid {
+			fmt.Fprintf(out, "invalid %s: %s\n", filepath.Base(v.Path), strings.Join(v.Problems, "; "))
+		}
+		entries, err := loadEntries()
+		if err != nil {
+			return err
+		}
+		orphans, missing, err := journal.CheckAttachments(dir, entries)
+		if err != nil {
+			return err
+		}
+		for _, m := range missing {
+			fmt.Fprintf(out, "missing %s (entry %s)\n", journal.AttachmentPath(dir, m.EntryID, m.Attachment), m.EntryID)
+		}
+		fix, _ := cmd.Flags().GetBool("fix")
+		if fix && len(invalid) > 0 {
+			fmt.Fprintln(out, "not removing orphans until the invalid entry files are fixed")
+			fix = false
+		}
+		for _, path := range orphans {
+			if !fix {
+				fmt.Fprintln(out, "orphan", path)
+				continue
+			}
+			if err := os.RemoveAll(path); err != nil {
+				return err
+			}
+			fmt.Fprintln(out, "removed orphan", path)
+		}
+		if len(invalid) > 0 || len(missing) > 0 {
+			return fmt.Errorf("%d invalid entry files, %d missing attachments", len(invalid), len(missing))
+		}
+		fmt.Fprintln(out, "journal healthy")
+		return nil
+	},
+}
+
+var journalExportCmd = &cobra.Command{
+	Use:   "export <dir>",
+	Short: "Export entries as JSON files, with or without attachments",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dst := config.ExpandPath(args[0])
+		if filepath.Clean(dst) == filepath.Clean(config.JournalDir()) {
+			return errors.New("export directory must differ from the journal directory")
+		}
+		entries, err := loadEntries()
+		if err != nil {
+			return err
+		}
+		filter, _ := cmd.Flags().GetString("filter")
+		q, err := journal.ParseQuery(filter)
+		if err != nil {
+			return err
+		}
+		var red *spots.Redactor
+		if share, _ := cmd.Flags().GetBool("share"); share {
+			red = spots.DefaultRedactor()
+		}
+		selected := entries[:0]
+		for _, e := range entries {
+			if !q.Match(e) {
+				continue
+			}
+			if red != nil {
+				e.Spot, e.Comments = red.Name(e.Spot), red.Text(e.Comments)
+			}
+			selected = append(selected, e)
+		}
+		withAttachments, _ := cmd.Flags().GetBool("attachments")
+		rep, err := journal.Export(dst, config.JournalDir(), selected, withAttachments)
+		if err != nil {
+			return err
+		}
+		for _, m := range rep.Missing {
+			fmt.Fprintf(cmd.ErrOrStderr(), "skipped missing attachment %s (entry %s)\n", m.Attachment.Name, m.EntryID)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "exported %d entries and %d attachments to %s\n", rep.Entries, rep.Attachments, dst)
+		return nil
+	},
+}
+
+// findEntry resolves a full or abbreviated entry ID.
+func findEntry(entries []create.Entry, prefix string) (create.Entry, error) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return create.Entry{}, errors.New("empty entry id")
+	}
+	var found []create.Entry
+	for _, e := range entries {
+		if e.ID == prefix {
+			return e, nil
+		}
+		if strings.HasPrefix(e.ID, prefix) {
+			found = append(found, e)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return create.Entry{}, fmt.Errorf("no entry with id %q", prefix)
+	case 1:
+		return found[0], nil
+	default:
+		return create.Entry{}, fmt.Errorf("id %q is ambiguous (%d entries)", prefix, len(found))
+	}
+}
+
+func init() {
+	journalAttachCmd.Flags().Bool("reference", false, "record the file's path instead of copying it")
+	journalDoctorCmd.Flags().Bool("fix", false, "remove orphaned attachments")
+	journalExportCmd.Flags().Bool("attachments", true, "include attachment files (--attachments=false to leave them out)")
+	journalExportCmd.Flags().String("filter", "", "which entries to export (default all)")
+	journalExportCmd.Flags().Bool("share", false, "replace private spot names with their aliases")
+	journalCmd.AddCommand(journalAttachCmd, journalDoctorCmd, journalExportCmd)
+}
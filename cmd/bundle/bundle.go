@@ -0,0 +1,129 @@
+// Package bundle seals journal entries into a passphrase-encrypted file that
+// can be handed to a friend and imported into their journal.
+//
+// A bundle file is the magic header, a random salt and nonce, then the
+// AES-256-GCM sealed gzip of the JSON payload. The key is derived from the
+// passphrase with PBKDF2-HMAC-SHA256.
+package bundle
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/create"
+)
+
+// Version is the payload format written by Seal.
+const Version = 1
+
+const (
+	magic      = "SURFLOGB1"
+	saltLen    = 16
+	keyLen     = 32
+	iterations = 600_000
+)
+
+// ErrPassphrase is returned by Open when the passphrase is wrong or the file
+// has been tampered with; GCM cannot tell the two apart.
+var ErrPassphrase = errors.New("wrong passphrase or corrupted bundle")
+
+// Bundle is the decrypted payload: who shared it, when, and the entries.
+type Bundle struct {
+	Version   int            `json:"version"`
+	From      string         `json:"from"`
+	CreatedAt time.Time      `json:"created_at"`
+	Entries   []create.Entry `json:"entries"`
+}
+
+// Seal encrypts b with passphrase.
+func Seal(b Bundle, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.New("empty passphrase")
+	}
+	b.Version = Version
+	var plain bytes.Buffer
+	zw := gzip.NewWriter(&plain)
+	if err := json.NewEncoder(zw).Encode(b); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	out := append([]byte(magic), salt...)
+	out = append(out, nonce...)
+	// the header is authenticated so it cannot be swapped between bundles
+	return aead.Seal(out, nonce, plain.Bytes(), out[:len(magic)+saltLen]), nil
+}
+
+// Open decrypts a bundle produced by Seal.
+func Open(data []byte, passphrase string) (Bundle, error) {
+	if !bytes.HasPrefix(data, []byte(magic)) {
+		return Bundle{}, errors.New("not a surflog bundle")
+	}
+	if len(data) < len(magic)+saltLen {
+		return Bundle{}, errors.New("truncated bundle")
+	}
+	header := data[:len(magic)+saltLen]
+	aead, err := newAEAD(passphrase, header[len(magic):])
+	if err != nil {
+		return Bundle{}, err
+	}
+	rest := data[len(header):]
+	if len(rest) < aead.NonceSize() {
+		return Bundle{}, errors.New("truncated bundle")
+	}
+	plain, err := aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():], header)
+	if err != nil {
+		return Bundle{}, ErrPassphrase
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(plain))
+	if err != nil {
+		return Bundle{}, err
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return Bundle{}, err
+	}
+	var b Bundle
+	if err := json.Unmarshal(raw, &b); err != nil {
+		return Bundle{}, err
+	}
+	if b.Version > Version {
+		return Bundle{}, fmt.Errorf("bundle version %d is newer than this surflog supports (%d)", b.Version, Version)
+	}
+	return b, nil
+}
+
+func newAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, keyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
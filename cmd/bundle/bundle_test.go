@@ -0,0 +1,47 @@
+package bundle
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/create"
+)
+
+func TestSealOpenRoundTrip(t *testing.T) {
+	in := Bundle{
+		From:      "kai",
+		CreatedAt: time.Date(2024, 5, 1, 7, 30, 0, 0, time.UTC),
+		Entries: []create.Entry{
+			{ID: "9b2f6c1e-3c1a-4e7a-9d4e-2f7a1b0c5d6e", Spot: "Ocean Beach", Comments: "glassy"},
+		},
+	}
+	sealed, err := Seal(in, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := Open(sealed, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Version != Version || out.From != in.From || !out.CreatedAt.Equal(in.CreatedAt) {
+		t.Errorf("Open = %+v, want %+v", out, in)
+	}
+	if len(out.Entries) != 1 || out.Entries[0].ID != in.Entries[0].ID || out.Entries[0].Comments != "glassy" {
+		t.Errorf("entries = %+v, want %+v", out.Entries, in.Entries)
+	}
+}
+
+func TestOpenWrongPassphrase(t *testing.T) {
+	sealed, err := Seal(Bundle{From: "kai"}, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(sealed, "battery staple"); !errors.Is(err, ErrPassphrase) {
+		t.Errorf("wrong passphrase: err = %v, want ErrPassphrase", err)
+	}
+	sealed[len(sealed)-1] ^= 1
+	if _, err := Open(sealed, "correct horse"); !errors.Is(err, ErrPassphrase) {
+		t.Errorf("tampered bundle: err = %v, want ErrPassphrase", err)
+	}
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/config"
+)
+
+var buoyCmd = &cobra.Command{
+	Use:   "buoy",
+	Short: "Print the latest buoy wave summary (for scripts and status bars)",
+	Long: `Fetches the latest wave summary for the configured buoy (or --station) and
+prints it as a single line, or with --json as the same object stored on
+journal entries.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		station := buoy.ConfiguredBuoyStation()
+		if err := buoy.ValidateBuoyStation(station); err != nil {
+			return err
+		}
+		ws, err := buoy.NewService().GetWaveSummary(cmd.Context())
+		if err != nil {
+			return err
+		}
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			data, err := json.MarshalIndent(ws, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return nil
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), ws.String())
+		return nil
+	},
+}
+
+var buoyExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the archived water temperatures as CSV",
+	Long: `Writes the water temperatures archived for the configured buoy (or
+--station), oldest first, for analysis in pandas, DuckDB or a spreadsheet.
+Each row has the station, the observation time in UTC and water_temp_c (or
+water_temp_f, following display.temperature and units).
+Readings older than archive.compact_after_months are 3-hourly averages.
+
+Water temperature is the only observation surflog archives locally; wave and
+wind readings are not kept, so they cannot be exported. CSV is the only
+format: DuckDB's read_csv or pandas' read_csv can convert it to Parquet.
+
+Without --out the export is written to stdout.`,
+	Example: `  surflog buoy export --station 46274 --from 2024-01-01
+  surflog buoy export -o 46274.csv`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		known := false
+		for _, f := range buoy.ExportFormats {
+			known = known || strings.EqualFold(f, format)
+		}
+		if !known {
+			return fmt.Errorf("unknown --format %q (want %s)", format, strings.Join(buoy.ExportFormats, " or "))
+		}
+		var from time.Time
+		if s, _ := cmd.Flags().GetString("from"); s != "" {
+			t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+			if err != nil {
+				return fmt.Errorf("invalid --from %q (want YYYY-MM-DD)", s)
+			}
+			from = t
+		}
+		station := buoy.ConfiguredBuoyStation()
+		if err := buoy.ValidateBuoyStation(station); err != nil {
+			return err
+		}
+		archive, err := buoy.NewWaterTempArchive(config.StateDir())
+		if err != nil {
+			return err
+		}
+		readings, err := archive.List(station)
+		if err != nil {
+			return err
+		}
+		selected := readings[:0]
+		for _, r := range readings {
+			if !r.Time.Before(from) {
+				selected = append(selected, r)
+			}
+		}
+		out, _ := cmd.Flags().GetString("out")
+		if out == "" || out == "-" {
+			return buoy.WriteWaterTemps(cmd.OutOrStdout(), format, station, selected)
+		}
+		f, err := os.Create(config.ExpandPath(out))
+		if err != nil {
+			return err
+		}
+		if err := buoy.WriteWaterTemps(f, format, station, selected); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "exported %d readings from %s to %s\n", len(selected), station, out)
+		return nil
+	},
+}
+
+func init() {
+	buoyExportCmd.Flags().String("format", "csv", "output format: "+strings.Join(buoy.ExportFormats, ", "))
+	buoyExportCmd.Flags().String("from", "", "only readings on or after this date, YYYY-MM-DD")
+	buoyExportCmd.Flags().StringP("out", "o", "", "file to write (default stdout)")
+	buoyCmd.AddCommand(buoyExportCmd)
+	buoyCmd.Flags().Bool("json", false, "print the wave summary as JSON")
+	buoyCmd.Flags().Bool("plain", false, "print the one-line summary (default)")
+	buoyCmd.MarkFlagsMutuallyExclusive("json", "plain")
+	rootCmd.AddCommand(buoyCmd)
+}
@@ -0,0 +1,66 @@
+package buoy
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/airquality"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/spots"
+)
+
+// aqiFetchedMsg carries an air quality reading for the active location.
+type aqiFetchedMsg struct {
+	reading airquality.Reading
+	err     error
+}
+
+func fetchAQICmd(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		lat, lon := spots.ActiveLocation()
+		r, err := airquality.NewService().Current(ctx, lat, lon)
+		return aqiFetchedMsg{reading: r, err: err}
+	}
+}
+
+// defaultFireSeason covers the West Coast wildfire months (Jul-Nov).
+var defaultFireSeason = []int{7, 8, 9, 10, 11}
+
+// showAQI reports whether the AQI section is relevant: always when
+// `aqi.always` is set, during the configured `aqi.season_months`, or whenever
+// the air is unhealthy for sensitive groups or worse.
+func showAQI(r *airquality.Reading, now time.Time) bool {
+	if viper.GetBool("aqi.always") || (r != nil && r.AQI > 100) {
+		return true
+	}
+	months := defaultFireSeason
+	if viper.IsSet("aqi.season_months") {
+		months = viper.GetIntSlice("aqi.season_months")
+	}
+	for _, m := range months {
+		if time.Month(m) == now.Month() {
+			return true
+		}
+	}
+	return false
+}
+
+// renderAQISection shows the current air quality during fire season.
+func renderAQISection(bd *BuoyData, now time.Time) section {
+	sec := newSection(i18n.T("Air Quality"))
+	if bd == nil || !showAQI(bd.aqi, now) {
+		return section{}
+	}
+	if bd.aqiErr != nil {
+		sec.err = bd.aqiErr
+		return sec
+	}
+	if bd.aqi == nil {
+		sec.add(i18n.T("Loading..."))
+		return sec
+	}
+	sec.add(i18n.T("AQI %d (%s) | PM2.5 %.0f µg/m³", bd.aqi.AQI, i18n.T(bd.aqi.Category()), bd.aqi.PM25))
+	return sec
+}
@@ -0,0 +1,96 @@
+package buoy
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/spots"
+	"github.com/sumwatshade/surflog/cmd/swell"
+	"github.com/sumwatshade/surflog/cmd/units"
+)
+
+// upstreamLocations holds approximate positions of common deep-water NDBC
+// buoys used to watch swell before it reaches the coast. Other stations need
+// `buoy.upstream_lat` and `buoy.upstream_lon`.
+var upstreamLocations = map[string][2]float64{
+	"46001": {56.30, -148.02}, // Gulf of Alaska
+	"46002": {42.61, -130.49}, // West Oregon
+	"46005": {46.14, -131.08}, // West Washington
+	"46006": {40.76, -137.38}, // Southeast Papa
+	"46059": {38.09, -129.95}, // West California
+	"51101": {24.32, -162.06}, // Northwest Hawaii
+	"41049": {27.49, -62.94},  // South Bermuda
+	"44011": {41.09, -66.56},  // Georges Bank
+}
+
+// upstreamStation returns `buoy.upstream_station`, upper-cased; empty disables
+// the arrival estimate.
+func upstreamStation() string {
+	return strings.ToUpper(strings.TrimSpace(viper.GetString("buoy.upstream_station")))
+}
+
+// upstreamLocation returns the configured or built-in position of station.
+func upstreamLocation(station string) (lat, lon float64, ok bool) {
+	if viper.IsSet("buoy.upstream_lat") && viper.IsSet("buoy.upstream_lon") {
+		return viper.GetFloat64("buoy.upstream_lat"), viper.GetFloat64("buoy.upstream_lon"), true
+	}
+	p, ok := upstreamLocations[station]
+	return p[0], p[1], ok
+}
+
+// upstreamFetchedMsg carries the latest reading from the upstream buoy.
+type upstreamFetchedMsg struct {
+	wave WaveSummary
+	err  error
+	svc  Service
+}
+
+func fetchUpstreamCmd(ctx context.Context, svc Service) tea.Cmd {
+	return func() tea.Msg {
+		ws, err := svc.GetWaveSummary(ctx)
+		return upstreamFetchedMsg{wave: ws, err: err, svc: svc}
+	}
+}
+
+// renderArrivalSection estimates when the swell at the upstream buoy reaches
+// the active spot. It is empty (and skipped) when no upstream buoy is set.
+func renderArrivalSection(bd *BuoyData, now time.Time) section {
+	sec := newSection(i18n.T("Swell Arrival"))
+	if bd == nil || bd.upstreamSvc == nil {
+		return sec
+	}
+	station := upstreamStation()
+	blat, blon, ok := upstreamLocation(station)
+	if !ok {
+		sec.add(i18n.T("set buoy.upstream_lat and buoy.upstream_lon for %s", station))
+		return sec
+	}
+	if bd.upstreamErr != nil {
+		sec.err = bd.upstreamErr
+		return sec
+	}
+	if bd.upstream == nil {
+		sec.add(i18n.T("Loading..."))
+		return sec
+	}
+	ws := bd.upstream
+	period := ws.swellPeriod
+	if period == 0 {
+		period = ws.averagePeriod
+	}
+	lat, lon := spots.ActiveLocation()
+	km := swell.DistanceKm(blat, blon, lat, lon)
+	at := swell.Arrival(ws.time, period, km).In(time.Local)
+	when := at.Format("Mon") + " " + i18n.Time(at)
+	if at.Before(now) {
+		sec.add(i18n.T("%.0f s energy from %s arrived ~%s", period, station, when))
+	} else {
+		sec.add(i18n.T("%.0f s energy from %s should arrive ~%s", period, station, when))
+	}
+	sec.add(i18n.T("%s swell %s | %.0f km away, %.0fh in transit", units.Height(ws.swellHeight), ws.swellDirection, km, swell.TravelTime(period, km).Hours()))
+	return sec
+}
@@ -0,0 +1,133 @@
+package buoy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/cache"
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+)
+
+// withCache runs fetch and saves a successful result under key. When fetch
+// fails the last saved result is returned instead, along with when it was
+// saved; cachedAt is zero for fresh data. Bad station IDs and cancelled
+// requests are reported as-is since cached data would only hide them.
+func withCache[T any](key string, fetch func() (T, error)) (v T, cachedAt time.Time, err error) {
+	v, err = fetch()
+	store, serr := cache.NewStore(config.CacheDir())
+	if err == nil {
+		if serr == nil {
+			_ = store.Put(key, v)
+		}
+		return v, time.Time{}, nil
+	}
+	if serr != nil || errors.Is(err, ErrUnknownStation) || errors.Is(err, context.Canceled) {
+		return v, time.Time{}, err
+	}
+	var cached T
+	at, cerr := store.Get(key, &cached)
+	if cerr != nil {
+		return v, time.Time{}, err
+	}
+	return cached, at, nil
+}
+
+// cacheKey names the cache entry for svc's kind ("waves", "tides" or
+// "wind") of data. NOAA data is keyed by station as before; other providers
+// add their name and what they read, so spots served by Open-Meteo or CDIP
+// don't share a cache.
+func cacheKey(svc Service, kind string) string {
+	noaa, _ := svc.(*dataService)
+	if ps, ok := svc.(*providerService); ok {
+		noaa = ps.dataService
+		if p := ps.provider(kind); p != Provider(noaa) {
+			key := kind + "-" + p.Name()
+			if src, ok := p.(sourced); ok {
+				key += "-" + src.source()
+			}
+			return key
+		}
+	}
+	buoyStation, tideStation := ConfiguredBuoyStation(), ConfiguredTideStation()
+	if noaa != nil {
+		buoyStation, tideStation = noaa.buoyStationID(), noaa.tideStationID()
+	}
+	if kind == "tides" {
+		return kind + "-" + tideStation
+	}
+	return kind + "-" + buoyStation
+}
+
+// cachedLabel renders e.g. "(cached, 3h old)".
+func cachedLabel(at, now time.Time) string {
+	d := now.Sub(at)
+	var age string
+	switch {
+	case d < time.Hour:
+		age = fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		age = fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		age = fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+	return i18n.T("(cached, %s old)", age)
+}
+
+// tideDataDTO is the exported representation used for caching.
+type tideDataDTO struct {
+	StationID string `json:"station_id"`
+	Points    []struct {
+		T string  `json:"t"`
+		V float64 `json:"v"`
+	} `json:"points"`
+	Extremes []struct {
+		Time  time.Time `json:"time"`
+		Value float64   `json:"value"`
+		High  bool      `json:"high"`
+	} `json:"extremes,omitempty"`
+}
+
+// MarshalJSON implements custom JSON encoding while keeping internal fields unexported.
+func (td TideData) MarshalJSON() ([]byte, error) {
+	var dto tideDataDTO
+	dto.StationID = td.stationId
+	for _, p := range td.points {
+		dto.Points = append(dto.Points, struct {
+			T string  `json:"t"`
+			V float64 `json:"v"`
+		}{p.time, p.value})
+	}
+	for _, e := range td.extremes {
+		dto.Extremes = append(dto.Extremes, struct {
+			Time  time.Time `json:"time"`
+			Value float64   `json:"value"`
+			High  bool      `json:"high"`
+		}{e.time, e.value, e.high})
+	}
+	return json.Marshal(dto)
+}
+
+// UnmarshalJSON decodes cached tide data back into the internal struct.
+func (td *TideData) UnmarshalJSON(b []byte) error {
+	var dto tideDataDTO
+	if err := json.Unmarshal(b, &dto); err != nil {
+		return err
+	}
+	td.stationId = dto.StationID
+	td.points = td.points[:0]
+	for _, p := range dto.Points {
+		td.points = append(td.points, struct {
+			time  string
+			value float64
+		}{p.T, p.V})
+	}
+	td.extremes = td.extremes[:0]
+	for _, e := range dto.Extremes {
+		td.extremes = append(td.extremes, tideExtreme{time: e.Time, value: e.Value, high: e.High})
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package buoy
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/cache"
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/netclient"
+)
+
+// StationInfo locates an NDBC buoy or NOAA tide prediction station.
+type StationInfo struct {
+	ID   string  `json:"id"`
+	Name string  `json:"name"`
+	Lat  float64 `json:"lat"`
+	Lon  float64 `json:"lon"`
+	Tide bool    `json:"tide,omitempty"` // CO-OPS tide station rather than an NDBC buoy
+	// Moored is set for NDBC stations that are buoys at sea, which measure
+	// waves, rather than fixed platforms and coastal stations.
+	Moored bool `json:"moored,omitempty"`
+}
+
+// stationCatalogKey caches the catalog; station lists change rarely, so it is
+// only refetched once stationCatalogTTL old.
+const (
+	stationCatalogKey = "station-catalog-v2"
+	stationCatalogTTL = 30 * 24 * time.Hour
+)
+
+const (
+	ndbcStationsURL = "https://www.ndbc.noaa.gov/activestations.xml"
+	tideStationsURL = "https://api.tidesandcurrents.noaa.gov/mdapi/prod/webapi/stations.json?type=tidepredictions"
+)
+
+// StationCatalog returns every active NDBC buoy and NOAA tide prediction
+// station with coordinates. The cached copy is used while fresh, and a stale
+// one when NOAA cannot be reached.
+func StationCatalog(ctx context.Context) ([]StationInfo, error) {
+	store, serr := cache.NewStore(config.CacheDir())
+	var cached []StationInfo
+	var at time.Time
+	if serr == nil {
+		at, _ = store.Get(stationCatalogKey, &cached)
+		if len(cached) > 0 && time.Since(at) < stationCatalogTTL {
+			return cached, nil
+		}
+	}
+	fresh, err := fetchStationCatalog(ctx, netclient.Shared())
+	if err != nil {
+		if len(cached) > 0 && !errors.Is(err, context.Canceled) {
+			return cached, nil
+		}
+		return nil, err
+	}
+	if serr == nil {
+		_ = store.Put(stationCatalogKey, fresh)
+	}
+	return fresh, nil
+}
+
+func fetchStationCatalog(ctx context.Context, client *http.Client) ([]StationInfo, error) {
+	buoys, err := fetchNDBCStations(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	tides, err := fetchTideStations(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	return append(buoys, tides...), nil
+}
+
+func getCatalog(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.New("unexpected status code: " + resp.Status)
+	}
+	return resp, nil
+}
+
+// fetchNDBCStations reads NDBC's active station list, keeping stations that
+// report standard meteorological data (waves, wind, water temperature).
+func fetchNDBCStations(ctx context.Context, client *http.Client) ([]StationInfo, error) {
+	resp, err := getCatalog(ctx, client, ndbcStationsURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var doc struct {
+		Stations []struct {
+			ID   string  `xml:"id,attr"`
+			Name string  `xml:"name,attr"`
+			Lat  float64 `xml:"lat,attr"`
+			Lon  float64 `xml:"lon,attr"`
+			Met  string  `xml:"met,attr"`
+			Type string  `xml:"type,attr"`
+		} `xml:"station"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, err
+	}
+	out := make([]StationInfo, 0, len(doc.Stations))
+	for _, st := range doc.Stations {
+		id := strings.ToUpper(st.ID)
+		if st.Met != "y" || ValidateBuoyStation(id) != nil {
+			continue
+		}
+		out = append(out, StationInfo{ID: id, Name: st.Name, Lat: st.Lat, Lon: st.Lon, Moored: st.Type == "buoy"})
+	}
+	return out, nil
+}
+
+// fetchTideStations reads the CO-OPS metadata API's tide prediction stations.
+func fetchTideStations(ctx context.Context, client *http.Client) ([]StationInfo, error) {
+	resp, err := getCatalog(ctx, client, tideStationsURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var doc struct {
+		Stations []struct {
+			ID   string  `json:"id"`
+			Name string  `json:"name"`
+			Lat  float64 `json:"lat"`
+			Lon  float64 `json:"lng"`
+		} `json:"stations"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, err
+	}
+	out := make([]StationInfo, 0, len(doc.Stations))
+	for _, st := range doc.Stations {
+		if ValidateTideStation(st.ID) != nil {
+			continue
+		}
+		out = append(out, StationInfo{ID: st.ID, Name: st.Name, Lat: st.Lat, Lon: st.Lon, Tide: true})
+	}
+	return out, nil
+}
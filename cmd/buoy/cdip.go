@@ -0,0 +1,185 @@
+package buoy
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/direction"
+)
+
+// ProviderCDIP serves waves from a Coastal Data Information Program buoy,
+// which many California breaks are better represented by than NDBC ones. The
+// station is the spot's cdip_station or `buoy.cdip_station`.
+const ProviderCDIP = "cdip"
+
+// cdipThredds is the CDIP THREDDS server's OPeNDAP endpoint for realtime data.
+const cdipThredds = "https://thredds.cdip.ucsd.edu/thredds/dodsC/cdip/realtime/"
+
+var cdipStationRe = regexp.MustCompile(`^[0-9]{3}$`) // e.g. 100 (Torrey Pines Outer)
+
+func init() {
+	RegisterProvider(ProviderCDIP, func(c ProviderConfig) Provider {
+		return &cdipProvider{client: c.Client, station: c.CDIPStation}
+	})
+}
+
+type cdipProvider struct {
+	client  *http.Client
+	station string
+}
+
+var _ WaveProvider = (*cdipProvider)(nil)
+
+func (p *cdipProvider) Name() string { return ProviderCDIP }
+
+// ValidateCDIPStation checks a CDIP station ID's shape.
+func ValidateCDIPStation(id string) error {
+	if !cdipStationRe.MatchString(id) {
+		return fmt.Errorf("invalid CDIP station %q: CDIP IDs are 3 digits (e.g. 100)", id)
+	}
+	return nil
+}
+
+// ConfiguredCDIPStation returns `buoy.cdip_station`; there is no default.
+func ConfiguredCDIPStation() string {
+	return strings.TrimSpace(viper.GetString("buoy.cdip_station"))
+}
+
+func (p *cdipProvider) source() string { return p.stationID() }
+
+func (p *cdipProvider) stationID() string {
+	if p.station != "" {
+		return p.station
+	}
+	return ConfiguredCDIPStation()
+}
+
+// GetWaveSummary reads the latest bulk parameters from the station's
+// realtime file: its length from the DDS, then the last record as ASCII.
+// CDIP reports total significant height and peak period without a swell/wind
+// split, so the summary carries them as sig height at the peak period.
+func (p *cdipProvider) GetWaveSummary(ctx context.Context) (WaveSummary, error) {
+	station := p.stationID()
+	if station == "" {
+		return WaveSummary{}, errors.New("cdip needs a station: set buoy.cdip_station or the spot's --cdip-station")
+	}
+	if err := ValidateCDIPStation(station); err != nil {
+		return WaveSummary{}, err
+	}
+	file := cdipThredds + station + "p1_rt.nc"
+	dds, err := p.get(ctx, file+".dds", station)
+	if err != nil {
+		return WaveSummary{}, err
+	}
+	n, err := cdipRecords(dds)
+	if err != nil {
+		return WaveSummary{}, err
+	}
+	last := fmt.Sprintf("[%d:1:%d]", n-1, n-1)
+	vars := []string{"waveTime", "waveHs", "waveTp", "waveTa", "waveDp"}
+	query := strings.Join(vars, last+",") + last
+	body, err := p.get(ctx, file+".ascii?"+query, station)
+	if err != nil {
+		return WaveSummary{}, err
+	}
+	vals := parseDAPASCII(body)
+	hs, ok := vals["waveHs"]
+	if !ok {
+		return WaveSummary{}, fmt.Errorf("cdip %s: no wave height in response", station)
+	}
+	ws := WaveSummary{
+		provider:      ProviderCDIP,
+		stationId:     station,
+		time:          time.Unix(int64(vals["waveTime"]), 0).UTC(),
+		wvht:          hs,
+		swellPeriod:   vals["waveTp"],
+		averagePeriod: vals["waveTa"],
+	}
+	if dp, ok := vals["waveDp"]; ok {
+		ws.swellDirection = direction.Text(dp)
+		ws.meanWaveDirectionDeg = int(dp)
+	}
+	return ws, nil
+}
+
+// get fetches url as a string, treating 404 as an unknown station.
+func (p *cdipProvider) get(ctx context.Context, url, station string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w %s: CDIP has no realtime data for it (check buoy.cdip_station)", ErrUnknownStation, station)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.New("unexpected status code: " + resp.Status)
+	}
+	b, err := io.ReadAll(resp.Body)
+	return string(b), err
+}
+
+var cdipWaveTimeRe = regexp.MustCompile(`waveHs\[waveTime = (\d+)\]`)
+
+// cdipRecords returns the number of wave records from a DDS response.
+func cdipRecords(dds string) (int, error) {
+	m := cdipWaveTimeRe.FindStringSubmatch(dds)
+	if m == nil {
+		return 0, errors.New("cdip: no wave records for station")
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil || n == 0 {
+		return 0, errors.New("cdip: no wave records for station")
+	}
+	return n, nil
+}
+
+// parseDAPASCII reads the first value of each variable from an OPeNDAP ASCII
+// response, where values follow a "name[n]" header line or share the line as
+// "name[i], value". Grid members ("waveHs.waveHs") are read by member name.
+// Fill values (negative, e.g. -999.99) are left out.
+func parseDAPASCII(body string) map[string]float64 {
+	out := map[string]float64{}
+	_, data, _ := strings.Cut(body, "\n---")
+	sc := bufio.NewScanner(strings.NewReader(data))
+	sc.Scan() // rest of the separator line
+	pending := ""
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		name, value := pending, line
+		if pending == "" {
+			head, rest, found := strings.Cut(line, ",")
+			name, _, _ = strings.Cut(head, "[")
+			name = name[strings.LastIndex(name, ".")+1:]
+			if !found {
+				pending = name
+				continue
+			}
+			value = rest
+		}
+		pending = ""
+		first, _, _ := strings.Cut(value, ",")
+		if v, err := strconv.ParseFloat(strings.TrimSpace(first), 64); err == nil && v >= 0 {
+			if _, seen := out[name]; !seen {
+				out[name] = v
+			}
+		}
+	}
+	return out
+}
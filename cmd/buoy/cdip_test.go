@@ -0,0 +1,118 @@
+package buoy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The fixtures under testdata/cdip follow the THREDDS OPeNDAP responses for
+// station 100's realtime file: its DDS, and the ASCII reply to the
+// last-record query GetWaveSummary makes, with waveDp at its fill value.
+
+func readFixture(t *testing.T, name string) string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", "cdip", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestCDIPRecords(t *testing.T) {
+	n, err := cdipRecords(readFixture(t, "100p1_rt.nc.dds"))
+	if err != nil || n != 9480 {
+		t.Errorf("cdipRecords = %d, %v; want 9480", n, err)
+	}
+	if _, err := cdipRecords("Dataset {\n    Float32 waveHs[waveTime = 0];\n} x;"); err == nil {
+		t.Error("cdipRecords accepted a file with no records")
+	}
+	if _, err := cdipRecords("<html>Error 500</html>"); err == nil {
+		t.Error("cdipRecords accepted a response that is not a DDS")
+	}
+}
+
+func TestParseDAPASCII(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want map[string]float64
+	}{
+		{
+			name: "grid fixture with a fill value",
+			body: readFixture(t, "100p1_rt.nc.ascii"),
+			want: map[string]float64{"waveTime": 1717000200, "waveHs": 1.14, "waveTp": 13.333333, "waveTa": 8.221},
+		},
+		{
+			name: "single-line values",
+			body: "Dataset {\n} x;\n---------------------------------------------\n" +
+				"waveTime[0], 1717000200\nwaveHs.waveHs[0], 2.05\nwaveTp[0], 15.38\nwaveDp[0], -999.99\n",
+			want: map[string]float64{"waveTime": 1717000200, "waveHs": 2.05, "waveTp": 15.38},
+		},
+		{
+			name: "several values keep the first",
+			body: "Dataset {\n} x;\n---------------------------------------------\nwaveHs[2]\n0.9, 1.1\n",
+			want: map[string]float64{"waveHs": 0.9},
+		},
+		{
+			name: "no data section",
+			body: "Error {\n    code = 404;\n};\n",
+			want: map[string]float64{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := parseDAPASCII(tc.body)
+			if len(got) != len(tc.want) {
+				t.Errorf("parseDAPASCII = %v, want %v", got, tc.want)
+			}
+			for k, v := range tc.want {
+				if got[k] != v {
+					t.Errorf("%s = %v, want %v", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+// fixtureTransport answers THREDDS requests from testdata/cdip.
+type fixtureTransport struct {
+	t        *testing.T
+	requests []string
+}
+
+func (f *fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.requests = append(f.requests, req.URL.Path+"?"+req.URL.RawQuery)
+	name := filepath.Base(req.URL.Path)
+	body := readFixture(f.t, name)
+	return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(body)), Request: req}, nil
+}
+
+func TestCDIPGetWaveSummary(t *testing.T) {
+	rt := &fixtureTransport{t: t}
+	p := &cdipProvider{client: &http.Client{Transport: rt}, station: "100"}
+	ws, err := p.GetWaveSummary(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rt.requests) != 2 || !strings.Contains(rt.requests[1], "waveHs[9479:1:9479]") {
+		t.Errorf("requests = %q, want the DDS then record 9479", rt.requests)
+	}
+	if ws.Provider() != ProviderCDIP || ws.StationID() != "100" {
+		t.Errorf("source = %s %s, want cdip 100", ws.Provider(), ws.StationID())
+	}
+	if want := time.Unix(1717000200, 0).UTC(); !ws.time.Equal(want) {
+		t.Errorf("time = %v, want %v", ws.time, want)
+	}
+	if ws.wvht != 1.14 || ws.swellPeriod != 13.333333 || ws.averagePeriod != 8.221 {
+		t.Errorf("readings = %v m @ %vs (avg %vs), want 1.14 m @ 13.333333s (avg 8.221s)", ws.wvht, ws.swellPeriod, ws.averagePeriod)
+	}
+	if ws.swellDirection != "" || ws.meanWaveDirectionDeg != 0 {
+		t.Errorf("direction from a fill value: %q %d", ws.swellDirection, ws.meanWaveDirectionDeg)
+	}
+}
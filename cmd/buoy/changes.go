@@ -0,0 +1,144 @@
+package buoy
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/alerts"
+	"github.com/sumwatshade/surflog/cmd/direction"
+	"github.com/sumwatshade/surflog/cmd/hooks"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/units"
+)
+
+// defaultChangeThreshold is the relative wave height jump (50%) between
+// refreshes that counts as significant.
+const defaultChangeThreshold = 0.5
+
+// Below these a jump is noise: a 0.2m swell doubling is not news, nor is a
+// 3kt breeze changing direction.
+const (
+	minHeightJump = 0.3 // m
+	minWindSpeed  = 5.0 // kt
+)
+
+var changedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true)
+
+// ChangedMsg reports significant changes found by a background refresh, one
+// human-readable line each, for the UI to surface as a toast.
+type ChangedMsg struct {
+	Lines []string
+}
+
+// changes are the highlighted differences from the previous refresh, kept
+// until the next reading of the same kind arrives.
+type changes struct {
+	wave string // e.g. "▲ 3.2ft → 5.1ft (+59%)"
+	wind string // e.g. "wind switched onshore (E → WSW)"
+}
+
+func changeThreshold() float64 {
+	if t := viper.GetFloat64("buoy.change_threshold"); t > 0 {
+		return t
+	}
+	return defaultChangeThreshold
+}
+
+// offshoreFrom returns the configured `buoy.offshore_from` direction (where
+// an offshore wind blows from at the spot), if any.
+func offshoreFrom() (float64, bool) {
+	return direction.Degrees(viper.GetString("buoy.offshore_from"))
+}
+
+// waveChange describes a significant height change between two readings,
+// or "" when there is none.
+func waveChange(prev, cur WaveSummary, threshold float64) string {
+	if prev.wvht <= 0 || cur.wvht <= 0 || math.Abs(cur.wvht-prev.wvht) < minHeightJump {
+		return ""
+	}
+	rel := (cur.wvht - prev.wvht) / prev.wvht
+	if math.Abs(rel) < threshold {
+		return ""
+	}
+	arrow := "▲"
+	if rel < 0 {
+		arrow = "▼"
+	}
+	return i18n.T("%s %s → %s (%+.0f%%)", arrow, units.Height(prev.wvht), units.Height(cur.wvht), rel*100)
+}
+
+// windChange describes the wind turning onshore (when `buoy.offshore_from`
+// is set) or otherwise swinging 90° or more, or "" when neither happened.
+func windChange(prev, cur WindSummary) string {
+	if !prev.hasDirection || !cur.hasDirection || cur.speed*MSToKnots < minWindSpeed {
+		return ""
+	}
+	from, to := direction.Text(prev.directionDeg), direction.Text(cur.directionDeg)
+	if off, ok := offshoreFrom(); ok {
+		if onshore(prev.directionDeg, off) || !onshore(cur.directionDeg, off) {
+			return ""
+		}
+		return i18n.T("wind switched onshore (%s → %s)", from, to)
+	}
+	if direction.Diff(prev.directionDeg, cur.directionDeg) < 90 {
+		return ""
+	}
+	return i18n.T("wind swung %s → %s", from, to)
+}
+
+// onshore reports whether wind from deg blows within 67.5° of straight
+// onshore, i.e. opposite the offshore direction.
+func onshore(deg, offshore float64) bool {
+	return direction.Diff(deg, offshore) > 112.5
+}
+
+// changedCmd emits a ChangedMsg for lines, and with `buoy.notify_changes`
+// also a desktop notification outside quiet hours and snoozes. It never
+// falls back to writing to the terminal, which the TUI owns; the
+// ChangedMsg toast covers systems without a notifier. The alert hook runs
+// either way.
+func changedCmd(lines ...string) tea.Cmd {
+	var out []string
+	for _, l := range lines {
+		if l != "" {
+			out = append(out, l)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		msg := strings.Join(out, "; ")
+		if viper.GetBool("buoy.notify_changes") && alerts.ShouldNotify("conditions", time.Now()) {
+			alerts.DesktopNotify("surflog", msg)
+		}
+		_ = hooks.Run(context.Background(), hooks.Alert, hooks.AlertEvent{Rule: "conditions", Message: msg, Time: time.Now()},
+			"SURFLOG_RULE=conditions", "SURFLOG_MESSAGE="+msg)
+		return ChangedMsg{Lines: out}
+	}
+}
+
+// noteWave compares a freshly fetched reading with the one it replaces and
+// returns a ChangedMsg command when it moved significantly. The first load
+// and repeats of the same observation leave the highlight alone.
+func (b *BuoyData) noteWave(prev *WaveSummary, cur WaveSummary) tea.Cmd {
+	if prev == nil || cur.time.Equal(prev.time) {
+		return nil
+	}
+	b.changed.wave = waveChange(*prev, cur, changeThreshold())
+	return changedCmd(b.changed.wave)
+}
+
+// noteWind is noteWave for wind readings.
+func (b *BuoyData) noteWind(prev *WindSummary, cur WindSummary) tea.Cmd {
+	if prev == nil || cur.time.Equal(prev.time) {
+		return nil
+	}
+	b.changed.wind = windChange(*prev, cur)
+	return changedCmd(b.changed.wind)
+}
@@ -0,0 +1,149 @@
+package buoy
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// compactBucket is the resolution compacted readings are averaged to.
+const compactBucket = 3 * time.Hour
+
+// CompactAfter is the age past which observations are compacted:
+// `archive.compact_after_months` (default 3) thirty-day months.
+func CompactAfter() time.Duration {
+	months := viper.GetInt("archive.compact_after_months")
+	if months <= 0 {
+		months = 3
+	}
+	return time.Duration(months) * 30 * 24 * time.Hour
+}
+
+func (a *WaterTempArchive) coldPath(station string) string {
+	return filepath.Join(a.dir, station+".archive.json.gz")
+}
+
+func (a *WaterTempArchive) readCold(station string) ([]WaterTempReading, error) {
+	f, err := os.Open(a.coldPath(station))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	var out []WaterTempReading
+	return out, json.NewDecoder(zr).Decode(&out)
+}
+
+// writeCold replaces the compacted file atomically so an interrupted
+// compaction never loses history.
+func (a *WaterTempArchive) writeCold(station string, readings []WaterTempReading) error {
+	tmp := a.coldPath(station) + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(f)
+	err = json.NewEncoder(zw).Encode(readings)
+	if cerr := zw.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, a.coldPath(station))
+}
+
+// Compact moves readings older than before out of the station's recent
+// archive into its gzip-compressed one, averaged into 3-hour buckets. before
+// is rounded down to a bucket boundary so a bucket is never split between
+// the two. It returns how many readings were moved.
+func (a *WaterTempArchive) Compact(station string, before time.Time) (int, error) {
+	before = before.UTC().Truncate(compactBucket)
+	hot, err := a.readHot(station)
+	if err != nil {
+		return 0, err
+	}
+	var old, keep []WaterTempReading
+	for _, r := range hot {
+		if r.Time.Before(before) {
+			old = append(old, r)
+		} else {
+			keep = append(keep, r)
+		}
+	}
+	if len(old) == 0 {
+		return 0, nil
+	}
+	cold, err := a.readCold(station)
+	if err != nil {
+		return 0, err
+	}
+	if err := a.writeCold(station, downsample(append(cold, old...), compactBucket)); err != nil {
+		return 0, err
+	}
+	if err := a.writeHot(station, keep); err != nil {
+		return 0, err
+	}
+	return len(old), nil
+}
+
+// downsample averages readings into bucket-wide slots stamped at the slot
+// start. Already-downsampled readings count for as many observations as they
+// average, so folding new readings into a slot keeps its mean exact.
+func downsample(readings []WaterTempReading, bucket time.Duration) []WaterTempReading {
+	type acc struct {
+		sum float64
+		n   int
+	}
+	slots := map[time.Time]*acc{}
+	for _, r := range readings {
+		t := r.Time.UTC().Truncate(bucket)
+		if slots[t] == nil {
+			slots[t] = &acc{}
+		}
+		n := max(r.N, 1)
+		slots[t].sum += r.C * float64(n)
+		slots[t].n += n
+	}
+	out := make([]WaterTempReading, 0, len(slots))
+	for t, s := range slots {
+		out = append(out, WaterTempReading{Time: t, C: s.sum / float64(s.n), N: s.n})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Time.Before(out[j].Time) })
+	return out
+}
+
+// Stations lists the stations with archived readings.
+func (a *WaterTempArchive) Stations() ([]string, error) {
+	files, err := os.ReadDir(a.dir)
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() || !strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".archive.json") {
+			continue
+		}
+		out = append(out, strings.TrimSuffix(name, ".json"))
+	}
+	return out, nil
+}
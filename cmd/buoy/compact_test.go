@@ -0,0 +1,53 @@
+package buoy
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDownsampleWeightsCompacted(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	got := downsample([]WaterTempReading{
+		{Time: t0, C: 10, N: 2}, // 10 and 10 from an earlier compaction
+		{Time: t0.Add(2 * time.Hour), C: 16},
+	}, compactBucket)
+	if len(got) != 1 || got[0].C != 12 || got[0].N != 3 {
+		t.Errorf("downsample = %+v, want one reading of 12°C over 3", got)
+	}
+}
+
+// TestCompactMidBucket compacts twice with cutoffs inside a bucket and
+// checks the bucket ends up with the mean of all its readings.
+func TestCompactMidBucket(t *testing.T) {
+	a, err := NewWaterTempArchive(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var hot []WaterTempReading
+	for h, c := range []float64{10, 11, 15, 20, 20, 20} {
+		hot = append(hot, WaterTempReading{Time: t0.Add(time.Duration(h) * time.Hour), C: c})
+	}
+	if err := a.writeHot("46026", hot); err != nil {
+		t.Fatal(err)
+	}
+	for _, cutoff := range []time.Duration{90 * time.Minute, 4 * time.Hour, 7 * time.Hour} {
+		if _, err := a.Compact("46026", t0.Add(cutoff)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := a.List("46026")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []WaterTempReading{{Time: t0, C: 12, N: 3}, {Time: t0.Add(3 * time.Hour), C: 20, N: 3}}
+	if len(got) != len(want) {
+		t.Fatalf("List = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if !got[i].Time.Equal(want[i].Time) || math.Abs(got[i].C-want[i].C) > 1e-9 || got[i].N != want[i].N {
+			t.Errorf("reading %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
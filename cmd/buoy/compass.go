@@ -0,0 +1,78 @@
+package buoy
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sumwatshade/surflog/cmd/direction"
+)
+
+// Compass rose size: a character is about twice as tall as it is wide, so
+// the horizontal radius is doubled to keep it round.
+const (
+	compassRX = 6
+	compassRY = 3
+)
+
+// Compass colors, from the ocean palette: the primary swell stands out, wind
+// is the lightest.
+var (
+	compassSwellStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("44")).Bold(true)
+	compassWindWaveStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("30"))
+	compassWindStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("159"))
+	compassRingStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("238"))
+	compassLabelStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
+)
+
+// arrows point the way something travels, clockwise from north in 45° steps.
+var arrows = []string{"↑", "↗", "→", "↘", "↓", "↙", "←", "↖"}
+
+// compassMark is one direction plotted on the rose.
+type compassMark struct {
+	from  float64 // degrees true the swell or wind comes from
+	style lipgloss.Style
+}
+
+// arrow returns the glyph for something coming from deg, i.e. heading the
+// opposite way.
+func arrow(from float64) string {
+	return arrows[int(math.Round(direction.Normalize(from+180)/45))%len(arrows)]
+}
+
+// compassRose draws a small rose with each mark as an arrow on the side it
+// comes from, pointing the way it travels. Later marks are drawn over earlier
+// ones.
+func compassRose(marks []compassMark) string {
+	w, h := 2*compassRX+1, 2*compassRY+1
+	grid := make([][]string, h)
+	for y := range grid {
+		grid[y] = make([]string, w)
+		for x := range grid[y] {
+			grid[y][x] = " "
+		}
+	}
+	plot := func(deg, r float64, s string) {
+		rad := deg * math.Pi / 180
+		x := compassRX + int(math.Round(math.Sin(rad)*r*compassRX))
+		y := compassRY - int(math.Round(math.Cos(rad)*r*compassRY))
+		grid[y][x] = s
+	}
+	for deg := 0.0; deg < 360; deg += 30 {
+		plot(deg, 1, compassRingStyle.Render("·"))
+	}
+	for deg, label := range map[float64]string{0: "N", 90: "E", 180: "S", 270: "W"} {
+		plot(deg, 1, compassLabelStyle.Render(label))
+	}
+	grid[compassRY][compassRX] = compassRingStyle.Render("+")
+	for _, m := range marks {
+		a := m.style.Render(arrow(m.from))
+		plot(m.from, 0.7, a)
+		plot(m.from, 0.35, a)
+	}
+	rows := make([]string, h)
+	for y, row := range grid {
+		rows[y] = strings.Join(row, "")
+	}
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,26 @@
+package buoy
+
+import (
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/solar"
+	"github.com/sumwatshade/surflog/cmd/spots"
+)
+
+// renderDaylightSection shows how much light is left in the afternoon, for the
+// after-work go/no-go call. Empty before noon and after sunset.
+func renderDaylightSection(now time.Time) section {
+	sec := newSection("")
+	if now.Hour() < 12 {
+		return sec
+	}
+	lat, lon := spots.ActiveLocation()
+	sunset, ok := solar.Sunset(now, lat, lon)
+	if !ok || !now.Before(sunset) {
+		return sec
+	}
+	left := sunset.Sub(now).Round(time.Minute)
+	sec.add(i18n.T("%dh %02dm of light left (sunset %s)", int(left.Hours()), int(left.Minutes())%60, i18n.Time(sunset)))
+	return sec
+}
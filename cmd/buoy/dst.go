@@ -0,0 +1,67 @@
+package buoy
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/i18n"
+)
+
+// clockChange finds a daylight-saving transition between begin and end (in
+// begin's location), returning its instant and how far clocks moved
+// (positive when they go forward). Charts plot real elapsed time, so a
+// transition day is 23 or 25 hours wide and its labels skip or repeat an
+// hour; the note from dstNote explains that.
+func clockChange(begin, end time.Time) (at time.Time, shift time.Duration, ok bool) {
+	_, from := begin.Zone()
+	_, to := end.In(begin.Location()).Zone()
+	if from == to || !begin.Before(end) {
+		return time.Time{}, 0, false
+	}
+	// offsets change once in a day's range, so bisect to the minute
+	lo, hi := begin, end.In(begin.Location())
+	for hi.Sub(lo) > time.Minute {
+		mid := lo.Add(hi.Sub(lo) / 2)
+		if _, off := mid.Zone(); off == from {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi.Truncate(time.Minute), time.Duration(to-from) * time.Second, true
+}
+
+// dstNote describes a clock change between begin and end, e.g. "clocks go
+// back 1h at 02:00 (PDT → PST)", or "" when there is none.
+func dstNote(begin, end time.Time) string {
+	at, shift, ok := clockChange(begin, end)
+	if !ok {
+		return ""
+	}
+	fromName, fromOff := at.Add(-time.Minute).Zone()
+	toName, _ := at.Zone()
+	wall := i18n.Time(at.In(time.FixedZone(fromName, fromOff))) // the clock reading it happens at
+	if shift > 0 {
+		return i18n.T("clocks go forward %s at %s (%s → %s)", formatShift(shift), wall, fromName, toName)
+	}
+	return i18n.T("clocks go back %s at %s (%s → %s)", formatShift(-shift), wall, fromName, toName)
+}
+
+// formatShift renders a clock change, e.g. "1h" or "30m" (Lord Howe Island).
+func formatShift(d time.Duration) string {
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
+
+// zoneSpan names the zone over a range, e.g. "PST", or "PDT/PST" across a
+// clock change.
+func zoneSpan(begin, end time.Time) string {
+	from, _ := begin.Zone()
+	to, _ := end.In(begin.Location()).Zone()
+	if from == to {
+		return from
+	}
+	return from + "/" + to
+}
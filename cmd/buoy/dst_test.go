@@ -0,0 +1,131 @@
+package buoy
+
+import (
+	"math"
+	"testing"
+	"time"
+	_ "time/tzdata" // zones for the tables below, whatever the host has
+
+	"github.com/NimbleMarkets/ntcharts/linechart/timeserieslinechart"
+	"github.com/spf13/viper"
+)
+
+func mustZone(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestClockChange(t *testing.T) {
+	la := mustZone(t, "America/Los_Angeles")
+	lordHowe := mustZone(t, "Australia/Lord_Howe")
+	tests := []struct {
+		name   string
+		loc    *time.Location
+		day    time.Time // midnight of the local day
+		dayLen time.Duration
+		at     time.Time
+		shift  time.Duration
+		ok     bool
+	}{
+		{"spring forward", la, time.Date(2024, 3, 10, 0, 0, 0, 0, la), 23 * time.Hour,
+			time.Date(2024, 3, 10, 10, 0, 0, 0, time.UTC), time.Hour, true},
+		{"fall back", la, time.Date(2024, 11, 3, 0, 0, 0, 0, la), 25 * time.Hour,
+			time.Date(2024, 11, 3, 9, 0, 0, 0, time.UTC), -time.Hour, true},
+		{"ordinary day", la, time.Date(2024, 7, 4, 0, 0, 0, 0, la), 24 * time.Hour,
+			time.Time{}, 0, false},
+		{"half-hour forward", lordHowe, time.Date(2024, 10, 6, 0, 0, 0, 0, lordHowe), 23*time.Hour + 30*time.Minute,
+			time.Date(2024, 10, 5, 15, 30, 0, 0, time.UTC), 30 * time.Minute, true},
+		{"half-hour back", lordHowe, time.Date(2024, 4, 7, 0, 0, 0, 0, lordHowe), 24*time.Hour + 30*time.Minute,
+			time.Date(2024, 4, 6, 15, 0, 0, 0, time.UTC), -30 * time.Minute, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			begin, end := tideDayBounds(tc.day, 0)
+			if got := end.Sub(begin) + time.Minute; got != tc.dayLen {
+				t.Errorf("day is %v long, want %v", got, tc.dayLen)
+			}
+			at, shift, ok := clockChange(begin, end)
+			if ok != tc.ok || !at.Equal(tc.at) || shift != tc.shift {
+				t.Errorf("clockChange = %v, %v, %v; want %v, %v, %v", at.UTC(), shift, ok, tc.at, tc.shift, tc.ok)
+			}
+		})
+	}
+}
+
+func TestDSTNoteAndZoneSpan(t *testing.T) {
+	viper.Set("locale", "en")
+	viper.Set("display.clock", 24)
+	t.Cleanup(func() { viper.Set("display.clock", nil) })
+	la := mustZone(t, "America/Los_Angeles")
+	lordHowe := mustZone(t, "Australia/Lord_Howe")
+	tests := []struct {
+		name string
+		day  time.Time
+		note string
+		span string
+	}{
+		{"spring forward", time.Date(2024, 3, 10, 0, 0, 0, 0, la), "clocks go forward 1h at 02:00 (PST → PDT)", "PST/PDT"},
+		{"fall back", time.Date(2024, 11, 3, 0, 0, 0, 0, la), "clocks go back 1h at 02:00 (PDT → PST)", "PDT/PST"},
+		{"ordinary day", time.Date(2024, 7, 4, 0, 0, 0, 0, la), "", "PDT"},
+		{"half-hour forward", time.Date(2024, 10, 6, 0, 0, 0, 0, lordHowe), "clocks go forward 30m at 02:00 (+1030 → +11)", "+1030/+11"},
+		{"half-hour back", time.Date(2024, 4, 7, 0, 0, 0, 0, lordHowe), "clocks go back 30m at 02:00 (+11 → +1030)", "+11/+1030"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			begin, end := tideDayBounds(tc.day, 0)
+			if got := dstNote(begin, end); got != tc.note {
+				t.Errorf("dstNote = %q, want %q", got, tc.note)
+			}
+			if got := zoneSpan(begin, end); got != tc.span {
+				t.Errorf("zoneSpan = %q, want %q", got, tc.span)
+			}
+		})
+	}
+}
+
+// TestNowColumn checks the current-time line sits at the elapsed-time
+// fraction of the day, which differs from the wall-clock one when the
+// clocks change.
+func TestNowColumn(t *testing.T) {
+	la := mustZone(t, "America/Los_Angeles")
+	lordHowe := mustZone(t, "Australia/Lord_Howe")
+	tests := []struct {
+		name       string
+		begin, end time.Time
+		now        time.Time
+		frac       float64 // of the elapsed span
+	}{
+		// 00:00 PST to 23:00 PDT is 22h; noon PDT is 11h in
+		{"spring forward", time.Date(2024, 3, 10, 0, 0, 0, 0, la), time.Date(2024, 3, 10, 23, 0, 0, 0, la),
+			time.Date(2024, 3, 10, 12, 0, 0, 0, la), 0.5},
+		// 00:00 PDT to 23:00 PST is 24h; 11:00 PST is 12h in
+		{"fall back", time.Date(2024, 11, 3, 0, 0, 0, 0, la), time.Date(2024, 11, 3, 23, 0, 0, 0, la),
+			time.Date(2024, 11, 3, 11, 0, 0, 0, la), 0.5},
+		{"ordinary day", time.Date(2024, 7, 4, 0, 0, 0, 0, la), time.Date(2024, 7, 4, 23, 0, 0, 0, la),
+			time.Date(2024, 7, 4, 11, 30, 0, 0, la), 0.5},
+		// 00:00 +1030 to 23:00 +11 is 22h30m; 12:00 +11 is 11h30m in
+		{"half-hour forward", time.Date(2024, 10, 6, 0, 0, 0, 0, lordHowe), time.Date(2024, 10, 6, 23, 0, 0, 0, lordHowe),
+			time.Date(2024, 10, 6, 12, 0, 0, 0, lordHowe), 11.5 / 22.5},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lc := timeserieslinechart.New(100, 10)
+			lc.SetTimeRange(tc.begin, tc.end)
+			lc.SetViewTimeAndYRange(tc.begin, tc.end, 0, 1)
+			first, ok1 := nowColumn(&lc, tc.begin)
+			last, ok2 := nowColumn(&lc, tc.end)
+			col, ok := nowColumn(&lc, tc.now)
+			if !ok || !ok1 || !ok2 {
+				t.Fatalf("nowColumn off the canvas: %v %v %v", ok1, ok, ok2)
+			}
+			want := first + int(math.Round(tc.frac*float64(last-first)))
+			if col != want {
+				t.Errorf("now column = %d, want %d (first %d, last %d)", col, want, first, last)
+			}
+		})
+	}
+}
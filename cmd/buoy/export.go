@@ -0,0 +1,40 @@
+package buoy
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/units"
+)
+
+// ExportFormats lists the formats WriteWaterTemps accepts.
+var ExportFormats = []string{"csv"}
+
+// WriteWaterTemps writes a station's archived water temperatures, one row
+// per reading with columns station, time (UTC) and water_temp_c (or
+// water_temp_f, per the display unit), as csv.
+func WriteWaterTemps(w io.Writer, format, station string, readings []WaterTempReading) error {
+	column := "water_temp_f"
+	if units.Celsius() {
+		column = "water_temp_c"
+	}
+	// converted readings are rounded so they don't carry float noise
+	temp := func(c float64) float64 { return math.Round(i18n.TemperatureValue(c)*100) / 100 }
+	switch strings.ToLower(format) {
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"station", "time", column})
+		for _, r := range readings {
+			cw.Write([]string{station, r.Time.UTC().Format(time.RFC3339), strconv.FormatFloat(temp(r.C), 'f', -1, 64)})
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+	return fmt.Errorf("unknown export format %q (want %s)", format, strings.Join(ExportFormats, " or "))
+}
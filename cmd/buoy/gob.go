@@ -0,0 +1,204 @@
+package buoy
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+)
+
+// The journal keeps a gob index of decoded entries so listing does not
+// re-parse every JSON file. gob cannot see unexported fields, so the
+// summaries encode themselves as a flat sequence of fields. Bump
+// summaryCodecVersion whenever the field list changes.
+const summaryCodecVersion = 1
+
+var errShortSummary = errors.New("buoy: truncated summary encoding")
+
+type fieldWriter struct{ b []byte }
+
+func (w *fieldWriter) str(s string) {
+	w.b = binary.AppendUvarint(w.b, uint64(len(s)))
+	w.b = append(w.b, s...)
+}
+
+func (w *fieldWriter) f64(f float64) {
+	w.b = binary.LittleEndian.AppendUint64(w.b, math.Float64bits(f))
+}
+
+func (w *fieldWriter) int(i int) { w.b = binary.AppendVarint(w.b, int64(i)) }
+
+func (w *fieldWriter) bool(v bool) {
+	if v {
+		w.b = append(w.b, 1)
+	} else {
+		w.b = append(w.b, 0)
+	}
+}
+
+func (w *fieldWriter) time(t time.Time) error {
+	tb, err := t.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	w.str(string(tb))
+	return nil
+}
+
+type fieldReader struct {
+	b   []byte
+	err error
+}
+
+func (r *fieldReader) take(n int) []byte {
+	if r.err != nil || n < 0 || n > len(r.b) {
+		r.err = errShortSummary
+		return nil
+	}
+	out := r.b[:n]
+	r.b = r.b[n:]
+	return out
+}
+
+func (r *fieldReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.b)
+	if n <= 0 {
+		r.err = errShortSummary
+		return 0
+	}
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *fieldReader) str() string {
+	n := r.uvarint()
+	if n > uint64(len(r.b)) {
+		r.err = errShortSummary
+		return ""
+	}
+	return string(r.take(int(n)))
+}
+
+func (r *fieldReader) f64() float64 {
+	b := r.take(8)
+	if b == nil {
+		return 0
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(b))
+}
+
+func (r *fieldReader) int() int {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(r.b)
+	if n <= 0 {
+		r.err = errShortSummary
+		return 0
+	}
+	r.b = r.b[n:]
+	return int(v)
+}
+
+func (r *fieldReader) bool() bool {
+	b := r.take(1)
+	return b != nil && b[0] == 1
+}
+
+func (r *fieldReader) time() time.Time {
+	var t time.Time
+	if s := r.str(); r.err == nil {
+		r.err = t.UnmarshalBinary([]byte(s))
+	}
+	return t
+}
+
+// version checks the leading codec version written by GobEncode.
+func (r *fieldReader) version() {
+	if v := r.uvarint(); r.err == nil && v != summaryCodecVersion {
+		r.err = errors.New("buoy: unknown summary encoding version")
+	}
+}
+
+// GobEncode lets the journal index store a summary without going
+// through JSON. The trend is not persisted, matching MarshalJSON.
+func (w WaveSummary) GobEncode() ([]byte, error) {
+	fw := fieldWriter{b: binary.AppendUvarint(nil, summaryCodecVersion)}
+	fw.str(w.provider)
+	fw.str(w.stationId)
+	if err := fw.time(w.time); err != nil {
+		return nil, err
+	}
+	for _, f := range []float64{w.wvht, w.swellHeight, w.swellPeriod, w.windWaveHeight, w.windWavePeriod, w.averagePeriod} {
+		fw.f64(f)
+	}
+	fw.str(w.swellDirection)
+	fw.str(w.windWaveDirection)
+	fw.str(w.steepness)
+	fw.int(w.meanWaveDirectionDeg)
+	return fw.b, nil
+}
+
+// GobDecode reverses GobEncode.
+func (w *WaveSummary) GobDecode(b []byte) error {
+	r := fieldReader{b: b}
+	r.version()
+	var s WaveSummary
+	s.provider = r.str()
+	s.stationId = r.str()
+	s.time = r.time()
+	for _, f := range []*float64{&s.wvht, &s.swellHeight, &s.swellPeriod, &s.windWaveHeight, &s.windWavePeriod, &s.averagePeriod} {
+		*f = r.f64()
+	}
+	s.swellDirection = r.str()
+	s.windWaveDirection = r.str()
+	s.steepness = r.str()
+	s.meanWaveDirectionDeg = r.int()
+	if r.err != nil {
+		return r.err
+	}
+	*w = s
+	return nil
+}
+
+// GobEncode lets the journal index store a wind reading without going
+// through JSON.
+func (w WindSummary) GobEncode() ([]byte, error) {
+	fw := fieldWriter{b: binary.AppendUvarint(nil, summaryCodecVersion)}
+	fw.str(w.provider)
+	fw.str(w.stationId)
+	if err := fw.time(w.time); err != nil {
+		return nil, err
+	}
+	for _, f := range []float64{w.speed, w.gust, w.directionDeg, w.pressure} {
+		fw.f64(f)
+	}
+	fw.bool(w.hasGust)
+	fw.bool(w.hasDirection)
+	fw.bool(w.hasPressure)
+	return fw.b, nil
+}
+
+// GobDecode reverses GobEncode.
+func (w *WindSummary) GobDecode(b []byte) error {
+	r := fieldReader{b: b}
+	r.version()
+	var s WindSummary
+	s.provider = r.str()
+	s.stationId = r.str()
+	s.time = r.time()
+	for _, f := range []*float64{&s.speed, &s.gust, &s.directionDeg, &s.pressure} {
+		*f = r.f64()
+	}
+	s.hasGust = r.bool()
+	s.hasDirection = r.bool()
+	s.hasPressure = r.bool()
+	if r.err != nil {
+		return r.err
+	}
+	*w = s
+	return nil
+}
@@ -0,0 +1,81 @@
+package buoy
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// Hindcast is the archived conditions nearest a past session time, for
+// logging old trips after the fact.
+type Hindcast struct {
+	Wave   WaveSummary
+	Wind   *WindSummary    // nil when the archive has no wind near that time
+	WaterC *float64        // nil likewise
+	Tide   *TidePrediction // predicted level at the session time; nil if the lookup failed
+}
+
+// GetHindcast looks up waves as GetWaveSummaryAt does, plus wind and water
+// temperature from the same met archive and the predicted tide level at at.
+// Only a missing wave observation is an error; the rest is best-effort.
+func (s *dataService) GetHindcast(ctx context.Context, at time.Time) (Hindcast, error) {
+	stationID := s.buoyStationID()
+	if err := ValidateBuoyStation(stationID); err != nil {
+		return Hindcast{}, err
+	}
+	var h Hindcast
+	ok := false
+	if time.Since(at) < realtimeWindow {
+		h.Wave, ok = s.specSummaryAt(ctx, stationID, at)
+	}
+	url, label := historyURL(stationID, at.UTC(), time.Now().UTC())
+	rows, err := s.fetchMetFile(ctx, url, label, 0)
+	if err != nil && !ok {
+		return Hindcast{}, err
+	}
+	if !ok {
+		if h.Wave, err = waveFromMet(stationID, rows, at); err != nil {
+			return Hindcast{}, err
+		}
+	}
+	if r, ok := nearestMetRow(rows, at, "WSPD", 99); ok {
+		w := WindSummary{stationId: stationID, time: r.time}
+		w.speed, _ = r.get("WSPD")
+		if v, ok := r.get("GST"); ok && v < 99 {
+			w.gust, w.hasGust = v, true
+		}
+		if v, ok := r.get("WDIR"); ok && v < 999 {
+			w.directionDeg, w.hasDirection = v, true
+		}
+		if v, ok := r.get("PRES"); ok && v < 9999 {
+			w.pressure, w.hasPressure = v, true
+		}
+		h.Wind = &w
+	}
+	if r, ok := nearestMetRow(rows, at, "WTMP", 999); ok {
+		c, _ := r.get("WTMP")
+		h.WaterC = &c
+	}
+	if td, err := s.GetTideData(ctx, at.Add(-2*time.Hour), at.Add(2*time.Hour)); err == nil {
+		if p, ok := td.LevelAt(at); ok {
+			h.Tide = &p
+		}
+	}
+	return h, nil
+}
+
+// LevelAt interpolates the predicted water level at t; ok is false when t is
+// outside the series (or there is none, in low-bandwidth mode).
+func (td TideData) LevelAt(t time.Time) (TidePrediction, bool) {
+	preds := td.Predictions()
+	i := sort.Search(len(preds), func(i int) bool { return !preds[i].Time.Before(t) })
+	if i == len(preds) || (i == 0 && preds[0].Time.After(t)) {
+		return TidePrediction{}, false
+	}
+	if i == 0 || preds[i].Time.Equal(t) {
+		return TidePrediction{Time: t, Height: preds[i].Height}, true
+	}
+	a, b := preds[i-1], preds[i]
+	frac := float64(t.Sub(a.Time)) / float64(b.Time.Sub(a.Time))
+	return TidePrediction{Time: t, Height: a.Height + frac*(b.Height-a.Height)}, true
+}
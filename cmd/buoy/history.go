@@ -0,0 +1,124 @@
+package buoy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxHistoryGap is how far the nearest observation may be from the requested
+// time before a lookup is considered a miss.
+const maxHistoryGap = 90 * time.Minute
+
+// realtimeWindow is roughly how much history NDBC keeps in realtime2 files.
+const realtimeWindow = 44 * 24 * time.Hour
+
+// ErrNoObservation is returned when no observation is close enough to the
+// requested time.
+var ErrNoObservation = errors.New("no buoy observation near that time")
+
+// GetWaveSummaryAt returns the observation nearest to at. Recent times read
+// the realtime .spec file, which has the full swell/wind-wave split; when
+// that misses (or for older times) it falls back to the standard met file or
+// NDBC's monthly (current year) and yearly historical archives. Those carry no
+// split, so the dominant period is reported as the swell period.
+func (s *dataService) GetWaveSummaryAt(ctx context.Context, at time.Time) (WaveSummary, error) {
+	stationID := s.buoyStationID()
+	if err := ValidateBuoyStation(stationID); err != nil {
+		return WaveSummary{}, err
+	}
+	if time.Since(at) < realtimeWindow {
+		if ws, ok := s.specSummaryAt(ctx, stationID, at); ok {
+			return ws, nil
+		}
+	}
+	url, label := historyURL(stationID, at.UTC(), time.Now().UTC())
+	rows, err := s.fetchMetFile(ctx, url, label, 0)
+	if err != nil {
+		return WaveSummary{}, err
+	}
+	return waveFromMet(stationID, rows, at)
+}
+
+// nearestMetRow picks the row closest to at that reports col below missing
+// (the archives' placeholder for no reading), within maxHistoryGap.
+func nearestMetRow(rows []metRow, at time.Time, col string, missing float64) (metRow, bool) {
+	best, bestGap := -1, time.Duration(0)
+	for i := range rows {
+		if v, ok := rows[i].get(col); !ok || v >= missing {
+			continue
+		}
+		gap := rows[i].time.Sub(at)
+		if gap < 0 {
+			gap = -gap
+		}
+		if best < 0 || gap < bestGap {
+			best, bestGap = i, gap
+		}
+	}
+	if best < 0 || bestGap > maxHistoryGap {
+		return metRow{}, false
+	}
+	return rows[best], true
+}
+
+// waveFromMet builds a wave summary from the met row nearest to at. Met files
+// carry no swell/wind-wave split, so the dominant period is reported as the
+// swell period.
+func waveFromMet(stationID string, rows []metRow, at time.Time) (WaveSummary, error) {
+	best, ok := nearestMetRow(rows, at, "WVHT", 99)
+	if !ok {
+		return WaveSummary{}, ErrNoObservation
+	}
+	ws := WaveSummary{stationId: stationID, time: best.time}
+	ws.wvht, _ = best.get("WVHT")
+	if dpd, ok := best.get("DPD"); ok && dpd < 99 {
+		ws.swellPeriod = dpd
+	}
+	if apd, ok := best.get("APD"); ok && apd < 99 {
+		ws.averagePeriod = apd
+	}
+	if mwd, ok := best.get("MWD"); ok && mwd < 999 {
+		ws.meanWaveDirectionDeg = int(mwd + 0.5)
+	}
+	return ws, nil
+}
+
+// specSummaryAt picks the realtime .spec row nearest to at, if one is within
+// maxHistoryGap.
+func (s *dataService) specSummaryAt(ctx context.Context, stationID string, at time.Time) (WaveSummary, bool) {
+	rows, err := s.fetchSpecRows(ctx, stationID, 0)
+	if err != nil {
+		return WaveSummary{}, false
+	}
+	best, bestGap := -1, time.Duration(0)
+	for i, r := range rows {
+		gap := r.time.Sub(at)
+		if gap < 0 {
+			gap = -gap
+		}
+		if best < 0 || gap < bestGap {
+			best, bestGap = i, gap
+		}
+	}
+	if best < 0 || bestGap > maxHistoryGap {
+		return WaveSummary{}, false
+	}
+	return rows[best], true
+}
+
+// historyURL picks the file covering at: realtime2 for the last ~45 days, the
+// monthly archive for earlier months of this year, the yearly archive before.
+// label names it for progress reports, e.g. "46274 Jun 2023".
+func historyURL(stationID string, at, now time.Time) (url, label string) {
+	if now.Sub(at) < realtimeWindow {
+		return "https://www.ndbc.noaa.gov/data/realtime2/" + stationID + ".txt", stationID + " realtime"
+	}
+	if at.Year() == now.Year() {
+		return fmt.Sprintf("https://www.ndbc.noaa.gov/view_text_file.php?filename=%s%x%d.txt.gz&dir=data/stdmet/%s/",
+			stationID, int(at.Month()), at.Year(), at.Format("Jan")), stationID + " " + at.Format("Jan 2006")
+	}
+	return fmt.Sprintf("https://www.ndbc.noaa.gov/view_text_file.php?filename=%sh%d.txt.gz&dir=data/historical/stdmet/",
+		stationID, at.Year()), fmt.Sprintf("%s %d", stationID, at.Year())
+}
@@ -0,0 +1,158 @@
+package buoy
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/netclient"
+)
+
+// metRow is a single observation from an NDBC standard meteorological (.txt)
+// file, with values keyed by column name (WDIR, WSPD, ATMP, WTMP, ...).
+// Missing readings ("MM") are omitted from values.
+type metRow struct {
+	time   time.Time
+	values map[string]float64
+}
+
+func (r metRow) get(col string) (float64, bool) {
+	v, ok := r.values[col]
+	return v, ok
+}
+
+// Temperatures holds the latest air and water temperature readings (°C).
+type Temperatures struct {
+	stationId string
+	time      time.Time
+	waterC    float64
+	airC      float64
+	hasWater  bool
+	hasAir    bool
+}
+
+// Water returns the water temperature (°C), if reported.
+func (t Temperatures) Water() (float64, bool) { return t.waterC, t.hasWater }
+
+// Air returns the air temperature (°C), if reported.
+func (t Temperatures) Air() (float64, bool) { return t.airC, t.hasAir }
+
+// fetchMetRows downloads a station's realtime2 .txt file and parses up to
+// limit of the most recent rows.
+func (s *dataService) fetchMetRows(ctx context.Context, stationID string, limit int) ([]metRow, error) {
+	if err := ValidateBuoyStation(stationID); err != nil {
+		return nil, err
+	}
+	return s.fetchMetFile(ctx, "https://www.ndbc.noaa.gov/data/realtime2/"+stationID+".txt", "", limit)
+}
+
+// fetchMetFile parses a standard meteorological file (realtime or historical
+// archive; both share the format) at url. limit <= 0 reads every row. A
+// non-empty label reports download progress under that name (see
+// netclient.WithProgress).
+func (s *dataService) fetchMetFile(ctx context.Context, url, label string, limit int) ([]metRow, error) {
+	get := s.get
+	if limit > 0 {
+		get = s.getLatest
+	}
+	resp, err := get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("unexpected status code: " + resp.Status)
+	}
+	if label != "" {
+		netclient.TrackBody(ctx, label, resp)
+	}
+
+	var header []string
+	var rows []metRow
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if line[0] == '#' {
+			// first comment line carries column names, second carries units
+			if header == nil {
+				header = strings.Fields(strings.TrimPrefix(line, "#"))
+			}
+			continue
+		}
+		if header == nil {
+			return nil, errors.New("met file missing header")
+		}
+		row, ok := parseMetRow(header, strings.Fields(line))
+		if !ok {
+			continue
+		}
+		rows = append(rows, row)
+		if len(rows) == limit {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("no data lines in met file")
+	}
+	return rows, nil
+}
+
+func parseMetRow(header, fields []string) (metRow, bool) {
+	if len(fields) < 5 || len(fields) != len(header) {
+		return metRow{}, false
+	}
+	var ts [5]int
+	for i := range ts {
+		v, err := strconv.Atoi(fields[i])
+		if err != nil {
+			return metRow{}, false
+		}
+		ts[i] = v
+	}
+	row := metRow{
+		time:   time.Date(ts[0], time.Month(ts[1]), ts[2], ts[3], ts[4], 0, 0, time.UTC),
+		values: make(map[string]float64, len(fields)-5),
+	}
+	for i := 5; i < len(fields); i++ {
+		if fields[i] == "MM" {
+			continue
+		}
+		if v, err := strconv.ParseFloat(fields[i], 64); err == nil {
+			row.values[header[i]] = v
+		}
+	}
+	return row, true
+}
+
+// GetTemperatures returns the most recent air and water temperatures from the
+// buoy's standard meteorological file, looking back a few rows for readings
+// that are temporarily missing.
+func (s *dataService) GetTemperatures(ctx context.Context) (Temperatures, error) {
+	rows, err := s.fetchMetRows(ctx, s.buoyStationID(), 6)
+	if err != nil {
+		return Temperatures{}, err
+	}
+	t := Temperatures{stationId: s.buoyStationID(), time: rows[0].time}
+	for _, r := range rows {
+		if v, ok := r.get("WTMP"); ok && !t.hasWater {
+			t.waterC, t.hasWater = v, true
+		}
+		if v, ok := r.get("ATMP"); ok && !t.hasAir {
+			t.airC, t.hasAir = v, true
+		}
+	}
+	if !t.hasWater && !t.hasAir {
+		return Temperatures{}, errors.New("no temperature readings")
+	}
+	return t, nil
+}
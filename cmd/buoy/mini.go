@@ -0,0 +1,47 @@
+package buoy
+
+import (
+	"strings"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/i18n"
+)
+
+// MiniView renders the buoy pane as three lines (waves, wind and water, next
+// tides) for layouts that need the width for something else, like the
+// create form.
+func MiniView(data *BuoyData) string {
+	if data == nil {
+		return buoyInfoStyle.Render(i18n.T("No buoy configured yet. Configure in $HOME/.surflog.yaml"))
+	}
+	now := time.Now()
+	waves := buoyTitleStyle.Render("≋ ")
+	switch {
+	case data.waveErr != nil:
+		waves += tideErrStyle.Render(data.waveErr.Error())
+	case data.wave == nil:
+		waves += i18n.T("Loading...")
+	default:
+		waves += data.wave.Short()
+		if !data.waveCachedAt.IsZero() {
+			waves += " " + buoyInfoStyle.Render(cachedLabel(data.waveCachedAt, now))
+		} else if !data.fetchedAt.IsZero() {
+			waves += " " + buoyInfoStyle.Render(updatedAgo(data.fetchedAt, now))
+		}
+	}
+
+	var conditions []string
+	if data.wind != nil {
+		conditions = append(conditions, i18n.T("Wind: ")+data.wind.String())
+	}
+	if t := data.temps; t != nil && t.hasWater {
+		conditions = append(conditions, i18n.T("water %s", i18n.Temperature(t.waterC)))
+	}
+	wind := buoyTitleStyle.Render("≈ ") + buoyInfoStyle.Render(strings.Join(conditions, " | "))
+
+	tide := buoyTitleStyle.Render("~ ")
+	if data.tide != nil {
+		tide += buoyInfoStyle.Render(nextExtremesLine(data.tide, now))
+	}
+	return strings.Join([]string{waves, wind, tide}, "\n")
+}
@@ -1,5 +1,12 @@
 package buoy
 
+import (
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/airquality"
+	"github.com/sumwatshade/surflog/cmd/forecast"
+)
+
 // BuoyData holds buoy identifier and associated tide information for the day.
 // All fields are unexported to keep the public surface small until stabilized.
 type BuoyData struct {
@@ -7,6 +14,48 @@ type BuoyData struct {
 	tideErr error
 	wave    *WaveSummary
 	waveErr error
+	// waveTrend is the last day of significant heights, oldest first.
+	waveTrend []WaveObservation
+	temps     *Temperatures
+	tempErr   error
+	// waterHistory is the archived water temperature for the trend chart.
+	waterHistory []WaterTempReading
+	wind         *WindSummary
+	windErr      error
+	aqi          *airquality.Reading
+	aqiErr       error
+	svc          Service // shared service used by all fetch commands
+	// backupSvc reads `buoy.backup_station`; outage is set while it stands in
+	// for a primary buoy that has stopped reporting.
+	backupSvc Service
+	outage    *outage
+	// upstreamSvc reads `buoy.upstream_station`, a distant buoy whose swell
+	// is projected forward to the spot.
+	upstreamSvc Service
+	upstream    *WaveSummary
+	upstreamErr error
+	tideTable   bool // show the hourly tide table instead of the chart
+	// fetchedAt is when wave data last arrived; refreshing is set while a
+	// manual refresh is in flight.
+	fetchedAt  time.Time
+	refreshing bool
+	// *CachedAt are set while a section shows offline-cache data.
+	waveCachedAt time.Time
+	windCachedAt time.Time
+	tideCachedAt time.Time
+	// tideDay is the day shown in the tide section relative to today (-1..1);
+	// tideDays caches fetched days by local date so paging back is instant.
+	tideDay  int
+	tideDays map[string]TideData
+	// changed highlights significant jumps since the previous refresh.
+	changed changes
+	// retrying marks sections whose fetch is being re-run after an error.
+	retrying map[Section]bool
+	// outlook is the swell forecast for the active spot's location;
+	// outlookHourly shows it as an hourly chart instead of a line per day.
+	outlook       *forecast.Forecast
+	outlookErr    error
+	outlookHourly bool
 }
 
 type TideData struct {
@@ -15,18 +64,52 @@ type TideData struct {
 		time  string
 		value float64
 	}
+	extremes []tideExtreme // highs and lows for the day and the one after, oldest first
 }
 
 // setWave populates wave summary fields (internal helper used after fetching).
 func (b *BuoyData) setWave(ws WaveSummary, err error) {
 	b.waveErr = err
+	b.refreshing = false
+	delete(b.retrying, SectionWaves)
 	if err == nil {
 		b.wave = &ws
+		b.fetchedAt = time.Now()
+		if ws.trend != nil { // cached summaries carry none; keep the last
+			b.waveTrend = ws.trend
+		}
+	}
+}
+
+func (b *BuoyData) setTemps(t Temperatures, err error) {
+	b.tempErr = err
+	if err == nil {
+		b.temps = &t
 	}
 }
 
-func (b *BuoyData) setTide(td TideData, err error) {
+func (b *BuoyData) setWind(w WindSummary, err error) {
+	b.windErr = err
+	delete(b.retrying, SectionWind)
+	if err == nil {
+		b.wind = &w
+	}
+}
+
+// setTide records a fetched day and shows it if it is the selected one.
+func (b *BuoyData) setTide(day string, td TideData, err error, cachedAt time.Time) {
+	delete(b.retrying, SectionTide)
+	if err == nil && cachedAt.IsZero() {
+		if b.tideDays == nil {
+			b.tideDays = map[string]TideData{}
+		}
+		b.tideDays[day] = td
+	}
+	if day != tideDayKey(time.Now(), b.tideDay) {
+		return
+	}
 	b.tideErr = err
+	b.tideCachedAt = cachedAt
 	if err == nil {
 		b.tide = &td
 	}
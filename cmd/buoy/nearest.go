@@ -0,0 +1,32 @@
+package buoy
+
+import (
+	"sort"
+
+	"github.com/sumwatshade/surflog/cmd/swell"
+)
+
+// NearStation is a station with its distance from a point.
+type NearStation struct {
+	StationInfo
+	Km float64
+}
+
+// Nearest returns up to n stations closest to lat/lon, nearest first: tide
+// prediction stations when tide is set, otherwise moored wave buoys.
+func Nearest(stations []StationInfo, lat, lon float64, tide bool, n int) []NearStation {
+	var out []NearStation
+	for _, st := range stations {
+		if st.Tide != tide || (!tide && !st.Moored) {
+			continue
+		}
+		out = append(out, NearStation{StationInfo: st, Km: swell.DistanceKm(lat, lon, st.Lat, st.Lon)})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Km != out[j].Km {
+			return out[i].Km < out[j].Km
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out[:min(n, len(out))]
+}
@@ -0,0 +1,38 @@
+package buoy
+
+import (
+	"context"
+	"time"
+)
+
+// WaveObservation is one measured sea state from the buoy's standard met file.
+type WaveObservation struct {
+	Time           time.Time
+	WaveHeight     float64 // significant wave height (m)
+	DominantPeriod float64 // seconds; 0 when not reported
+}
+
+// GetWaveObservations returns every realtime observation with a wave height
+// (roughly the last 45 days), newest first.
+func (s *dataService) GetWaveObservations(ctx context.Context) ([]WaveObservation, error) {
+	rows, err := s.fetchMetRows(ctx, s.buoyStationID(), 0)
+	if err != nil {
+		return nil, err
+	}
+	var out []WaveObservation
+	for _, r := range rows {
+		wvht, ok := r.get("WVHT")
+		if !ok || wvht >= 99 {
+			continue
+		}
+		o := WaveObservation{Time: r.time, WaveHeight: wvht}
+		if dpd, ok := r.get("DPD"); ok && dpd < 99 {
+			o.DominantPeriod = dpd
+		}
+		out = append(out, o)
+	}
+	if len(out) == 0 {
+		return nil, ErrNoObservation
+	}
+	return out, nil
+}
@@ -0,0 +1,151 @@
+package buoy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/direction"
+)
+
+// ProviderOpenMeteo serves modelled waves (Open-Meteo Marine) and wind
+// (Open-Meteo Forecast) at the spot's location, for spots with no buoy
+// nearby. It needs no API key.
+const ProviderOpenMeteo = "open-meteo"
+
+func init() {
+	RegisterProvider(ProviderOpenMeteo, func(c ProviderConfig) Provider {
+		return &openMeteoProvider{client: c.Client, lat: c.Lat, lon: c.Lon}
+	})
+}
+
+type openMeteoProvider struct {
+	client   *http.Client
+	lat, lon float64
+}
+
+var (
+	_ WaveProvider = (*openMeteoProvider)(nil)
+	_ WindProvider = (*openMeteoProvider)(nil)
+)
+
+func (p *openMeteoProvider) Name() string { return ProviderOpenMeteo }
+
+// location is the "lat,lon" stand-in for a station ID.
+func (p *openMeteoProvider) location() string { return fmt.Sprintf("%.3f,%.3f", p.lat, p.lon) }
+
+func (p *openMeteoProvider) source() string { return p.location() }
+
+// current fetches the "current" block of an Open-Meteo endpoint into out.
+func (p *openMeteoProvider) current(ctx context.Context, url string, out any) error {
+	if p.lat == 0 && p.lon == 0 {
+		return errors.New("open-meteo needs a location: set the spot's --lat/--lon or location.lat/lon")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.New("unexpected status code: " + resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func parseOpenMeteoTime(s string) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02T15:04", s, time.UTC)
+}
+
+func (p *openMeteoProvider) GetWaveSummary(ctx context.Context) (WaveSummary, error) {
+	url := fmt.Sprintf("https://marine-api.open-meteo.com/v1/marine?latitude=%.4f&longitude=%.4f&timezone=GMT"+
+		"&current=wave_height,wave_direction,wave_period,swell_wave_height,swell_wave_period,swell_wave_direction,"+
+		"wind_wave_height,wind_wave_period,wind_wave_direction", p.lat, p.lon)
+	var parsed struct {
+		Current struct {
+			Time              string   `json:"time"`
+			WaveHeight        *float64 `json:"wave_height"`
+			WaveDirection     *float64 `json:"wave_direction"`
+			WavePeriod        *float64 `json:"wave_period"`
+			SwellHeight       *float64 `json:"swell_wave_height"`
+			SwellPeriod       *float64 `json:"swell_wave_period"`
+			SwellDirection    *float64 `json:"swell_wave_direction"`
+			WindWaveHeight    *float64 `json:"wind_wave_height"`
+			WindWavePeriod    *float64 `json:"wind_wave_period"`
+			WindWaveDirection *float64 `json:"wind_wave_direction"`
+		} `json:"current"`
+	}
+	if err := p.current(ctx, url, &parsed); err != nil {
+		return WaveSummary{}, err
+	}
+	c := parsed.Current
+	t, err := parseOpenMeteoTime(c.Time)
+	if err != nil || c.WaveHeight == nil {
+		return WaveSummary{}, errors.New("no marine data for location")
+	}
+	val := func(v *float64) float64 {
+		if v == nil {
+			return 0
+		}
+		return *v
+	}
+	compass := func(v *float64) string {
+		if v == nil {
+			return ""
+		}
+		return direction.Text(*v)
+	}
+	return WaveSummary{
+		provider:             ProviderOpenMeteo,
+		stationId:            p.location(),
+		time:                 t,
+		wvht:                 *c.WaveHeight,
+		swellHeight:          val(c.SwellHeight),
+		swellPeriod:          val(c.SwellPeriod),
+		swellDirection:       compass(c.SwellDirection),
+		windWaveHeight:       val(c.WindWaveHeight),
+		windWavePeriod:       val(c.WindWavePeriod),
+		windWaveDirection:    compass(c.WindWaveDirection),
+		averagePeriod:        val(c.WavePeriod),
+		meanWaveDirectionDeg: int(val(c.WaveDirection)),
+	}, nil
+}
+
+func (p *openMeteoProvider) GetWindSummary(ctx context.Context) (WindSummary, error) {
+	url := fmt.Sprintf("https://api.open-meteo.com/v1/forecast?latitude=%.4f&longitude=%.4f&timezone=GMT&wind_speed_unit=ms"+
+		"&current=wind_speed_10m,wind_direction_10m,wind_gusts_10m,surface_pressure", p.lat, p.lon)
+	var parsed struct {
+		Current struct {
+			Time      string   `json:"time"`
+			Speed     *float64 `json:"wind_speed_10m"`
+			Direction *float64 `json:"wind_direction_10m"`
+			Gust      *float64 `json:"wind_gusts_10m"`
+			Pressure  *float64 `json:"surface_pressure"`
+		} `json:"current"`
+	}
+	if err := p.current(ctx, url, &parsed); err != nil {
+		return WindSummary{}, err
+	}
+	c := parsed.Current
+	t, err := parseOpenMeteoTime(c.Time)
+	if err != nil || c.Speed == nil {
+		return WindSummary{}, errors.New("no wind data for location")
+	}
+	w := WindSummary{provider: ProviderOpenMeteo, stationId: p.location(), time: t, speed: *c.Speed}
+	if c.Gust != nil {
+		w.gust, w.hasGust = *c.Gust, true
+	}
+	if c.Direction != nil {
+		w.directionDeg, w.hasDirection = *c.Direction, true
+	}
+	if c.Pressure != nil {
+		w.pressure, w.hasPressure = *c.Pressure, true
+	}
+	return w, nil
+}
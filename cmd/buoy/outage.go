@@ -0,0 +1,98 @@
+package buoy
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+)
+
+// defaultStaleAfter is how long the primary buoy may go without reporting
+// before the backup station is substituted (`buoy.stale_after`).
+const defaultStaleAfter = 3 * time.Hour
+
+// outage records that the primary station is offline and a backup is shown.
+type outage struct {
+	primary string
+	since   time.Time // last primary observation; zero when it failed outright
+	backup  string
+}
+
+// backupStation returns the configured `buoy.backup_station`, if any.
+func backupStation() string {
+	return strings.TrimSpace(viper.GetString("buoy.backup_station"))
+}
+
+func staleAfter() time.Duration {
+	if d := viper.GetDuration("buoy.stale_after"); d > 0 {
+		return d
+	}
+	return defaultStaleAfter
+}
+
+// isStale reports whether a primary observation is too old to trust.
+func isStale(ws WaveSummary, now time.Time) bool {
+	return !ws.time.IsZero() && now.Sub(ws.time) > staleAfter()
+}
+
+// backupWaveMsg carries wave, temperature and wind data from the backup station.
+type backupWaveMsg struct {
+	wave    WaveSummary
+	waveErr error
+	temps   Temperatures
+	tempErr error
+	wind    WindSummary
+	windErr error
+}
+
+func fetchBackupCmd(ctx context.Context, svc Service) tea.Cmd {
+	return func() tea.Msg {
+		ws, werr := svc.GetWaveSummary(ctx)
+		t, terr := svc.GetTemperatures(ctx)
+		w, wderr := svc.GetWindSummary(ctx)
+		return backupWaveMsg{wave: ws, waveErr: werr, temps: t, tempErr: terr, wind: w, windErr: wderr}
+	}
+}
+
+// checkOutage decides whether a primary wave result should be replaced by the
+// backup station and returns the fetch command when it should.
+func (b *BuoyData) checkOutage(ctx context.Context, ws WaveSummary, err error, now time.Time) tea.Cmd {
+	if b.backupSvc == nil {
+		return nil
+	}
+	switch {
+	case err != nil:
+		b.outage = &outage{primary: b.primaryStation(), backup: backupStation()}
+	case isStale(ws, now):
+		primary := ws.stationId
+		if primary == "" {
+			primary = b.primaryStation()
+		}
+		b.outage = &outage{primary: primary, since: ws.time, backup: backupStation()}
+	default:
+		b.outage = nil
+		return nil
+	}
+	return fetchBackupCmd(ctx, b.backupSvc)
+}
+
+// primaryStation names what the wave data is read from: the NOAA station,
+// or another provider's station or location (its name when it has neither).
+func (b *BuoyData) primaryStation() string {
+	svc := b.svc
+	if ps, ok := svc.(*providerService); ok {
+		if p := ps.waves; p != WaveProvider(ps.dataService) {
+			if src, ok := p.(sourced); ok && src.source() != "" {
+				return src.source()
+			}
+			return p.Name()
+		}
+		svc = ps.dataService
+	}
+	if ds, ok := svc.(*dataService); ok {
+		return ds.buoyStationID()
+	}
+	return ConfiguredBuoyStation()
+}
@@ -0,0 +1,181 @@
+package buoy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NimbleMarkets/ntcharts/linechart/timeserieslinechart"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/forecast"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/spots"
+	"github.com/sumwatshade/surflog/cmd/units"
+)
+
+// outlookDays is how many days of swell forecast the buoy pane shows.
+const outlookDays = 3
+
+// outlookFetchedMsg carries the Open-Meteo Marine forecast for the active
+// spot's location.
+type outlookFetchedMsg struct {
+	forecast forecast.Forecast
+	err      error
+	svc      Service // buoy service at fetch time; results from before a reload are dropped
+}
+
+func fetchOutlookCmd(ctx context.Context, svc Service) tea.Cmd {
+	return func() tea.Msg {
+		lat, lon := spots.ActiveLocation()
+		if lat == 0 && lon == 0 {
+			return outlookFetchedMsg{err: errors.New("set the spot's --lat/--lon or location.lat/lon for a swell forecast"), svc: svc}
+		}
+		fc, err := forecast.NewService().Get(ctx, lat, lon, outlookDays)
+		return outlookFetchedMsg{forecast: fc, err: err, svc: svc}
+	}
+}
+
+// ToggleOutlook switches the swell forecast section between the daily
+// summary and an hourly chart.
+func (b *BuoyData) ToggleOutlook() {
+	if b == nil {
+		return
+	}
+	b.outlookHourly = !b.outlookHourly
+}
+
+// outlookDay summarises one local day of the forecast by its biggest hour.
+type outlookDay struct {
+	day        time.Time // local midnight
+	minM, maxM float64   // swell height range
+	peak       forecast.Point
+	ok         bool
+}
+
+// dailyOutlook groups points into local days from now's, oldest first.
+func dailyOutlook(points []forecast.Point, now time.Time) []outlookDay {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	days := make([]outlookDay, outlookDays)
+	for i := range days {
+		days[i].day = today.AddDate(0, 0, i)
+	}
+	for _, p := range points {
+		t := p.Time.In(now.Location())
+		i := -1
+		for j := range days {
+			if !t.Before(days[j].day) && t.Before(days[j].day.AddDate(0, 0, 1)) {
+				i = j
+			}
+		}
+		if i < 0 || (i == 0 && t.Before(now.Truncate(time.Hour))) {
+			continue
+		}
+		d := &days[i]
+		h := p.SwellHeight
+		if !d.ok {
+			d.minM, d.maxM, d.peak, d.ok = h, h, p, true
+			continue
+		}
+		d.minM = min(d.minM, h)
+		if h > d.maxM {
+			d.maxM, d.peak = h, p
+		}
+	}
+	out := days[:0]
+	for _, d := range days {
+		if d.ok {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// outlookDayLabel names a forecast day relative to today.
+func outlookDayLabel(day, today time.Time) string {
+	switch int(day.Sub(today).Hours()+12) / 24 {
+	case 0:
+		return i18n.T("today")
+	case 1:
+		return i18n.T("tomorrow")
+	}
+	return i18n.T(day.Format("Mon"))
+}
+
+// renderOutlookSection shows the swell forecast below the current conditions:
+// a line per day, or an hourly swell height chart when toggled. It is
+// skipped in low-bandwidth mode, which doesn't fetch it.
+func renderOutlookSection(bd *BuoyData, now time.Time) section {
+	if bd == nil || config.LowBandwidth() {
+		return section{}
+	}
+	sec := newSection(i18n.T("Swell Forecast (%d days)", outlookDays))
+	if bd.outlookHourly {
+		sec.title = i18n.T("Swell Forecast (hourly)")
+	}
+	if bd.outlookErr != nil {
+		sec.err = bd.outlookErr
+		return sec
+	}
+	if bd.outlook == nil {
+		sec.add(i18n.T("Loading..."))
+		return sec
+	}
+	days := dailyOutlook(bd.outlook.Points, now)
+	if len(days) == 0 {
+		sec.add(i18n.T("No forecast data"))
+		return sec
+	}
+	if bd.outlookHourly {
+		if chart, ok := outlookChart(bd.outlook.Points, 42, 10, now); ok {
+			sec.add(chart)
+			sec.add(lipgloss.NewStyle().Foreground(lipgloss.Color("44")).Render("─") + " " + buoyInfoStyle.Render(i18n.T("Swell height (%s)", units.HeightUnit())))
+			sec.add(dstNote(now, now.AddDate(0, 0, outlookDays)))
+		}
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for _, d := range days {
+		sec.add(i18n.T("%-8s %.1f-%.1f%s @ %s %s (peak %s)", outlookDayLabel(d.day, today), units.HeightValue(d.minM), units.HeightValue(d.maxM), units.HeightUnit(),
+			formatPeriod("%.0fs", d.peak.SwellPeriod), d.peak.SwellCompass(), i18n.Time(d.peak.Time.In(now.Location()))))
+	}
+	return sec
+}
+
+// outlookChart plots the hourly swell height in the display unit from now on.
+func outlookChart(points []forecast.Point, width, height int, now time.Time) (string, bool) {
+	var pts []timeserieslinechart.TimePoint
+	minV, maxV := 0.0, 0.0
+	for _, p := range points {
+		t := p.Time.In(now.Location())
+		if t.Before(now.Truncate(time.Hour)) {
+			continue
+		}
+		h := units.HeightValue(p.SwellHeight)
+		if len(pts) == 0 || h > maxV {
+			maxV = h
+		}
+		if len(pts) == 0 || h < minV {
+			minV = h
+		}
+		pts = append(pts, timeserieslinechart.TimePoint{Time: t, Value: h})
+	}
+	if len(pts) < 2 {
+		return "", false
+	}
+	first, last := pts[0].Time, pts[len(pts)-1].Time
+	lc := timeserieslinechart.New(width, height)
+	lo, hi := max(0, minV-0.5), maxV+0.5
+	lc.SetTimeRange(first, last)
+	lc.SetYRange(lo, hi)
+	lc.SetViewTimeAndYRange(first, last, lo, hi)
+	lc.Model.XLabelFormatter = func(i int, v float64) string {
+		t := time.Unix(int64(v), 0).In(now.Location())
+		return i18n.T(t.Format("Mon")) + " " + t.Format("15h")
+	}
+	for _, p := range pts {
+		lc.Push(p)
+	}
+	lc.DrawBraille()
+	return lc.View(), true
+}
@@ -0,0 +1,35 @@
+package buoy
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Period bands: short-period wind chop, mid-period swell and long-period
+// groundswell. Each band gets a colour and a glyph so conditions read at a
+// glance from across the room (and without colour).
+var (
+	periodShortStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("203")) // red
+	periodMidStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("214")) // amber
+	periodLongStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("78"))  // green
+)
+
+// periodBand returns the glyph and style for a wave period in seconds.
+func periodBand(s float64) (string, lipgloss.Style) {
+	switch {
+	case s < 8:
+		return "○", periodShortStyle
+	case s <= 12:
+		return "◐", periodMidStyle
+	default:
+		return "●", periodLongStyle
+	}
+}
+
+// formatPeriod renders a period with its band glyph and colour, using format
+// for the number (e.g. "%.0fs").
+func formatPeriod(format string, s float64) string {
+	glyph, style := periodBand(s)
+	return style.Render(glyph + " " + fmt.Sprintf(format, s))
+}
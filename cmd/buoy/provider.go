@@ -0,0 +1,264 @@
+package buoy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/netclient"
+	"github.com/sumwatshade/surflog/cmd/spots"
+)
+
+// Provider is a named source of marine data. Each implements one or more of
+// WaveProvider, TideProvider and WindProvider.
+type Provider interface {
+	// Name is the registered name, e.g. "noaa".
+	Name() string
+}
+
+// WaveProvider supplies the latest wave conditions.
+type WaveProvider interface {
+	Provider
+	GetWaveSummary(ctx context.Context) (WaveSummary, error)
+}
+
+// TideProvider supplies tide predictions between begin and end.
+type TideProvider interface {
+	Provider
+	GetTideData(ctx context.Context, begin, end time.Time) (TideData, error)
+}
+
+// WindProvider supplies the latest wind and pressure.
+type WindProvider interface {
+	Provider
+	GetWindSummary(ctx context.Context) (WindSummary, error)
+}
+
+// ProviderConfig is what a provider is built for: the stations and location
+// of the spot being read. Empty stations mean the configured defaults.
+type ProviderConfig struct {
+	Client      *http.Client
+	BuoyStation string
+	TideStation string
+	CDIPStation string
+	Lat, Lon    float64
+}
+
+// ProviderFactory builds a provider for a spot.
+type ProviderFactory func(ProviderConfig) Provider
+
+// ProviderNOAA is the built-in NDBC (waves, wind) and CO-OPS (tides) provider.
+const ProviderNOAA = "noaa"
+
+// ProviderKinds are the kinds of data a provider can be chosen for, as used
+// in `providers.<kind>` and a spot's providers.
+var ProviderKinds = []string{"waves", "tides", "wind"}
+
+var providers = map[string]ProviderFactory{}
+
+// RegisterProvider makes a provider available by name for `providers.<kind>`
+// config and spot overrides.
+func RegisterProvider(name string, f ProviderFactory) {
+	providers[name] = f
+}
+
+func init() {
+	RegisterProvider(ProviderNOAA, func(c ProviderConfig) Provider {
+		return &dataService{client: c.Client, buoyStation: strings.TrimSpace(c.BuoyStation), tideStation: strings.TrimSpace(c.TideStation)}
+	})
+}
+
+func (s *dataService) Name() string { return ProviderNOAA }
+
+func providerOrNOAA(name string) string {
+	if name == "" {
+		return ProviderNOAA
+	}
+	return name
+}
+
+// ProviderNames lists the registered providers that can serve kind.
+func ProviderNames(kind string) []string {
+	var out []string
+	for name, f := range providers {
+		if _, ok := servesKind(f(ProviderConfig{}), kind); ok {
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// servesKind returns p as the interface for kind, if it implements it.
+func servesKind(p Provider, kind string) (Provider, bool) {
+	switch kind {
+	case "waves":
+		wp, ok := p.(WaveProvider)
+		return wp, ok
+	case "tides":
+		tp, ok := p.(TideProvider)
+		return tp, ok
+	case "wind":
+		wp, ok := p.(WindProvider)
+		return wp, ok
+	}
+	return nil, false
+}
+
+// ValidateProvider checks that name is registered and can serve kind.
+func ValidateProvider(kind, name string) error {
+	if !slices.Contains(ProviderKinds, kind) {
+		return fmt.Errorf("unknown data kind %q (want one of %s)", kind, strings.Join(ProviderKinds, ", "))
+	}
+	if !slices.Contains(ProviderNames(kind), name) {
+		return fmt.Errorf("no %s provider %q (have %s)", kind, name, strings.Join(ProviderNames(kind), ", "))
+	}
+	return nil
+}
+
+// providerName picks the provider for kind: the spot's override, then
+// `providers.<kind>` (or `buoy.provider` for waves), then NOAA.
+func providerName(kind string, overrides map[string]string) string {
+	if name := strings.TrimSpace(overrides[kind]); name != "" {
+		return strings.ToLower(name)
+	}
+	if name := strings.TrimSpace(viper.GetString("providers." + kind)); name != "" {
+		return strings.ToLower(name)
+	}
+	if name := strings.TrimSpace(viper.GetString("buoy.provider")); name != "" && kind == "waves" {
+		return strings.ToLower(name)
+	}
+	return ProviderNOAA
+}
+
+// providerService serves waves, tides and wind from the chosen providers and
+// everything else (history, temperatures, hindcasts) from NOAA.
+type providerService struct {
+	*dataService
+	waves WaveProvider
+	tides TideProvider
+	wind  WindProvider
+}
+
+// provider returns the provider chosen for kind.
+func (s *providerService) provider(kind string) Provider {
+	switch kind {
+	case "tides":
+		return s.tides
+	case "wind":
+		return s.wind
+	}
+	return s.waves
+}
+
+// sourced is implemented by providers that can name what they read, a
+// station or a location, for cache keys and outage notes.
+type sourced interface {
+	source() string
+}
+
+func (s *providerService) GetWaveSummary(ctx context.Context) (WaveSummary, error) {
+	return s.waves.GetWaveSummary(ctx)
+}
+
+func (s *providerService) GetTideData(ctx context.Context, begin, end time.Time) (TideData, error) {
+	return s.tides.GetTideData(ctx, begin, end)
+}
+
+func (s *providerService) GetWindSummary(ctx context.Context) (WindSummary, error) {
+	return s.wind.GetWindSummary(ctx)
+}
+
+// unknownProvider stands in for a misconfigured provider so the error shows
+// where that data would have been.
+type unknownProvider struct{ kind, name string }
+
+func (u unknownProvider) Name() string { return u.name }
+func (u unknownProvider) err() error   { return ValidateProvider(u.kind, u.name) }
+func (u unknownProvider) GetWaveSummary(context.Context) (WaveSummary, error) {
+	return WaveSummary{}, u.err()
+}
+func (u unknownProvider) GetTideData(context.Context, time.Time, time.Time) (TideData, error) {
+	return TideData{}, u.err()
+}
+func (u unknownProvider) GetWindSummary(context.Context) (WindSummary, error) {
+	return WindSummary{}, u.err()
+}
+
+// newProviderService builds the service for cfg with the configured
+// providers, plus overrides per kind. When every kind is NOAA it is the plain
+// NOAA service.
+func newProviderService(cfg ProviderConfig, overrides map[string]string) Service {
+	if cfg.Client == nil {
+		cfg.Client = netclient.Shared()
+	}
+	noaa := providers[ProviderNOAA](cfg).(*dataService)
+	chosen := map[string]Provider{}
+	for _, kind := range ProviderKinds {
+		name := providerName(kind, overrides)
+		if name == ProviderNOAA {
+			chosen[kind] = noaa
+			continue
+		}
+		var p Provider = unknownProvider{kind, name}
+		if f, ok := providers[name]; ok {
+			if sp, ok := servesKind(f(cfg), kind); ok {
+				p = sp
+			}
+		}
+		chosen[kind] = p
+	}
+	if chosen["waves"] == noaa && chosen["tides"] == noaa && chosen["wind"] == noaa {
+		return noaa
+	}
+	return &providerService{dataService: noaa, waves: chosen["waves"].(WaveProvider), tides: chosen["tides"].(TideProvider), wind: chosen["wind"].(WindProvider)}
+}
+
+// NewSpotService returns the service for the active spot: its mapped buoy
+// and tide stations (empty for the configured ones), its location and CDIP
+// station, and its providers over the configured ones.
+func NewSpotService(buoyStation, tideStation string) Service {
+	cfg := ProviderConfig{BuoyStation: buoyStation, TideStation: tideStation}
+	cfg.Lat, cfg.Lon = spots.ActiveLocation()
+	var overrides map[string]string
+	if sp, ok := spots.Current(); ok {
+		overrides = sp.Providers
+		cfg.CDIPStation = sp.CDIPStation
+	}
+	return newProviderService(cfg, overrides)
+}
+
+// NewServiceForSpot returns the service for sessions at sp, which need not
+// be the active spot: its stations, location, CDIP station and providers
+// over the configured ones. A non-empty station replaces the wave station
+// of whichever provider serves waves (see ValidateWaveStation).
+func NewServiceForSpot(sp spots.Spot, station string) Service {
+	cfg := ProviderConfig{BuoyStation: sp.Station, TideStation: sp.TideStation, CDIPStation: sp.CDIPStation, Lat: sp.Lat, Lon: sp.Lon}
+	if !sp.HasCoords() {
+		cfg.Lat, cfg.Lon = config.Location()
+	}
+	if station != "" {
+		if providerName("waves", sp.Providers) == ProviderCDIP {
+			cfg.CDIPStation = station
+		} else {
+			cfg.BuoyStation = station
+		}
+	}
+	return newProviderService(cfg, sp.Providers)
+}
+
+// ValidateWaveStation checks a station override for sessions at sp against
+// the provider serving its waves: a CDIP ID for "cdip", otherwise an NDBC
+// ID (NOAA, and the buoy read for wind and water alongside other providers).
+func ValidateWaveStation(sp spots.Spot, station string) error {
+	if providerName("waves", sp.Providers) == ProviderCDIP {
+		return ValidateCDIPStation(station)
+	}
+	return ValidateBuoyStation(station)
+}
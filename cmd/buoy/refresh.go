@@ -0,0 +1,107 @@
+package buoy
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+)
+
+// Default auto-refresh intervals: NDBC posts new observations about every
+// 30 minutes, while tide predictions only change day to day.
+const (
+	defaultWaveRefresh = 30 * time.Minute
+	defaultTideRefresh = 24 * time.Hour
+)
+
+// refreshMsg fires when a kind of data ("waves" or "tides") is due again.
+type refreshMsg struct {
+	kind string
+	svc  Service // service the schedule belongs to; reloads start a new one
+}
+
+// refreshInterval returns the cadence for kind. Waves honour `refresh.waves`
+// and then `buoy.refresh_interval`.
+func refreshInterval(kind string) time.Duration {
+	if kind == "tides" {
+		return config.RefreshInterval("tides", defaultTideRefresh)
+	}
+	def := defaultWaveRefresh
+	if d := viper.GetDuration("buoy.refresh_interval"); d > 0 {
+		def = d
+	}
+	return config.RefreshInterval("waves", def)
+}
+
+func scheduleRefresh(kind string, svc Service) tea.Cmd {
+	return tea.Tick(refreshInterval(kind), func(time.Time) tea.Msg {
+		return refreshMsg{kind: kind, svc: svc}
+	})
+}
+
+// refresh re-fetches the data a refreshMsg is for and schedules the next one.
+// On a metered connection the fetch is skipped but the schedule continues,
+// so refreshing resumes once the flag is cleared.
+func (b *BuoyData) refresh(ctx context.Context, msg refreshMsg) tea.Cmd {
+	next := scheduleRefresh(msg.kind, msg.svc)
+	if config.Metered() {
+		return next
+	}
+	if msg.kind == "tides" {
+		b.tideDays = nil // predictions are revised and "today" may have rolled over
+		return tea.Batch(fetchTideCmd(ctx, b.svc, b.tideDay), next)
+	}
+	return tea.Batch(b.fetchConditions(ctx), next)
+}
+
+// fetchConditions fetches waves along with the temperatures, wind, air
+// quality and upstream buoy that update on the same cadence. Low-bandwidth
+// mode skips air quality and the upstream buoy.
+func (b *BuoyData) fetchConditions(ctx context.Context) tea.Cmd {
+	cmds := []tea.Cmd{fetchWaveCmd(ctx, b.svc), fetchTempCmd(ctx, b.svc), fetchWindCmd(ctx, b.svc)}
+	if config.LowBandwidth() {
+		return tea.Batch(cmds...)
+	}
+	cmds = append(cmds, fetchAQICmd(ctx), fetchOutlookCmd(ctx, b.svc))
+	if b.upstreamSvc != nil {
+		cmds = append(cmds, fetchUpstreamCmd(ctx, b.upstreamSvc))
+	}
+	return tea.Batch(cmds...)
+}
+
+// Refresh re-fetches everything now (the manual refresh key). Unlike the
+// background schedule it ignores the metered flag, since the user asked.
+func (b *BuoyData) Refresh(ctx context.Context) tea.Cmd {
+	if b == nil || b.svc == nil {
+		return nil
+	}
+	b.refreshing = true
+	b.tideDays = nil
+	return tea.Batch(b.fetchConditions(ctx), fetchTideCmd(ctx, b.svc, b.tideDay))
+}
+
+// staleTickMsg re-renders the "updated … ago" indicator once a minute.
+type staleTickMsg struct {
+	svc Service
+}
+
+func scheduleStaleTick(svc Service) tea.Cmd {
+	return tea.Tick(time.Minute, func(time.Time) tea.Msg { return staleTickMsg{svc: svc} })
+}
+
+// updatedAgo renders how long ago wave data was last fetched, e.g.
+// "updated 12m ago".
+func updatedAgo(at, now time.Time) string {
+	d := now.Sub(at)
+	switch {
+	case d < time.Minute:
+		return i18n.T("updated just now")
+	case d < time.Hour:
+		return i18n.T("updated %dm ago", int(d.Minutes()))
+	default:
+		return i18n.T("updated %dh %02dm ago", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
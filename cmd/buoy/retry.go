@@ -0,0 +1,56 @@
+package buoy
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Section names a buoy pane section that can be re-fetched on its own.
+type Section string
+
+const (
+	SectionWaves Section = "waves"
+	SectionWind  Section = "wind" // wind and the temperatures from the same met file
+	SectionTide  Section = "tide"
+)
+
+// RetryMsg asks HandleUpdate to re-run only the fetch behind a section.
+type RetryMsg struct {
+	Section Section
+}
+
+// Retry returns a command that sends a RetryMsg for section.
+func Retry(section Section) tea.Cmd {
+	return func() tea.Msg { return RetryMsg{Section: section} }
+}
+
+// retry re-fetches one section, marking it retrying until its result lands.
+// Unlike the background schedule it ignores the metered flag, since the user
+// asked.
+func (b *BuoyData) retry(ctx context.Context, section Section) tea.Cmd {
+	if b == nil || b.svc == nil {
+		return nil
+	}
+	if b.retrying == nil {
+		b.retrying = map[Section]bool{}
+	}
+	b.retrying[section] = true
+	switch section {
+	case SectionWaves:
+		return fetchWaveCmd(ctx, b.svc)
+	case SectionWind:
+		return tea.Batch(fetchWindCmd(ctx, b.svc), fetchTempCmd(ctx, b.svc))
+	case SectionTide:
+		return fetchTideCmd(ctx, b.svc, b.tideDay)
+	}
+	delete(b.retrying, section)
+	return nil
+}
+
+// retryKeys are the keys bound to each section's retry, for error hints.
+// The TUI's key map binds the same keys.
+var retryKeys = map[Section]string{SectionWaves: "W", SectionWind: "N", SectionTide: "H"}
+
+// RetryKey returns the key that retries section.
+func RetryKey(section Section) string { return retryKeys[section] }
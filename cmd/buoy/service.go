@@ -1,28 +1,81 @@
 package buoy
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/direction"
+	"github.com/sumwatshade/surflog/cmd/netclient"
+	"github.com/sumwatshade/surflog/cmd/units"
 )
 
 type Service interface {
-	GetTideData() (TideData, error)
+	// GetTideData retrieves tide predictions between begin and end (e.g. one
+	// local day) for the tide station.
+	GetTideData(ctx context.Context, begin, end time.Time) (TideData, error)
 	// GetWaveSummary retrieves the latest detailed wave summary (.spec) entry
-	// for a fixed buoy station and distills it into structured data. Currently
-	// hard-coded to station 46274 (San Francisco Bar / SF approach) and returns
-	// the most recent observation (first non-comment line in the .spec file).
-	GetWaveSummary() (WaveSummary, error)
+	// for the buoy station (`buoy.station`, 46274 San Francisco Bar by default)
+	// and distills the most recent observations into structured data.
+	GetWaveSummary(ctx context.Context) (WaveSummary, error)
+	// GetTemperatures retrieves the latest air/water temperature readings from
+	// the buoy's standard meteorological (.txt) file.
+	GetTemperatures(ctx context.Context) (Temperatures, error)
+	// GetWaveSummaryAt looks up the observation nearest to a past time, for
+	// backfilling entries saved without conditions.
+	GetWaveSummaryAt(ctx context.Context, at time.Time) (WaveSummary, error)
+	// GetHindcast looks up archived waves, wind, water temperature and the
+	// predicted tide for a past session time.
+	GetHindcast(ctx context.Context, at time.Time) (Hindcast, error)
+	// GetWaveObservations returns the recent measured wave heights and
+	// periods, for scoring archived forecasts.
+	GetWaveObservations(ctx context.Context) ([]WaveObservation, error)
+	// GetWindSummary retrieves the latest wind speed, gust, direction and
+	// pressure from the buoy's standard meteorological (.txt) file.
+	GetWindSummary(ctx context.Context) (WindSummary, error)
+	// GetWaterTempHistory returns the water temperatures in the buoy's
+	// realtime met file, for seeding the local archive.
+	GetWaterTempHistory(ctx context.Context) ([]WaterTempReading, error)
 }
 
+// defaultBuoyStation is the NDBC station used for wave and met data.
+const defaultBuoyStation = "46274"
+
+// defaultTideStation is the NOAA CO-OPS station used for tide predictions.
+const defaultTideStation = "9410170"
+
 var _ Service = (*dataService)(nil)
 
+// NewService returns the service for the configured stations, reading
+// waves, tides and wind from the configured providers (NOAA by default; see
+// NewSpotService).
 func NewService() Service {
-	return &dataService{}
+	return NewSpotService("", "")
+}
+
+// NewServiceWithClient returns a NOAA-backed service using the provided client.
+func NewServiceWithClient(client *http.Client) Service {
+	if client == nil {
+		client = netclient.Shared()
+	}
+	return &dataService{client: client}
+}
+
+// NewServiceForStations returns a NOAA-backed service reading the given buoy
+// and tide stations, e.g. for a spot with its own mapped stations. Empty IDs
+// fall back to the configured stations.
+func NewServiceForStations(buoyStation, tideStation string) Service {
+	return &dataService{client: netclient.Shared(), buoyStation: strings.TrimSpace(buoyStation), tideStation: strings.TrimSpace(tideStation)}
 }
 
 // WaveSummary provides a distilled view of a single line from the NOAA
@@ -35,7 +88,12 @@ func NewService() Service {
 //	STEEPNESS: Wave steepness category
 //	APD: Average Wave Period (s)
 //	MWD: Mean Wave Direction (deg true)
+//
+// Saved on an entry it is an immutable snapshot: the raw readings above are
+// what was observed, and anything derived from them (feet, knots, scores) is
+// recomputed when rendered rather than stored.
 type WaveSummary struct {
+	provider             string // registered provider name; empty is NOAA
 	stationId            string
 	time                 time.Time
 	wvht                 float64
@@ -48,10 +106,14 @@ type WaveSummary struct {
 	steepness            string
 	averagePeriod        float64
 	meanWaveDirectionDeg int
+	// trend is the last day of significant heights, oldest first, for the
+	// trend chart. It is not persisted.
+	trend []WaveObservation
 }
 
 // waveSummaryDTO is the exported representation used for JSON persistence.
 type waveSummaryDTO struct {
+	Provider          string    `json:"provider,omitempty"` // empty for NOAA
 	StationID         string    `json:"station_id"`
 	Time              time.Time `json:"time"`
 	SignificantHeight float64   `json:"significant_height_m"`
@@ -64,12 +126,23 @@ type waveSummaryDTO struct {
 	Steepness         string    `json:"steepness"`
 	AveragePeriod     float64   `json:"average_period_s"`
 	MeanWaveDirection int       `json:"mean_wave_direction_deg"`
-	Summary           string    `json:"summary"` // human readable string (optional convenience)
+	// Derived fields below are conveniences for readers of the raw files.
+	// Directions are stored both ways; see the direction package.
+	SwellDirectionDeg     *float64 `json:"swell_direction_deg,omitempty"`
+	WindWaveDirectionDeg  *float64 `json:"wind_wave_direction_deg,omitempty"`
+	MeanWaveDirectionText string   `json:"mean_wave_direction,omitempty"`
+	// Summary is display text older versions saved in the units of the day.
+	// It is read and dropped; String renders it from the fields above.
+	Summary string `json:"summary,omitempty"`
 }
 
-// MarshalJSON implements custom JSON encoding while keeping internal fields unexported.
+// MarshalJSON implements custom JSON encoding while keeping internal fields
+// unexported. Only the readings and unit-free conveniences are written;
+// display text depends on the units and is rendered by String, so re-saving
+// an entry (an edit, a migration) never rewrites its snapshot.
 func (w WaveSummary) MarshalJSON() ([]byte, error) {
 	dto := waveSummaryDTO{
+		Provider:          w.provider,
 		StationID:         w.stationId,
 		Time:              w.time,
 		SignificantHeight: w.wvht,
@@ -82,7 +155,15 @@ func (w WaveSummary) MarshalJSON() ([]byte, error) {
 		Steepness:         w.steepness,
 		AveragePeriod:     w.averagePeriod,
 		MeanWaveDirection: w.meanWaveDirectionDeg,
-		Summary:           w.String(),
+	}
+	if d, ok := w.SwellDirectionDeg(); ok {
+		dto.SwellDirectionDeg = &d
+	}
+	if d, ok := w.WindWaveDirectionDeg(); ok {
+		dto.WindWaveDirectionDeg = &d
+	}
+	if !w.IsZero() {
+		dto.MeanWaveDirectionText = w.MeanWaveDirectionText()
 	}
 	return json.Marshal(dto)
 }
@@ -98,6 +179,7 @@ func (w *WaveSummary) UnmarshalJSON(b []byte) error {
 		return err
 	}
 	// Populate internal fields.
+	w.provider = dto.Provider
 	w.stationId = dto.StationID
 	w.time = dto.Time
 	w.wvht = dto.SignificantHeight
@@ -110,55 +192,130 @@ func (w *WaveSummary) UnmarshalJSON(b []byte) error {
 	w.steepness = dto.Steepness
 	w.averagePeriod = dto.AveragePeriod
 	w.meanWaveDirectionDeg = dto.MeanWaveDirection
+	// sources that only gave degrees still get compass text
+	if w.swellDirection == "" && dto.SwellDirectionDeg != nil {
+		w.swellDirection = direction.Text(*dto.SwellDirectionDeg)
+	}
+	if w.windWaveDirection == "" && dto.WindWaveDirectionDeg != nil {
+		w.windWaveDirection = direction.Text(*dto.WindWaveDirectionDeg)
+	}
 	return nil
 }
 
-func (w *WaveSummary) String() string {
-	return fmt.Sprintf("%.1fft sig (swell %.1fft @ %.0fs %s / wind %.1fft @ %.0fs %s) | avg %.1fs | mean %d°",
-		w.wvht, w.swellHeight, w.swellPeriod, w.swellDirection, w.windWaveHeight, w.windWavePeriod, w.windWaveDirection, w.averagePeriod, w.meanWaveDirectionDeg)
+// IsZero reports whether no observation has been recorded.
+func (w WaveSummary) IsZero() bool { return w.time.IsZero() && w.wvht == 0 && w.swellHeight == 0 }
+
+// Provider returns the name of the provider the reading came from.
+func (w WaveSummary) Provider() string { return providerOrNOAA(w.provider) }
+
+// StationID returns the NDBC station the observation came from.
+func (w WaveSummary) StationID() string { return w.stationId }
+
+// Time returns the observation time (UTC).
+func (w WaveSummary) Time() time.Time { return w.time }
+
+// SignificantHeight returns WVHT in meters.
+func (w WaveSummary) SignificantHeight() float64 { return w.wvht }
+
+// SwellHeight returns the primary swell height in meters.
+func (w WaveSummary) SwellHeight() float64 { return w.swellHeight }
+
+// SwellPeriod returns the primary swell period in seconds.
+func (w WaveSummary) SwellPeriod() float64 { return w.swellPeriod }
+
+// SwellDirection returns the primary swell compass direction (e.g. "WNW").
+func (w WaveSummary) SwellDirection() string { return w.swellDirection }
+
+// WindWaveHeight returns the wind wave height in meters.
+func (w WaveSummary) WindWaveHeight() float64 { return w.windWaveHeight }
+
+// WindWavePeriod returns the wind wave period in seconds.
+func (w WaveSummary) WindWavePeriod() float64 { return w.windWavePeriod }
+
+// WindWaveDirection returns the wind wave compass direction.
+func (w WaveSummary) WindWaveDirection() string { return w.windWaveDirection }
+
+// Steepness returns the NDBC steepness category.
+func (w WaveSummary) Steepness() string { return w.steepness }
+
+// AveragePeriod returns APD in seconds.
+func (w WaveSummary) AveragePeriod() float64 { return w.averagePeriod }
+
+// SwellDirectionDeg returns the primary swell direction in degrees true; ok
+// is false when the buoy reported none.
+func (w WaveSummary) SwellDirectionDeg() (float64, bool) { return direction.Degrees(w.swellDirection) }
+
+// WindWaveDirectionDeg returns the wind wave direction in degrees true.
+func (w WaveSummary) WindWaveDirectionDeg() (float64, bool) {
+	return direction.Degrees(w.windWaveDirection)
 }
 
-// GetTideData retrieves today's tide prediction data for a fixed station.
-// Currently hard-coded to station 9410170 (San Francisco, CA) and returns
-// times in GMT as provided by the API.
-func (s *dataService) GetTideData() (TideData, error) {
-	const stationID = "9410170"
-	const url = "https://api.tidesandcurrents.noaa.gov/api/prod/datagetter?date=today&station=" + stationID + "&product=predictions&datum=MLLW&time_zone=gmt&units=english&format=json"
+// MeanWaveDirectionText returns the mean wave direction as compass text.
+func (w WaveSummary) MeanWaveDirectionText() string {
+	return direction.Text(float64(w.meanWaveDirectionDeg))
+}
 
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Get(url)
-	if err != nil {
-		return TideData{}, err
+// MeanWaveDirection returns MWD in degrees true.
+func (w WaveSummary) MeanWaveDirection() int { return w.meanWaveDirectionDeg }
+
+// Short renders a compact summary for list lines in the display units, e.g.
+// "4.3ft @ 14s WNW".
+func (w WaveSummary) Short() string {
+	if w.IsZero() {
+		return ""
 	}
-	defer resp.Body.Close()
+	s := units.Height(w.wvht)
+	if w.swellPeriod > 0 {
+		s += fmt.Sprintf(" @ %.0fs", w.swellPeriod)
+	}
+	if w.swellDirection != "" {
+		s += " " + w.swellDirection
+	}
+	return s
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return TideData{}, errors.New("unexpected status code: " + resp.Status)
+// String renders the full summary in the display units.
+func (w *WaveSummary) String() string {
+	if w.IsZero() {
+		return "" // entries saved before summaries were recorded
 	}
+	if w.swellHeight == 0 && w.windWaveHeight == 0 {
+		// backfilled from standard met data, which has no swell/wind split
+		return fmt.Sprintf("%s sig @ %.0fs | avg %.1fs | mean %d°", units.Height(w.wvht), w.swellPeriod, w.averagePeriod, w.meanWaveDirectionDeg)
+	}
+	return fmt.Sprintf("%s sig (swell %s @ %.0fs %s / wind %s @ %.0fs %s) | avg %.1fs | mean %d°",
+		units.Height(w.wvht), units.Height(w.swellHeight), w.swellPeriod, w.swellDirection, units.Height(w.windWaveHeight), w.windWavePeriod, w.windWaveDirection, w.averagePeriod, w.meanWaveDirectionDeg)
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
+// GetTideData retrieves tide prediction data between begin and end for the
+// service's tide station (`tide.station`, 9410170 San Francisco by default)
+// and returns times in GMT as provided by the API. The high/low extremes are
+// fetched alongside for the same window plus a day, so the next turn is known
+// late in the day.
+func (s *dataService) GetTideData(ctx context.Context, begin, end time.Time) (TideData, error) {
+	stationID := s.tideStationID()
+	if err := ValidateTideStation(stationID); err != nil {
 		return TideData{}, err
 	}
-
-	// Struct matching NOAA response
-	var parsed struct {
-		Predictions []struct {
-			T string `json:"t"`
-			V string `json:"v"`
-		} `json:"predictions"`
+	if config.LowBandwidth() {
+		// highs and lows alone are a few hundred bytes and render as text
+		hilo, err := s.fetchPredictions(ctx, stationID, tideRange(begin, end.Add(24*time.Hour))+"&interval=hilo")
+		if err != nil {
+			return TideData{}, err
+		}
+		return TideData{stationId: stationID, extremes: parseExtremes(hilo)}, nil
 	}
-
-	if err := json.Unmarshal(body, &parsed); err != nil {
+	preds, err := s.fetchPredictions(ctx, stationID, tideRange(begin, end))
+	if err != nil {
 		return TideData{}, err
 	}
 
 	td := TideData{stationId: stationID, points: make([]struct {
 		time  string
 		value float64
-	}, len(parsed.Predictions))}
+	}, len(preds))}
 
-	for i, p := range parsed.Predictions {
+	for i, p := range preds {
 		v, err := strconv.ParseFloat(p.V, 64)
 		if err != nil {
 			return TideData{}, err
@@ -169,193 +326,255 @@ func (s *dataService) GetTideData() (TideData, error) {
 		}{time: p.T, value: v}
 	}
 
+	// extremes are a nicety on top of the series; the chart still works without them
+	if hilo, err := s.fetchPredictions(ctx, stationID, tideRange(begin, end.Add(24*time.Hour))+"&interval=hilo"); err == nil {
+		td.extremes = parseExtremes(hilo)
+	}
 	return td, nil
 }
 
-// GetWaveSummary fetches the latest detailed wave summary (.spec) file for a
-// fixed buoy station and returns the most recent observation parsed into a
-// WaveSummary struct.
-func (s *dataService) GetWaveSummary() (WaveSummary, error) {
-	const stationID = "46274"
-	const url = "https://www.ndbc.noaa.gov/data/realtime2/" + stationID + ".spec"
-
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Get(url)
+// tideRange formats begin/end as CO-OPS begin_date/end_date parameters in GMT.
+func tideRange(begin, end time.Time) string {
+	const layout = "20060102 15:04"
+	return "begin_date=" + url.QueryEscape(begin.UTC().Format(layout)) + "&end_date=" + url.QueryEscape(end.UTC().Format(layout))
+}
+
+// tidePrediction is one row of the CO-OPS predictions product. Type is "H" or
+// "L" for interval=hilo and empty otherwise.
+type tidePrediction struct {
+	T    string `json:"t"`
+	V    string `json:"v"`
+	Type string `json:"type"`
+}
+
+// fetchPredictions requests the predictions product for stationID with the
+// given date/interval query parameters.
+func (s *dataService) fetchPredictions(ctx context.Context, stationID, query string) ([]tidePrediction, error) {
+	url := "https://api.tidesandcurrents.noaa.gov/api/prod/datagetter?" + query + "&station=" + stationID + "&product=predictions&datum=MLLW&time_zone=gmt&units=english&format=json"
+
+	resp, err := s.get(ctx, url)
 	if err != nil {
-		return WaveSummary{}, err
+		return nil, err
 	}
 	defer resp.Body.Close()
+
 	if resp.StatusCode != http.StatusOK {
-		return WaveSummary{}, errors.New("unexpected status code: " + resp.Status)
+		return nil, errors.New("unexpected status code: " + resp.Status)
 	}
 
 	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Struct matching NOAA response
+	var parsed struct {
+		Predictions []tidePrediction `json:"predictions"`
+		// CO-OPS reports bad stations with 200 and an error object
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, err
+	}
+	if parsed.Error != nil {
+		return nil, fmt.Errorf("%w %s: %s (check tide.station)", ErrUnknownStation, stationID, parsed.Error.Message)
+	}
+	return parsed.Predictions, nil
+}
+
+// GetWaveSummary fetches the latest detailed wave summary (.spec) file for a
+// the service's buoy station and returns the most recent observation parsed
+// into a WaveSummary struct: the latest few smoothed per buoy.smoothing,
+// with the last day of heights as its trend.
+func (s *dataService) GetWaveSummary(ctx context.Context) (WaveSummary, error) {
+	stationID := s.buoyStationID()
+	if err := ValidateBuoyStation(stationID); err != nil {
+		return WaveSummary{}, err
+	}
+	rows, err := s.fetchSpecRows(ctx, stationID, trendRows)
 	if err != nil {
 		return WaveSummary{}, err
 	}
+	trend := waveTrend(rows)
+	rows = rows[:min(len(rows), smoothRows)]
 
-	lines := splitLines(string(body))
-	// collect up to 5 most recent data lines
-	var dataLines []string
-	for _, line := range lines {
-		if len(line) == 0 || line[0] == '#' {
-			continue
-		}
-		dataLines = append(dataLines, line)
-		if len(dataLines) == 5 { // we only need first 5 (already newest first in file)
-			break
+	// Smooth numeric fields per buoy.smoothing
+	method := smoothingMethod()
+	field := func(get func(WaveSummary) float64) float64 {
+		values := make([]float64, len(rows))
+		for i, r := range rows {
+			values[i] = get(r)
 		}
+		return smooth(method, values)
 	}
-	if len(dataLines) == 0 {
-		return WaveSummary{}, errors.New("no data lines in spec file")
-	}
-
-	type parsed struct {
-		ts       time.Time
-		wvht     float64
-		swellH   float64
-		swellP   float64
-		windH    float64
-		windP    float64
-		swellDir string
-		windDir  string
-		steep    string
-		apd      float64
-		mwd      int
-	}
-
-	var parsedRows []parsed
-	for _, ln := range dataLines {
-		fields := fieldsCondense(ln)
-		if len(fields) < 15 {
-			continue // skip malformed
+	latest := rows[0] // first row is most recent
+
+	return WaveSummary{
+		stationId:            stationID,
+		time:                 latest.time,
+		wvht:                 field(func(r WaveSummary) float64 { return r.wvht }),
+		swellHeight:          field(func(r WaveSummary) float64 { return r.swellHeight }),
+		swellPeriod:          field(func(r WaveSummary) float64 { return r.swellPeriod }),
+		windWaveHeight:       field(func(r WaveSummary) float64 { return r.windWaveHeight }),
+		windWavePeriod:       field(func(r WaveSummary) float64 { return r.windWavePeriod }),
+		swellDirection:       latest.swellDirection,
+		windWaveDirection:    latest.windWaveDirection,
+		steepness:            latest.steepness,
+		averagePeriod:        field(func(r WaveSummary) float64 { return r.averagePeriod }),
+		meanWaveDirectionDeg: int(field(func(r WaveSummary) float64 { return float64(r.meanWaveDirectionDeg) }) + 0.5),
+		trend:                trend,
+	}, nil
+}
+
+// fetchSpecRows reads up to limit of the most recent parsable rows of a
+// station's realtime .spec file (newest first). limit <= 0 reads every row.
+func (s *dataService) fetchSpecRows(ctx context.Context, stationID string, limit int) ([]WaveSummary, error) {
+	url := "https://www.ndbc.noaa.gov/data/realtime2/" + stationID + ".spec"
+
+	get := s.get
+	if limit > 0 {
+		get = s.getLatest
+	}
+	resp, err := get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError(resp, stationID)
+	}
+
+	// the file is newest first, so we can stop reading as soon as we have enough
+	var rows []WaveSummary
+	sawData := false
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || line[0] == '#' {
+			continue
 		}
-		// Parse timestamp
-		year, err1 := strconv.Atoi(fields[0])
-		mon, err2 := strconv.Atoi(fields[1])
-		day, err3 := strconv.Atoi(fields[2])
-		hour, err4 := strconv.Atoi(fields[3])
-		minute, err5 := strconv.Atoi(fields[4])
-		if err1 != nil || err2 != nil || err3 != nil || err4 != nil || err5 != nil {
+		sawData = true
+		row, ok := parseSpecRow(line)
+		if !ok {
 			continue
 		}
-		ts := time.Date(year, time.Month(mon), day, hour, minute, 0, 0, time.UTC)
-		// helper parse float with graceful skip
-		parseF := func(v string) (float64, bool) {
-			f, err := strconv.ParseFloat(v, 64)
-			if err != nil {
-				return 0, false
-			}
-			return f, true
+		row.stationId = stationID
+		rows = append(rows, row)
+		if len(rows) == limit {
+			break
 		}
-		wvht, ok1 := parseF(fields[5])
-		swellH, ok2 := parseF(fields[6])
-		swellP, ok3 := parseF(fields[7])
-		windH, ok4 := parseF(fields[8])
-		windP, ok5 := parseF(fields[9])
-		apd, ok6 := parseF(fields[13])
-		mwd, err := strconv.Atoi(fields[14])
-		if err != nil { // skip direction if invalid
-			mwd = 0
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !sawData {
+		return nil, errors.New("no data lines in spec file")
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("no parsable data rows")
+	}
+	return rows, nil
+}
+
+// parseSpecRow parses one .spec data line. Rows with any missing numeric
+// field are rejected so they do not bias averages.
+func parseSpecRow(line string) (WaveSummary, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 15 {
+		return WaveSummary{}, false // skip malformed
+	}
+	// Parse timestamp
+	var ts [5]int
+	for i := range ts {
+		v, err := strconv.Atoi(fields[i])
+		if err != nil {
+			return WaveSummary{}, false
 		}
-		if !(ok1 && ok2 && ok3 && ok4 && ok5 && ok6) {
-			// If any numeric field failed parsing, skip this row for averaging to avoid bias.
-			continue
+		ts[i] = v
+	}
+	ws := WaveSummary{
+		time:              time.Date(ts[0], time.Month(ts[1]), ts[2], ts[3], ts[4], 0, 0, time.UTC),
+		swellDirection:    fields[10],
+		windWaveDirection: fields[11],
+		steepness:         fields[12],
+	}
+	for _, f := range []struct {
+		src string
+		dst *float64
+	}{{fields[5], &ws.wvht}, {fields[6], &ws.swellHeight}, {fields[7], &ws.swellPeriod}, {fields[8], &ws.windWaveHeight}, {fields[9], &ws.windWavePeriod}, {fields[13], &ws.averagePeriod}} {
+		v, err := strconv.ParseFloat(f.src, 64)
+		if err != nil {
+			return WaveSummary{}, false
 		}
-		parsedRows = append(parsedRows, parsed{
-			ts:       ts,
-			wvht:     wvht,
-			swellH:   swellH,
-			swellP:   swellP,
-			windH:    windH,
-			windP:    windP,
-			swellDir: fields[10],
-			windDir:  fields[11],
-			steep:    fields[12],
-			apd:      apd,
-			mwd:      mwd,
-		})
-	}
-	if len(parsedRows) == 0 {
-		return WaveSummary{}, errors.New("no parsable data rows")
-	}
-
-	// Average numeric fields
-	var sumWvht, sumSwellH, sumSwellP, sumWindH, sumWindP, sumApd float64
-	var sumMwd float64
-	for _, r := range parsedRows {
-		sumWvht += r.wvht
-		sumSwellH += r.swellH
-		sumSwellP += r.swellP
-		sumWindH += r.windH
-		sumWindP += r.windP
-		sumApd += r.apd
-		sumMwd += float64(r.mwd)
-	}
-	n := float64(len(parsedRows))
-	latest := parsedRows[0] // first row is most recent
+		*f.dst = v
+	}
+	if mwd, err := strconv.Atoi(fields[14]); err == nil { // skip direction if invalid
+		ws.meanWaveDirectionDeg = mwd
+	}
+	return ws, true
+}
 
-	return WaveSummary{
-		stationId:            stationID,
-		time:                 latest.ts,
-		wvht:                 sumWvht / n,
-		swellHeight:          sumSwellH / n,
-		swellPeriod:          sumSwellP / n,
-		windWaveHeight:       sumWindH / n,
-		windWavePeriod:       sumWindP / n,
-		swellDirection:       latest.swellDir,
-		windWaveDirection:    latest.windDir,
-		steepness:            latest.steep,
-		averagePeriod:        sumApd / n,
-		meanWaveDirectionDeg: int(sumMwd/n + 0.5), // simple rounded average
-	}, nil
+type dataService struct {
+	client      *http.Client
+	buoyStation string // empty uses the configured buoy.station
+	tideStation string // empty uses the configured tide.station
 }
 
-// splitLines splits on both \r and \n while keeping things simple.
-func splitLines(s string) []string {
-	var out []string
-	start := 0
-	for i, ch := range s {
-		if ch == '\n' { // line end
-			line := s[start:i]
-			// trim trailing CR
-			if len(line) > 0 && line[len(line)-1] == '\r' {
-				line = line[:len(line)-1]
-			}
-			out = append(out, line)
-			start = i + 1
-		}
+func (s *dataService) buoyStationID() string {
+	if s.buoyStation != "" {
+		return strings.ToUpper(s.buoyStation)
 	}
-	if start < len(s) { // last line
-		line := s[start:]
-		if len(line) > 0 && line[len(line)-1] == '\r' {
-			line = line[:len(line)-1]
-		}
-		out = append(out, line)
+	return ConfiguredBuoyStation()
+}
+
+func (s *dataService) tideStationID() string {
+	if s.tideStation != "" {
+		return s.tideStation
 	}
-	return out
+	return ConfiguredTideStation()
 }
 
-// fieldsCondense splits a line on any run of whitespace.
-func fieldsCondense(line string) []string {
-	var f []string
-	fieldStart := -1
-	for i, ch := range line {
-		if ch == ' ' || ch == '\t' || ch == '\r' {
-			if fieldStart >= 0 {
-				f = append(f, line[fieldStart:i])
-				fieldStart = -1
-			}
-		} else {
-			if fieldStart < 0 {
-				fieldStart = i
-			}
-		}
+// lowBandwidthBytes is how much of a realtime file is requested in
+// low-bandwidth mode: the header lines plus a dozen or so of the newest rows.
+const lowBandwidthBytes = 2048
+
+// getLatest fetches a newest-first realtime file. In low-bandwidth mode only
+// its first few KB are requested with a Range header, and a row cut off
+// mid-line is dropped so it cannot parse as a wrong value.
+func (s *dataService) getLatest(ctx context.Context, url string) (*http.Response, error) {
+	if !config.LowBandwidth() {
+		return s.get(ctx, url)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", lowBandwidthBytes-1))
+	resp, err := s.client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusPartialContent {
+		return resp, err // servers that ignore Range send the whole file
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
 	}
-	if fieldStart >= 0 {
-		f = append(f, line[fieldStart:])
+	if i := bytes.LastIndexByte(body, '\n'); i >= 0 {
+		body = body[:i+1]
 	}
-	return f
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.StatusCode, resp.Status = http.StatusOK, "200 OK"
+	return resp, nil
 }
 
-type dataService struct{}
+// get issues a GET that is abandoned when ctx is cancelled (e.g. on quit).
+func (s *dataService) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(req)
+}
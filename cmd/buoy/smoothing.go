@@ -0,0 +1,67 @@
+package buoy
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Smoothing methods for `buoy.smoothing`, which decides how the latest
+// smoothRows .spec observations are combined into the displayed summary.
+const (
+	SmoothLatest = "latest" // the newest observation alone
+	SmoothMean   = "mean"   // their simple mean (the default)
+	SmoothMedian = "median" // their median, robust to a single odd reading
+	SmoothEWMA   = "ewma"   // exponentially weighted toward the newest
+)
+
+// SmoothingMethods lists the accepted `buoy.smoothing` values.
+var SmoothingMethods = []string{SmoothLatest, SmoothMean, SmoothMedian, SmoothEWMA}
+
+// smoothRows is how many observations the summary is smoothed over.
+const smoothRows = 5
+
+// ewmaAlpha is the weight of each newer observation in the EWMA, so the
+// newest carries half and a build-up shows within an hour or so.
+const ewmaAlpha = 0.5
+
+// smoothingMethod returns the configured method; unknown values fall back to
+// the mean.
+func smoothingMethod() string {
+	m := strings.ToLower(strings.TrimSpace(viper.GetString("buoy.smoothing")))
+	if slices.Contains(SmoothingMethods, m) {
+		return m
+	}
+	return SmoothMean
+}
+
+// smooth combines newest-first values by method.
+func smooth(method string, values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	switch method {
+	case SmoothLatest:
+		return values[0]
+	case SmoothMedian:
+		sorted := slices.Clone(values)
+		slices.Sort(sorted)
+		n := len(sorted)
+		if n%2 == 1 {
+			return sorted[n/2]
+		}
+		return (sorted[n/2-1] + sorted[n/2]) / 2
+	case SmoothEWMA:
+		s := values[len(values)-1]
+		for i := len(values) - 2; i >= 0; i-- {
+			s = ewmaAlpha*values[i] + (1-ewmaAlpha)*s
+		}
+		return s
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
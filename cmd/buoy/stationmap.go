@@ -0,0 +1,316 @@
+package buoy
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/swell"
+)
+
+// mapZooms are the picker's view radii in km, nearest first.
+var mapZooms = []float64{25, 50, 100, 200, 400, 800}
+
+var (
+	mapCoastStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("239"))
+	mapBuoyStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("44"))
+	mapTideStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("179"))
+	mapHomeStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Bold(true)
+	mapSelectedStyle = lipgloss.NewStyle().Reverse(true).Bold(true)
+)
+
+// StationPicker is a standalone program that plots stations around a point
+// on a coarse ASCII map and lets the user pick one with the arrow keys.
+// Tide stations sit on the shore, so lines joining neighbouring ones sketch
+// the coastline.
+type StationPicker struct {
+	all        []StationInfo
+	tide       bool // picking tide stations rather than buoys
+	lat, lon   float64
+	zoom       int
+	candidates []StationInfo // pickable stations in view, nearest first
+	selected   int
+	width      int
+	height     int
+	picked     bool
+}
+
+// NewStationPicker centres a picker on lat/lon. current, when it is one of
+// the pickable stations, starts selected.
+func NewStationPicker(stations []StationInfo, tide bool, lat, lon float64, current string) *StationPicker {
+	p := &StationPicker{all: stations, tide: tide, lat: lat, lon: lon, zoom: 2, width: 72, height: 22}
+	// zoom out until something is pickable
+	for p.refresh(); len(p.candidates) == 0 && p.zoom < len(mapZooms)-1; p.refresh() {
+		p.zoom++
+	}
+	for i, st := range p.candidates {
+		if st.ID == current {
+			p.selected = i
+		}
+	}
+	return p
+}
+
+// Picked returns the chosen station; ok is false when the picker was left
+// without choosing.
+func (p *StationPicker) Picked() (StationInfo, bool) {
+	if !p.picked || len(p.candidates) == 0 {
+		return StationInfo{}, false
+	}
+	return p.candidates[p.selected], true
+}
+
+func (p *StationPicker) Init() tea.Cmd { return nil }
+
+func (p *StationPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width = max(20, msg.Width)
+		p.height = max(8, msg.Height-5) // room for the title, details and help
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			return p, tea.Quit
+		case "enter":
+			p.picked = len(p.candidates) > 0
+			return p, tea.Quit
+		case "up", "k":
+			p.move(0, 1)
+		case "down", "j":
+			p.move(0, -1)
+		case "left", "h":
+			p.move(-1, 0)
+		case "right", "l":
+			p.move(1, 0)
+		case "tab", "n":
+			p.step(1)
+		case "shift+tab", "p":
+			p.step(-1)
+		case "+", "=":
+			p.setZoom(p.zoom - 1)
+		case "-", "_":
+			p.setZoom(p.zoom + 1)
+		}
+	}
+	return p, nil
+}
+
+// project returns a point's offset from the centre in km (x east, y north).
+func (p *StationPicker) project(lat, lon float64) (x, y float64) {
+	const kmPerDeg = 111.2
+	return (lon - p.lon) * kmPerDeg * math.Cos(p.lat*math.Pi/180), (lat - p.lat) * kmPerDeg
+}
+
+// cell maps a point to a map cell; ok is false outside the view. Terminal
+// cells are about twice as tall as wide, so rows cover twice the distance.
+func (p *StationPicker) cell(lat, lon float64) (col, row int, ok bool) {
+	x, y := p.project(lat, lon)
+	kmPerCol := 2 * mapZooms[p.zoom] / float64(p.width)
+	col = p.width/2 + int(math.Round(x/kmPerCol))
+	row = p.height/2 - int(math.Round(y/(2*kmPerCol)))
+	return col, row, col >= 0 && col < p.width && row >= 0 && row < p.height
+}
+
+// refresh recomputes the pickable stations in view, keeping the selection
+// when it is still visible.
+func (p *StationPicker) refresh() {
+	var keep string
+	if p.selected < len(p.candidates) {
+		keep = p.candidates[p.selected].ID
+	}
+	p.candidates = p.candidates[:0]
+	for _, st := range p.all {
+		if st.Tide != p.tide {
+			continue
+		}
+		if _, _, ok := p.cell(st.Lat, st.Lon); ok {
+			p.candidates = append(p.candidates, st)
+		}
+	}
+	sort.SliceStable(p.candidates, func(i, j int) bool {
+		return p.distance(p.candidates[i]) < p.distance(p.candidates[j])
+	})
+	p.selected = 0
+	for i, st := range p.candidates {
+		if st.ID == keep {
+			p.selected = i
+		}
+	}
+}
+
+func (p *StationPicker) distance(st StationInfo) float64 {
+	return swell.DistanceKm(p.lat, p.lon, st.Lat, st.Lon)
+}
+
+func (p *StationPicker) setZoom(z int) {
+	if z < 0 || z >= len(mapZooms) {
+		return
+	}
+	p.zoom = z
+	p.refresh()
+}
+
+// step cycles through stations in order of distance.
+func (p *StationPicker) step(delta int) {
+	if n := len(p.candidates); n > 0 {
+		p.selected = (p.selected + delta + n) % n
+	}
+}
+
+// move selects the closest station in direction (dx, dy), favouring those
+// straight ahead over ones off to the side.
+func (p *StationPicker) move(dx, dy float64) {
+	if len(p.candidates) == 0 {
+		return
+	}
+	cur := p.candidates[p.selected]
+	cx, cy := p.project(cur.Lat, cur.Lon)
+	best, bestCost := -1, math.Inf(1)
+	for i, st := range p.candidates {
+		x, y := p.project(st.Lat, st.Lon)
+		ahead := (x-cx)*dx + (y-cy)*dy
+		if i == p.selected || ahead <= 0 {
+			continue
+		}
+		side := math.Abs((x-cx)*dy - (y-cy)*dx)
+		if cost := ahead + 2*side; cost < bestCost {
+			best, bestCost = i, cost
+		}
+	}
+	if best >= 0 {
+		p.selected = best
+	}
+}
+
+// View draws the map with the selection's details and key help below it.
+func (p *StationPicker) View() string {
+	grid := make([][]string, p.height)
+	for r := range grid {
+		grid[r] = make([]string, p.width)
+		for c := range grid[r] {
+			grid[r][c] = " "
+		}
+	}
+	p.drawCoast(grid)
+	for _, st := range p.all {
+		col, row, ok := p.cell(st.Lat, st.Lon)
+		if !ok {
+			continue
+		}
+		if st.Tide {
+			grid[row][col] = mapTideStyle.Render("T")
+		} else {
+			grid[row][col] = mapBuoyStyle.Render("B")
+		}
+	}
+	if col, row, ok := p.cell(p.lat, p.lon); ok {
+		grid[row][col] = mapHomeStyle.Render("@")
+	}
+	sel, ok := StationInfo{}, len(p.candidates) > 0
+	if ok {
+		sel = p.candidates[p.selected]
+		if col, row, in := p.cell(sel.Lat, sel.Lon); in {
+			mark := "B"
+			if sel.Tide {
+				mark = "T"
+			}
+			grid[row][col] = mapSelectedStyle.Render(mark)
+		}
+	}
+
+	var b strings.Builder
+	title := i18n.T("Pick a buoy")
+	if p.tide {
+		title = i18n.T("Pick a tide station")
+	}
+	fmt.Fprintf(&b, "%s %s\n", buoyTitleStyle.Render(title), buoyInfoStyle.Render(i18n.T("(%.0f km radius)", mapZooms[p.zoom])))
+	for _, row := range grid {
+		b.WriteString(strings.Join(row, ""))
+		b.WriteByte('\n')
+	}
+	if ok {
+		fmt.Fprintf(&b, "%s %s · %.0f km\n", buoyTitleStyle.Render(sel.ID), sel.Name, p.distance(sel))
+	} else {
+		fmt.Fprintln(&b, buoyInfoStyle.Render(i18n.T("No stations in view; press - to zoom out.")))
+	}
+	legend := fmt.Sprintf("%s %s  %s %s  %s %s", mapHomeStyle.Render("@"), i18n.T("you"),
+		mapBuoyStyle.Render("B"), i18n.T("buoy"), mapTideStyle.Render("T"), i18n.T("tide station"))
+	fmt.Fprintln(&b, legend)
+	b.WriteString(buoyInfoStyle.Render(i18n.T("arrows move • tab next nearest • +/- zoom • enter pick • esc skip")))
+	return b.String()
+}
+
+// drawCoast joins each tide station in view to its two nearest neighbours,
+// which traces a rough shoreline.
+func (p *StationPicker) drawCoast(grid [][]string) {
+	limit := 1.5 * mapZooms[p.zoom]
+	var shore []StationInfo
+	for _, st := range p.all {
+		if st.Tide && swell.DistanceKm(p.lat, p.lon, st.Lat, st.Lon) < 2*mapZooms[p.zoom] {
+			shore = append(shore, st)
+		}
+	}
+	dot := mapCoastStyle.Render("·")
+	for i, a := range shore {
+		near := make([]int, 0, len(shore)-1)
+		for j := range shore {
+			if j != i {
+				near = append(near, j)
+			}
+		}
+		sort.Slice(near, func(x, y int) bool {
+			return swell.DistanceKm(a.Lat, a.Lon, shore[near[x]].Lat, shore[near[x]].Lon) <
+				swell.DistanceKm(a.Lat, a.Lon, shore[near[y]].Lat, shore[near[y]].Lon)
+		})
+		for _, j := range near[:min(2, len(near))] {
+			b := shore[j]
+			if swell.DistanceKm(a.Lat, a.Lon, b.Lat, b.Lon) > limit {
+				continue
+			}
+			c0, r0, _ := p.cell(a.Lat, a.Lon)
+			c1, r1, _ := p.cell(b.Lat, b.Lon)
+			plotLine(grid, c0, r0, c1, r1, dot)
+		}
+	}
+}
+
+// plotLine draws a Bresenham line of s between two cells, clipped to grid.
+func plotLine(grid [][]string, c0, r0, c1, r1 int, s string) {
+	dc, dr := abs(c1-c0), -abs(r1-r0)
+	sc, sr := 1, 1
+	if c0 > c1 {
+		sc = -1
+	}
+	if r0 > r1 {
+		sr = -1
+	}
+	e := dc + dr
+	for {
+		if r0 >= 0 && r0 < len(grid) && c0 >= 0 && c0 < len(grid[r0]) {
+			grid[r0][c0] = s
+		}
+		if c0 == c1 && r0 == r1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dr {
+			e += dr
+			c0 += sc
+		}
+		if e2 <= dc {
+			e += dc
+			r0 += sr
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,60 @@
+package buoy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ErrUnknownStation is returned (wrapped) when NOAA has no data for a station.
+var ErrUnknownStation = errors.New("unknown station")
+
+var (
+	ndbcStationRe = regexp.MustCompile(`^[A-Z0-9]{5}$`) // e.g. 46026, LJAC1
+	tideStationRe = regexp.MustCompile(`^[0-9]{7}$`)    // e.g. 9414290
+)
+
+// ValidateBuoyStation checks an NDBC station ID's shape.
+func ValidateBuoyStation(id string) error {
+	if !ndbcStationRe.MatchString(id) {
+		return fmt.Errorf("invalid buoy station %q: NDBC IDs are 5 letters/digits (e.g. 46026)", id)
+	}
+	return nil
+}
+
+// ValidateTideStation checks a NOAA CO-OPS station ID's shape.
+func ValidateTideStation(id string) error {
+	if !tideStationRe.MatchString(id) {
+		return fmt.Errorf("invalid tide station %q: NOAA tide IDs are 7 digits (e.g. 9414290)", id)
+	}
+	return nil
+}
+
+// ConfiguredBuoyStation returns `buoy.station` (upper-cased) or the default.
+func ConfiguredBuoyStation() string {
+	if id := strings.ToUpper(strings.TrimSpace(viper.GetString("buoy.station"))); id != "" {
+		return id
+	}
+	return defaultBuoyStation
+}
+
+// ConfiguredTideStation returns `tide.station` or the default.
+func ConfiguredTideStation() string {
+	if id := strings.TrimSpace(viper.GetString("tide.station")); id != "" {
+		return id
+	}
+	return defaultTideStation
+}
+
+// statusError turns a non-200 NOAA response into an error, calling out
+// missing stations (NDBC answers 404 for IDs it doesn't know).
+func statusError(resp *http.Response, station string) error {
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w %s: NOAA has no realtime data for it (check buoy.station)", ErrUnknownStation, station)
+	}
+	return errors.New("unexpected status code: " + resp.Status)
+}
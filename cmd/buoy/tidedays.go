@@ -0,0 +1,59 @@
+package buoy
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+)
+
+// maxTideDay bounds paging to yesterday / today / tomorrow.
+const maxTideDay = 1
+
+// tideDayBounds returns the local midnight-to-midnight window offset days
+// from now.
+func tideDayBounds(now time.Time, offset int) (begin, end time.Time) {
+	y, m, d := now.Date()
+	begin = time.Date(y, m, d+offset, 0, 0, 0, 0, now.Location())
+	return begin, begin.AddDate(0, 0, 1).Add(-time.Minute)
+}
+
+// tideDayKey identifies the local day offset days from now in the cache.
+func tideDayKey(now time.Time, offset int) string {
+	begin, _ := tideDayBounds(now, offset)
+	return begin.Format("2006-01-02")
+}
+
+// ShiftTideDay pages the tide section delta days (clamped to yesterday ..
+// tomorrow). Cached days are shown immediately; others are fetched.
+func (b *BuoyData) ShiftTideDay(ctx context.Context, delta int) tea.Cmd {
+	if b == nil {
+		return nil
+	}
+	day := min(max(b.tideDay+delta, -maxTideDay), maxTideDay)
+	if day == b.tideDay {
+		return nil
+	}
+	b.tideDay = day
+	b.tideErr = nil
+	b.tideCachedAt = time.Time{}
+	if td, ok := b.tideDays[tideDayKey(time.Now(), day)]; ok {
+		b.tide = &td
+		return nil
+	}
+	b.tide = nil
+	return fetchTideCmd(ctx, b.svc, day)
+}
+
+// tideDayLabel names the selected day for the section title.
+func tideDayLabel(offset int) string {
+	switch offset {
+	case -1:
+		return i18n.T("yesterday")
+	case 1:
+		return i18n.T("tomorrow")
+	default:
+		return i18n.T("today")
+	}
+}
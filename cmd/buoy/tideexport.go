@@ -0,0 +1,63 @@
+package buoy
+
+import (
+	"strings"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/i18n"
+)
+
+// TidePrediction is one predicted water level, exported for the `tide`
+// command's JSON and CSV output.
+type TidePrediction struct {
+	Time   time.Time `json:"time"`
+	Height float64   `json:"height_ft"`
+	Type   string    `json:"type,omitempty"` // "high" or "low" for extremes
+}
+
+// Station returns the tide station the data was fetched for.
+func (td TideData) Station() string { return td.stationId }
+
+// Predictions returns the predicted series in local time, skipping
+// unparsable rows. It is empty in low-bandwidth mode.
+func (td TideData) Predictions() []TidePrediction {
+	out := make([]TidePrediction, 0, len(td.points))
+	for _, p := range td.points {
+		gmt, err := time.ParseInLocation("2006-01-02 15:04", p.time, time.UTC)
+		if err != nil {
+			continue
+		}
+		out = append(out, TidePrediction{Time: gmt.In(time.Local), Height: p.value})
+	}
+	return out
+}
+
+// Extremes returns the highs and lows between begin and end in local time.
+func (td TideData) Extremes(begin, end time.Time) []TidePrediction {
+	var out []TidePrediction
+	for _, e := range td.extremes {
+		if e.time.Before(begin) || e.time.After(end) {
+			continue
+		}
+		typ := "low"
+		if e.high {
+			typ = "high"
+		}
+		out = append(out, TidePrediction{Time: e.time.In(time.Local), Height: e.value, Type: typ})
+	}
+	return out
+}
+
+// RenderTideChart draws td as the TUI's tide chart at the given size, with
+// its legend, for use outside the TUI. Without a series (low-bandwidth mode)
+// the highs and lows between begin and end are listed instead.
+func RenderTideChart(td TideData, begin, end time.Time, width, height int) string {
+	if len(td.points) < 2 {
+		return renderExtremesList(&td, begin, end)
+	}
+	chart, ok := buildTideChart(&td, width, height, time.Now())
+	if !ok {
+		return i18n.T("No parsable tide times")
+	}
+	return strings.Join(chart.lines(time.Now()), "\n")
+}
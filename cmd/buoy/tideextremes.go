@@ -0,0 +1,120 @@
+package buoy
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/linechart/timeserieslinechart"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/units"
+)
+
+// tideExtreme is a predicted high or low tide.
+type tideExtreme struct {
+	time  time.Time // UTC
+	value float64   // ft
+	high  bool
+}
+
+// parseExtremes converts hilo predictions, skipping unparsable rows.
+func parseExtremes(preds []tidePrediction) []tideExtreme {
+	var out []tideExtreme
+	for _, p := range preds {
+		t, err := time.ParseInLocation("2006-01-02 15:04", p.T, time.UTC)
+		if err != nil {
+			continue
+		}
+		v, err := strconv.ParseFloat(p.V, 64)
+		if err != nil {
+			continue
+		}
+		typ := strings.ToUpper(strings.TrimSpace(p.Type))
+		if typ != "H" && typ != "L" {
+			continue
+		}
+		out = append(out, tideExtreme{time: t, value: v, high: typ == "H"})
+	}
+	return out
+}
+
+// nextExtremes returns the first high and low at or after now; ok flags are
+// false when the predictions do not reach that far.
+func nextExtremes(td *TideData, now time.Time) (high, low tideExtreme, okHigh, okLow bool) {
+	for _, e := range td.extremes {
+		if e.time.Before(now) {
+			continue
+		}
+		if e.high && !okHigh {
+			high, okHigh = e, true
+		} else if !e.high && !okLow {
+			low, okLow = e, true
+		}
+	}
+	return high, low, okHigh, okLow
+}
+
+// nextExtremesLine renders e.g. "Next high 14:32 (5.4ft) | low 20:51 (0.3ft)".
+func nextExtremesLine(td *TideData, now time.Time) string {
+	high, low, okHigh, okLow := nextExtremes(td, now)
+	var parts []string
+	if okHigh {
+		parts = append(parts, i18n.T("high %s (%s)", i18n.Time(high.time.In(time.Local)), units.Feet(high.value)))
+	}
+	if okLow {
+		parts = append(parts, i18n.T("low %s (%s)", i18n.Time(low.time.In(time.Local)), units.Feet(low.value)))
+	}
+	if okHigh && okLow && low.time.Before(high.time) {
+		parts[0], parts[1] = parts[1], parts[0]
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return i18n.T("Next %s", strings.Join(parts, " | "))
+}
+
+// renderExtremesList lists the highs and lows between begin and end, one
+// per line, e.g. "▲ high 14:32  5.4ft".
+func renderExtremesList(td *TideData, begin, end time.Time) string {
+	var lines []string
+	for _, e := range td.extremes {
+		if e.time.Before(begin) || e.time.After(end) {
+			continue
+		}
+		glyph, label := "▼", i18n.T("low")
+		if e.high {
+			glyph, label = "▲", i18n.T("high")
+		}
+		lines = append(lines, tideExtremeStyle.Render(glyph)+" "+buoyInfoStyle.Render(fmt.Sprintf("%-5s %6s %6s", label, i18n.Time(e.time.In(time.Local)), units.Feet(e.value))))
+	}
+	if len(lines) == 0 {
+		return i18n.T("No tide data")
+	}
+	return strings.Join(lines, "\n")
+}
+
+var tideExtremeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Bold(true)
+
+// markTideExtremes draws ▲/▼ on the chart at each high/low inside the plotted
+// window and reports whether any were drawn.
+func markTideExtremes(lc *timeserieslinechart.Model, td *TideData, minTime, maxTime time.Time, minV, maxV float64) bool {
+	marked := false
+	for _, e := range td.extremes {
+		if e.time.Before(minTime) || e.time.After(maxTime) {
+			continue
+		}
+		r := '▼'
+		if e.high {
+			r = '▲'
+		}
+		// hilo times fall between 6-minute samples, so clamp to the axis range
+		v := math.Min(math.Max(units.FeetValue(e.value), minV), maxV)
+		lc.Model.DrawRuneWithStyle(canvas.Float64Point{X: float64(e.time.Unix()), Y: v}, r, tideExtremeStyle)
+		marked = true
+	}
+	return marked
+}
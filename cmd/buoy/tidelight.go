@@ -0,0 +1,91 @@
+package buoy
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/linechart/timeserieslinechart"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/solar"
+	"github.com/sumwatshade/surflog/cmd/spots"
+)
+
+// Backgrounds shading the tide chart outside daylight.
+var (
+	nightShade    = lipgloss.Color("234")
+	twilightShade = lipgloss.Color("237")
+)
+
+// lightOn returns the light times at the active spot on day's date.
+func lightOn(day time.Time) solar.Light {
+	lat, lon := spots.ActiveLocation()
+	return solar.LightOn(day, lat, lon)
+}
+
+// lightLine renders day's light at the active spot, e.g. "light 06:41–18:59
+// · sun 07:08–18:32", or "" when the sun neither rises nor sets.
+func lightLine(day time.Time) string {
+	l := lightOn(day)
+	var parts []string
+	if !l.FirstLight.IsZero() {
+		parts = append(parts, i18n.T("light %s–%s", i18n.Time(l.FirstLight), i18n.Time(l.LastLight)))
+	}
+	if !l.Sunrise.IsZero() {
+		parts = append(parts, i18n.T("sun %s–%s", i18n.Time(l.Sunrise), i18n.Time(l.Sunset)))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return buoyInfoStyle.Render("☀ " + strings.Join(parts, " · "))
+}
+
+// shadeDarkness gives the chart's columns before sunrise and after sunset a
+// background, darker outside first and last light, keeping what is drawn in
+// them. It reports whether any column was shaded.
+func shadeDarkness(lc *timeserieslinechart.Model) bool {
+	viewMin, viewMax := lc.Model.ViewMinX(), lc.Model.ViewMaxX()
+	width := lc.GraphWidth()
+	if viewMax <= viewMin || width < 2 {
+		return false
+	}
+	start := lc.Model.Origin().X
+	if lc.Model.YStep() > 0 {
+		start++ // past the y axis, as for the current-time line
+	}
+	days := map[string]solar.Light{}
+	shaded := false
+	for i := 0; i < width; i++ {
+		t := time.Unix(int64(math.Round(viewMin+float64(i)/float64(width-1)*(viewMax-viewMin))), 0).In(time.Local)
+		key := t.Format("2006-01-02")
+		l, ok := days[key]
+		if !ok {
+			l = lightOn(t)
+			days[key] = l
+		}
+		var bg lipgloss.Color
+		switch l.Phase(t) {
+		case solar.Day:
+			continue
+		case solar.Twilight:
+			bg = twilightShade
+		default:
+			bg = nightShade
+		}
+		for y := 0; y < lc.Model.Origin().Y; y++ {
+			p := canvas.Point{X: start + i, Y: y}
+			cell := lc.Canvas.Cell(p)
+			lc.Canvas.SetCell(p, canvas.NewCellWithStyle(cell.Rune, cell.Style.Background(bg)))
+		}
+		shaded = true
+	}
+	return shaded
+}
+
+// darknessLegend keys the chart's shading.
+func darknessLegend() string {
+	return lipgloss.NewStyle().Background(nightShade).Render("  ") + lipgloss.NewStyle().Background(twilightShade).Render("  ") +
+		" " + buoyInfoStyle.Render(i18n.T("Dark / twilight"))
+}
@@ -0,0 +1,82 @@
+package buoy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/units"
+)
+
+var tideNowStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("159")).Bold(true)
+
+// tideTableColumns is how many hour/height pairs are printed per row.
+const tideTableColumns = 3
+
+// ToggleTideTable switches the tide section between the chart and an hourly
+// table. The starting mode comes from `display.accessible` or `tide.view`.
+func (b *BuoyData) ToggleTideTable() {
+	if b == nil {
+		return
+	}
+	b.tideTable = !b.tideTable
+}
+
+// defaultTideTable reports whether the table should be shown initially.
+func defaultTideTable() bool {
+	return viper.GetBool("display.accessible") || strings.EqualFold(viper.GetString("tide.view"), "table")
+}
+
+type tidePoint struct {
+	time  time.Time // local
+	value float64   // ft
+}
+
+// hourlyTide returns the on-the-hour predictions in local time.
+func hourlyTide(td *TideData) []tidePoint {
+	var out []tidePoint
+	for _, p := range td.points {
+		gmt, err := time.ParseInLocation("2006-01-02 15:04", p.time, time.UTC)
+		if err != nil || gmt.Minute() != 0 {
+			continue
+		}
+		out = append(out, tidePoint{time: gmt.In(time.Local), value: p.value})
+	}
+	return out
+}
+
+// renderTideTable lays out hour → height in columns, highlighting the
+// current hour.
+func renderTideTable(td *TideData, now time.Time) string {
+	pts := hourlyTide(td)
+	if len(pts) == 0 {
+		return i18n.T("No tide data")
+	}
+	rows := (len(pts) + tideTableColumns - 1) / tideTableColumns
+	var b strings.Builder
+	for r := 0; r < rows; r++ {
+		for c := 0; c < tideTableColumns; c++ {
+			i := c*rows + r // fill down columns so time reads top to bottom
+			if i >= len(pts) {
+				break
+			}
+			cell := fmt.Sprintf("%5s %5.1f", i18n.Time(pts[i].time), units.FeetValue(pts[i].value))
+			if now.Truncate(time.Hour).Equal(pts[i].time.Truncate(time.Hour)) {
+				cell = tideNowStyle.Render(cell)
+			} else {
+				cell = buoyInfoStyle.Render(cell)
+			}
+			if c > 0 {
+				b.WriteString("   ")
+			}
+			b.WriteString(cell)
+		}
+		if r < rows-1 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
@@ -1,55 +1,207 @@
 package buoy
 
 import (
+	"context"
+	"strings"
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sumwatshade/surflog/cmd/spots"
 )
 
 // internal message indicating tide data fetch completed
 type tideFetchedMsg struct {
-	tide TideData
-	err  error
+	day      string // local date the predictions cover
+	tide     TideData
+	err      error
+	cachedAt time.Time // set when served from the offline cache
+	svc      Service   // service that fetched it; results from before a reload are dropped
 }
 
 // internal message for wave summary fetch completion
 type waveFetchedMsg struct {
-	wave WaveSummary
-	err  error
+	wave     WaveSummary
+	err      error
+	cachedAt time.Time
+	svc      Service
+}
+
+// internal message for temperature fetch completion
+type tempFetchedMsg struct {
+	temps   Temperatures
+	err     error
+	svc     Service
+	history []WaterTempReading // archived water temperatures, oldest first
 }
 
-// fetchTideCmd performs the HTTP request via the buoy service and returns a tideFetchedMsg
-func fetchTideCmd() tea.Cmd {
+// fetchTempCmd retrieves the latest air/water temperatures and archives the
+// water reading for the trend chart
+func fetchTempCmd(ctx context.Context, svc Service) tea.Cmd {
 	return func() tea.Msg {
-		svc := NewService()
-		td, err := svc.GetTideData()
-		return tideFetchedMsg{tide: td, err: err}
+		t, err := svc.GetTemperatures(ctx)
+		msg := tempFetchedMsg{temps: t, err: err, svc: svc}
+		if err == nil {
+			msg.history = archiveWaterTemp(ctx, svc, t)
+		}
+		return msg
+	}
+}
+
+// internal message for wind fetch completion
+type windFetchedMsg struct {
+	wind     WindSummary
+	err      error
+	cachedAt time.Time
+	svc      Service
+}
+
+// fetchWindCmd retrieves the latest wind and pressure readings
+func fetchWindCmd(ctx context.Context, svc Service) tea.Cmd {
+	return func() tea.Msg {
+		w, at, err := withCache(cacheKey(svc, "wind"), func() (WindSummary, error) { return svc.GetWindSummary(ctx) })
+		return windFetchedMsg{wind: w, err: err, cachedAt: at, svc: svc}
+	}
+}
+
+// fetchTideCmd fetches the predictions for the day offset days from today via
+// the buoy service and returns a tideFetchedMsg
+func fetchTideCmd(ctx context.Context, svc Service, offset int) tea.Cmd {
+	now := time.Now()
+	day := tideDayKey(now, offset)
+	begin, end := tideDayBounds(now, offset)
+	return func() tea.Msg {
+		td, at, err := withCache(cacheKey(svc, "tides")+"-"+day, func() (TideData, error) { return svc.GetTideData(ctx, begin, end) })
+		return tideFetchedMsg{day: day, tide: td, err: err, cachedAt: at, svc: svc}
 	}
 }
 
 // fetchWaveCmd retrieves wave summary (latest .spec reading)
-func fetchWaveCmd(data *BuoyData) tea.Cmd {
+func fetchWaveCmd(ctx context.Context, svc Service) tea.Cmd {
 	return func() tea.Msg {
-		svc := NewService()
-		ws, err := svc.GetWaveSummary()
-		return waveFetchedMsg{wave: ws, err: err}
+		ws, at, err := withCache(cacheKey(svc, "waves"), func() (WaveSummary, error) { return svc.GetWaveSummary(ctx) })
+		return waveFetchedMsg{wave: ws, err: err, cachedAt: at, svc: svc}
+	}
+}
+
+// Reload (re)builds the buoy data for the active spot's stations and fetches
+// everything again, e.g. after switching spots. View settings are kept. It
+// also starts a fresh auto-refresh schedule; the previous one is dropped.
+func Reload(ctx context.Context, prev *BuoyData) (*BuoyData, tea.Cmd) {
+	buoyStation, tideStation := spots.ActiveStations()
+	data := &BuoyData{svc: NewSpotService(buoyStation, tideStation), tideTable: defaultTideTable()}
+	if prev != nil {
+		data.tideTable, data.outlookHourly = prev.tideTable, prev.outlookHourly
+	}
+	if backup := backupStation(); backup != "" && !strings.EqualFold(backup, data.primaryStation()) {
+		data.backupSvc = NewServiceForStations(backup, "")
+	}
+	if up := upstreamStation(); up != "" {
+		data.upstreamSvc = NewServiceForStations(up, "")
 	}
+	return data, tea.Batch(fetchTideCmd(ctx, data.svc, 0), data.fetchConditions(ctx),
+		scheduleRefresh("waves", data.svc), scheduleRefresh("tides", data.svc), scheduleStaleTick(data.svc))
 }
 
 // HandleUpdate manages buoy-specific updates. It triggers an initial tide fetch
 // the first time we get a window size (a proxy for program start) when no data
 // has been loaded yet, and applies fetched tide data when received.
-func HandleUpdate(data *BuoyData, msg tea.Msg) (*BuoyData, tea.Cmd) {
+// Fetches are bound to ctx so they are cancelled when the program shuts down.
+func HandleUpdate(ctx context.Context, data *BuoyData, msg tea.Msg) (*BuoyData, tea.Cmd) {
 	switch m := msg.(type) {
 	case tea.WindowSizeMsg:
 		if data == nil { // trigger initial load once
-			data = &BuoyData{}
-			return data, tea.Batch(fetchTideCmd(), fetchWaveCmd(nil))
+			return Reload(ctx, nil)
 		}
 		_ = m // unused otherwise
+	case refreshMsg:
+		if data == nil || m.svc != data.svc {
+			return data, nil // schedule from before a reload
+		}
+		return data, data.refresh(ctx, m)
+	case RetryMsg:
+		return data, data.retry(ctx, m.Section)
+	case staleTickMsg:
+		if data == nil || m.svc != data.svc {
+			return data, nil
+		}
+		return data, scheduleStaleTick(m.svc) // the view re-renders with the new age
 	case tideFetchedMsg:
-		data.setTide(m.tide, m.err)
+		if data == nil || m.svc != data.svc {
+			return data, nil
+		}
+		data.setTide(m.day, m.tide, m.err, m.cachedAt)
+		return data, nil
+	case aqiFetchedMsg:
+		data.aqiErr = m.err
+		if m.err == nil {
+			data.aqi = &m.reading
+		}
+		return data, nil
+	case tempFetchedMsg:
+		if data == nil || m.svc != data.svc {
+			return data, nil
+		}
+		data.setTemps(m.temps, m.err)
+		if m.history != nil {
+			data.waterHistory = m.history
+		}
+		return data, nil
+	case windFetchedMsg:
+		if data == nil || m.svc != data.svc {
+			return data, nil
+		}
+		prev := data.wind
+		data.setWind(m.wind, m.err)
+		data.windCachedAt = m.cachedAt
+		if m.err != nil || !m.cachedAt.IsZero() {
+			return data, nil
+		}
+		return data, data.noteWind(prev, m.wind)
+	case outlookFetchedMsg:
+		if data == nil || m.svc != data.svc {
+			return data, nil
+		}
+		data.outlookErr = m.err
+		if m.err == nil {
+			data.outlook = &m.forecast
+		}
+		return data, nil
+	case upstreamFetchedMsg:
+		if data == nil || m.svc != data.upstreamSvc {
+			return data, nil
+		}
+		data.upstreamErr = m.err
+		if m.err == nil {
+			data.upstream = &m.wave
+		}
 		return data, nil
 	case waveFetchedMsg:
+		if data == nil || m.svc != data.svc {
+			return data, nil
+		}
+		prev := data.wave
 		data.setWave(m.wave, m.err)
+		data.waveCachedAt = m.cachedAt
+		cmd := data.checkOutage(ctx, m.wave, m.err, time.Now())
+		if m.err != nil || !m.cachedAt.IsZero() {
+			return data, cmd
+		}
+		return data, tea.Batch(cmd, data.noteWave(prev, m.wave))
+	case backupWaveMsg:
+		if data.outage == nil {
+			return data, nil
+		}
+		if m.waveErr != nil {
+			data.outage = nil // backup is down too; keep showing the primary result
+			return data, nil
+		}
+		data.setWave(m.wave, nil)
+		if m.tempErr == nil {
+			data.setTemps(m.temps, nil)
+		}
+		if m.windErr == nil {
+			data.setWind(m.wind, nil)
+		}
 		return data, nil
 	}
 	return data, nil
@@ -1,7 +1,6 @@
 package buoy
 
 import (
-	"fmt"
 	"math"
 	"strings"
 	"time"
@@ -9,17 +8,24 @@ import (
 	"github.com/NimbleMarkets/ntcharts/canvas"
 	"github.com/NimbleMarkets/ntcharts/linechart/timeserieslinechart"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sumwatshade/surflog/cmd/direction"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/layout"
+	"github.com/sumwatshade/surflog/cmd/netclient"
+	"github.com/sumwatshade/surflog/cmd/units"
 )
 
 var buoyTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("44"))
 var buoyInfoStyle = lipgloss.NewStyle().Faint(true).Foreground(lipgloss.Color("246"))
 var tideErrStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("203")) // muted red
+var outageStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Bold(true)
 
 // section represents a logically grouped portion of the buoy view.
 type section struct {
 	title string
 	lines []string
 	err   error
+	retry Section // section whose retry key to suggest under err, if any
 }
 
 func newSection(title string) section { return section{title: title} }
@@ -31,52 +37,243 @@ func (s *section) add(line string) {
 
 // renderWaveSection builds the wave summary section.
 func renderWaveSection(bd *BuoyData) section {
-	sec := newSection("Current Wave Conditions")
+	sec := newSection(i18n.T("Current Wave Conditions"))
 	if bd == nil {
-		sec.add("No data")
+		sec.add(i18n.T("No data"))
+		return sec
+	}
+	if o := bd.outage; o != nil {
+		if o.since.IsZero() {
+			sec.add(outageStyle.Render(i18n.T("substituting %s (%s offline)", o.backup, o.primary)))
+		} else {
+			sec.add(outageStyle.Render(i18n.T("substituting %s (%s offline since %s)", o.backup, o.primary, i18n.Time(o.since.In(time.Local)))))
+		}
+	}
+	if bd.retrying[SectionWaves] {
+		sec.add(i18n.T("retrying..."))
 		return sec
 	}
 	if bd.waveErr != nil {
-		sec.err = bd.waveErr
+		sec.err, sec.retry = bd.waveErr, SectionWaves
 		return sec
 	}
 	if bd.wave == nil {
-		sec.add("Loading...")
+		sec.add(i18n.T("Loading..."))
 		return sec
 	}
+	if !bd.waveCachedAt.IsZero() {
+		sec.title += " " + cachedLabel(bd.waveCachedAt, time.Now())
+	}
 	ws := bd.wave
-	ft := func(m float64) float64 { return m * 3.28084 }
 	localTs := ws.time.In(time.Local)
-	sec.add(fmt.Sprintf("%.1fft sig (swell %.1fft @ %.0fs %s / wind %.1fft @ %.0fs %s)",
-		ft(ws.wvht), ft(ws.swellHeight), ws.swellPeriod, ws.swellDirection,
-		ft(ws.windWaveHeight), ws.windWavePeriod, ws.windWaveDirection))
-	sec.add(fmt.Sprintf("steep %s | avg %.1fs | mean %d° @ %s",
-		strings.ToLower(ws.steepness), ws.averagePeriod, ws.meanWaveDirectionDeg, localTs.Format("15:04")))
+	sig := i18n.T("%s sig (swell %s @ %s %s / wind %s @ %s %s)",
+		units.Height(ws.wvht), units.Height(ws.swellHeight), formatPeriod("%.0fs", ws.swellPeriod), ws.swellDirection,
+		units.Height(ws.windWaveHeight), formatPeriod("%.0fs", ws.windWavePeriod), ws.windWaveDirection)
+	if bd.changed.wave != "" {
+		sig = changedStyle.Render(sig)
+	}
+	sec.add(sig)
+	if bd.changed.wave != "" {
+		sec.add(changedStyle.Render(bd.changed.wave))
+	}
+	sec.add(i18n.T("steep %s | avg %s | mean %d° %s @ %s",
+		strings.ToLower(ws.steepness), formatPeriod("%.1fs", ws.averagePeriod), ws.meanWaveDirectionDeg, ws.MeanWaveDirectionText(), i18n.Time(localTs)))
+	if t := bd.temps; t != nil && t.hasWater {
+		sec.add(i18n.T("water %s", i18n.Temperature(t.waterC)))
+	}
+	if bd.refreshing {
+		sec.add(i18n.T("refreshing..."))
+	} else if !bd.fetchedAt.IsZero() && bd.waveCachedAt.IsZero() {
+		sec.add(updatedAgo(bd.fetchedAt, time.Now()))
+	}
+	return sec
+}
+
+// renderWindSection builds the wind and pressure section.
+func renderWindSection(bd *BuoyData) section {
+	sec := newSection(i18n.T("Wind"))
+	if bd == nil {
+		sec.add(i18n.T("No data"))
+		return sec
+	}
+	if bd.retrying[SectionWind] {
+		sec.add(i18n.T("retrying..."))
+		return sec
+	}
+	if bd.windErr != nil {
+		sec.err, sec.retry = bd.windErr, SectionWind
+		return sec
+	}
+	if bd.wind == nil {
+		sec.add(i18n.T("Loading..."))
+		return sec
+	}
+	if !bd.windCachedAt.IsZero() {
+		sec.title += " " + cachedLabel(bd.windCachedAt, time.Now())
+	}
+	w := bd.wind
+	line := i18n.T("%.0fkt", w.speed*MSToKnots)
+	if w.hasGust {
+		line += " " + i18n.T("gusting %.0fkt", w.gust*MSToKnots)
+	}
+	if w.hasDirection {
+		line += " " + i18n.T("from %s (%.0f°)", direction.Text(w.directionDeg), w.directionDeg)
+	}
+	if bd.changed.wind != "" {
+		line = changedStyle.Render(line)
+	}
+	sec.add(line)
+	if bd.changed.wind != "" {
+		sec.add(changedStyle.Render(bd.changed.wind))
+	}
+	if w.hasPressure {
+		sec.add(i18n.T("pressure %.0fhPa @ %s", w.pressure, i18n.Time(w.time.In(time.Local))))
+	} else {
+		sec.add(i18n.T("@ %s", i18n.Time(w.time.In(time.Local))))
+	}
+	return sec
+}
+
+// renderCompassSection plots the primary swell, wind wave and wind
+// directions on a compass rose, keyed beside it. Empty until one of them has
+// a direction.
+func renderCompassSection(bd *BuoyData) section {
+	sec := newSection(i18n.T("Directions"))
+	if bd == nil {
+		return sec
+	}
+	var marks []compassMark
+	var key []string
+	if w := bd.wind; w != nil && w.hasDirection {
+		marks = append(marks, compassMark{w.directionDeg, compassWindStyle})
+		key = append(key, compassWindStyle.Render(arrow(w.directionDeg))+" "+i18n.T("wind %s %.0fkt", direction.Text(w.directionDeg), w.speed*MSToKnots))
+	}
+	if ws := bd.wave; ws != nil {
+		if d, ok := ws.WindWaveDirectionDeg(); ok {
+			marks = append(marks, compassMark{d, compassWindWaveStyle})
+			key = append(key, compassWindWaveStyle.Render(arrow(d))+" "+i18n.T("wind waves %s %s", ws.windWaveDirection, formatPeriod("%.0fs", ws.windWavePeriod)))
+		}
+		if d, ok := ws.SwellDirectionDeg(); ok {
+			marks = append(marks, compassMark{d, compassSwellStyle})
+			key = append(key, compassSwellStyle.Render(arrow(d))+" "+i18n.T("swell %s %s", ws.swellDirection, formatPeriod("%.0fs", ws.swellPeriod)))
+		}
+	}
+	if len(marks) == 0 {
+		return sec
+	}
+	// swell first in the key, as it is drawn on top
+	for i, j := 0, len(key)-1; i < j; i, j = i+1, j-1 {
+		key[i], key[j] = key[j], key[i]
+	}
+	legend := lipgloss.NewStyle().PaddingTop(compassRY - len(key)/2).PaddingLeft(2).Render(strings.Join(key, "\n"))
+	sec.add(lipgloss.JoinHorizontal(lipgloss.Top, compassRose(marks), legend))
+	return sec
+}
+
+// renderGearSection builds the air temperature and wetsuit suggestion section
+// (water temperature is shown with the wave conditions).
+func renderGearSection(bd *BuoyData) section {
+	sec := newSection(i18n.T("Gear"))
+	if bd == nil {
+		sec.add(i18n.T("No data"))
+		return sec
+	}
+	if bd.tempErr != nil {
+		sec.err, sec.retry = bd.tempErr, SectionWind
+		return sec
+	}
+	if bd.temps == nil {
+		sec.add(i18n.T("Loading..."))
+		return sec
+	}
+	t := bd.temps
+	if t.hasAir {
+		sec.add(i18n.T("air %s", i18n.Temperature(t.airC)))
+	}
+	if gear, ok := RecommendWetsuit(*t); ok {
+		sec.add(i18n.T("suggested: %s", gear))
+	}
 	return sec
 }
 
-// renderTideSection builds the tide timeseries chart and stats.
+// renderTideSection builds the tide timeseries chart and stats for the
+// selected day.
 func renderTideSection(bd *BuoyData) section {
-	sec := newSection("Tide (ft)")
+	sec := newSection(i18n.T("Tide (%s)", units.HeightUnit()))
 	if bd == nil {
-		sec.add("No data")
+		sec.add(i18n.T("No data"))
+		return sec
+	}
+	sec.title += " · " + tideDayLabel(bd.tideDay)
+	if !bd.tideCachedAt.IsZero() {
+		sec.title += " " + cachedLabel(bd.tideCachedAt, time.Now())
+	}
+	if bd.retrying[SectionTide] {
+		sec.add(i18n.T("retrying..."))
 		return sec
 	}
 	if bd.tideErr != nil {
-		sec.err = bd.tideErr
+		sec.err, sec.retry = bd.tideErr, SectionTide
+		return sec
+	}
+	if bd.tide == nil {
+		sec.add(i18n.T("Loading..."))
+		return sec
+	}
+	if len(bd.tide.points) == 0 {
+		if len(bd.tide.extremes) == 0 {
+			sec.add(i18n.T("No tide data"))
+			return sec
+		}
+		// low-bandwidth mode fetches only highs and lows
+		begin, end := tideDayBounds(time.Now(), bd.tideDay)
+		sec.add(renderExtremesList(bd.tide, begin, end))
+		sec.add(nextExtremesLine(bd.tide, time.Now()))
+		sec.add(lightLine(begin))
+		sec.add(dstNote(begin, end))
 		return sec
 	}
-	if bd.tide == nil || len(bd.tide.points) == 0 {
-		sec.add("No tide data")
+	if bd.tideTable {
+		begin, end := tideDayBounds(time.Now(), bd.tideDay)
+		sec.add(renderTideTable(bd.tide, time.Now()))
+		sec.add(nextExtremesLine(bd.tide, time.Now()))
+		sec.add(lightLine(begin))
+		sec.add(dstNote(begin, end))
 		return sec
 	}
 	if len(bd.tide.points) == 1 {
-		sec.add("Insufficient tide points")
+		sec.add(i18n.T("Insufficient tide points"))
 		return sec
 	}
+	now := time.Now()
+	chart, ok := buildTideChart(bd.tide, 42, 10, now)
+	if !ok {
+		sec.add(i18n.T("No parsable tide times"))
+		return sec
+	}
+	for _, line := range chart.lines(now) {
+		sec.add(line)
+	}
+	sec.add(nextExtremesLine(bd.tide, now))
+	return sec
+}
+
+// tideChart is a rendered tide chart with the bounds it was drawn over.
+type tideChart struct {
+	view             string
+	minTime, maxTime time.Time // local
+	minV, maxV       float64
+	marked           bool // highs/lows were drawn
+	shaded           bool // hours outside daylight were shaded
+}
+
+// buildTideChart plots td's predictions with high/low markers and, when now
+// falls inside the range, a current-time line. ok is false when no
+// prediction time parses.
+func buildTideChart(td *TideData, width, height int, now time.Time) (tideChart, bool) {
 	// Build chart (adapted from previous implementation)
 	layout := "2006-01-02 15:04"
-	pts := bd.tide.points
+	pts := td.points
 	var minTime, maxTime time.Time
 	values := make([]float64, len(pts))
 	parsedTimes := make([]time.Time, len(pts))
@@ -87,7 +284,7 @@ func renderTideSection(bd *BuoyData) section {
 		}
 		localTm := gmt.In(time.Local)
 		parsedTimes[i] = localTm
-		values[i] = p.value
+		values[i] = units.FeetValue(p.value)
 		if i == 0 || localTm.Before(minTime) {
 			minTime = localTm
 		}
@@ -96,8 +293,7 @@ func renderTideSection(bd *BuoyData) section {
 		}
 	}
 	if maxTime.IsZero() {
-		sec.add("No parsable tide times")
-		return sec
+		return tideChart{}, false
 	}
 	minV, maxV := values[0], values[0]
 	for _, v := range values[1:] {
@@ -112,7 +308,6 @@ func renderTideSection(bd *BuoyData) section {
 		maxV += 0.1
 		minV -= 0.1
 	}
-	width, height := 42, 10
 	lc := timeserieslinechart.New(width, height)
 	lc.SetTimeRange(minTime, maxTime)
 	lc.SetViewTimeAndYRange(minTime, maxTime, minV, maxV)
@@ -128,7 +323,7 @@ func renderTideSection(bd *BuoyData) section {
 		}
 	}
 	lc.SetXStep(xStep)
-	lc.Model.XLabelFormatter = func(i int, v float64) string { return time.Unix(int64(v), 0).In(time.Local).Format("15:04") }
+	lc.Model.XLabelFormatter = func(i int, v float64) string { return i18n.Time(time.Unix(int64(v), 0).In(time.Local)) }
 	for i, tm := range parsedTimes {
 		if tm.IsZero() {
 			continue
@@ -136,45 +331,57 @@ func renderTideSection(bd *BuoyData) section {
 		lc.Push(timeserieslinechart.TimePoint{Time: tm, Value: values[i]})
 	}
 	lc.DrawBraille()
-	now := time.Now()
+	shaded := shadeDarkness(&lc)
+	marked := markTideExtremes(&lc, td, minTime, maxTime, minV, maxV)
 	if (now.Equal(minTime) || now.After(minTime)) && (now.Equal(maxTime) || now.Before(maxTime)) {
-		viewMin, viewMax := lc.Model.ViewMinX(), lc.Model.ViewMaxX()
-		if viewMax > viewMin {
-			dx := viewMax - viewMin
-			xRel := (float64(now.Unix()) - viewMin) / dx
-			if xRel < 0 {
-				xRel = 0
-			} else if xRel > 1 {
-				xRel = 1
-			}
-			col := int(math.Round(xRel * float64(lc.GraphWidth()-1)))
-			col += lc.Model.Origin().X
-			if lc.Model.YStep() > 0 {
-				col += 1
-			}
-			if col >= 0 && col < lc.Canvas.Width() {
-				lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("159"))
-				for y := 0; y < lc.Model.Origin().Y; y++ {
-					p := canvas.Point{X: col, Y: y}
-					cell := lc.Canvas.Cell(p)
-					if cell.Rune == '│' && cell.Style.GetForeground() != (lipgloss.Style{}).GetForeground() {
-						lc.Canvas.SetCell(p, canvas.NewCellWithStyle('│', lineStyle))
-					} else {
-						lc.Canvas.SetCell(p, canvas.NewCellWithStyle('│', lineStyle))
-					}
-				}
+		if col, ok := nowColumn(&lc, now); ok {
+			lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("159"))
+			for y := 0; y < lc.Model.Origin().Y; y++ {
+				lc.Canvas.SetCell(canvas.Point{X: col, Y: y}, canvas.NewCellWithStyle('│', lineStyle))
 			}
 		}
 	}
-	sec.add(lc.View())
+	return tideChart{view: lc.View(), minTime: minTime, maxTime: maxTime, minV: minV, maxV: maxV, marked: marked, shaded: shaded}, true
+}
+
+// nowColumn returns the canvas column of now on lc's time axis. The axis is
+// in Unix seconds, so on a clock-change day the column follows elapsed time,
+// not the wall clock. ok is false when now falls off the canvas.
+func nowColumn(lc *timeserieslinechart.Model, now time.Time) (col int, ok bool) {
+	viewMin, viewMax := lc.Model.ViewMinX(), lc.Model.ViewMaxX()
+	if viewMax <= viewMin {
+		return 0, false
+	}
+	xRel := min(max((float64(now.Unix())-viewMin)/(viewMax-viewMin), 0), 1)
+	col = int(math.Round(xRel*float64(lc.GraphWidth()-1))) + lc.Model.Origin().X
+	if lc.Model.YStep() > 0 {
+		col++
+	}
+	return col, col >= 0 && col < lc.Canvas.Width()
+}
+
+// lines returns the chart followed by its key and range lines.
+func (c tideChart) lines(now time.Time) []string {
+	lines := []string{c.view}
 	legendStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("44"))
-	sec.add(legendStyle.Render("─") + " " + buoyInfoStyle.Render("Predicted tide"))
-	if now := time.Now(); (now.Equal(minTime) || now.After(minTime)) && (now.Equal(maxTime) || now.Before(maxTime)) {
-		sec.add(lipgloss.NewStyle().Foreground(lipgloss.Color("159")).Render("│") + " " + buoyInfoStyle.Render("Current time"))
+	lines = append(lines, legendStyle.Render("─")+" "+buoyInfoStyle.Render(i18n.T("Predicted tide")))
+	if (now.Equal(c.minTime) || now.After(c.minTime)) && (now.Equal(c.maxTime) || now.Before(c.maxTime)) {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("159")).Render("│")+" "+buoyInfoStyle.Render(i18n.T("Current time")))
 	}
-	tzName, _ := minTime.Zone()
-	sec.add(fmt.Sprintf("min %.2f / max %.2f | %s - %s %s", minV, maxV, minTime.Format("15:04"), maxTime.Format("15:04"), tzName))
-	return sec
+	if c.marked {
+		lines = append(lines, tideExtremeStyle.Render("▲▼")+" "+buoyInfoStyle.Render(i18n.T("High / low tide")))
+	}
+	if c.shaded {
+		lines = append(lines, darknessLegend())
+	}
+	lines = append(lines, i18n.T("min %.2f / max %.2f | %s - %s %s", c.minV, c.maxV, i18n.Time(c.minTime), i18n.Time(c.maxTime), zoneSpan(c.minTime, c.maxTime)))
+	if light := lightLine(c.minTime); light != "" {
+		lines = append(lines, light)
+	}
+	if note := dstNote(c.minTime, c.maxTime); note != "" {
+		lines = append(lines, note)
+	}
+	return lines
 }
 
 // View renders buoy data using section-based layout.
@@ -185,9 +392,9 @@ func View(data *BuoyData) string { return ViewSized(data, 0) }
 // leading ASCII art logo is horizontally centered within that width.
 func ViewSized(data *BuoyData, width int) string {
 	if data == nil {
-		return buoyInfoStyle.Render("No buoy configured yet. Configure in $HOME/.surflog.yaml")
+		return buoyInfoStyle.Render(i18n.T("No buoy configured yet. Configure in $HOME/.surflog.yaml"))
 	}
-	sections := []section{renderWaveSection(data), renderTideSection(data)}
+	sections := []section{renderWaveSection(data), renderWaveTrendSection(data), renderArrivalSection(data, time.Now()), renderOutlookSection(data, time.Now()), renderWindSection(data), renderCompassSection(data), renderGearSection(data), renderWaterTempSection(data, time.Now()), renderDaylightSection(time.Now()), renderAQISection(data, time.Now()), renderTideSection(data)}
 	var b strings.Builder
 	art := `⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⣀⣤⣤⣀⠀⠀⠀
 ⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⢀⣾⣿⣿⣿⣿⣷⠀⠀
@@ -224,20 +431,27 @@ func ViewSized(data *BuoyData, width int) string {
 			b.WriteString("\n\n")
 		}
 		first = false
+		// every line is cut to the column so nothing wraps into the journal pane
 		if s.title != "" {
-			b.WriteString(buoyTitleStyle.Render(s.title))
+			b.WriteString(layout.Truncate(buoyTitleStyle.Render(s.title), width))
 			b.WriteString("\n")
 		}
 		if s.err != nil {
-			b.WriteString(tideErrStyle.Render(s.err.Error()))
+			b.WriteString(layout.Truncate(tideErrStyle.Render(s.err.Error()), width))
+			if hint := netclient.Hint(s.err); hint != "" {
+				b.WriteString("\n" + layout.Truncate(buoyInfoStyle.Render(hint), width))
+			}
+			if s.retry != "" {
+				b.WriteString("\n" + layout.Truncate(buoyInfoStyle.Render(i18n.T("press %s to retry", RetryKey(s.retry))), width))
+			}
 			continue
 		}
 		for i, line := range s.lines {
 			// Chart block (ASCII) already contains newlines internally; print raw
 			if strings.ContainsRune(line, '\n') {
-				b.WriteString(line)
+				b.WriteString(layout.TruncateLines(line, width))
 			} else {
-				b.WriteString(buoyInfoStyle.Render(line))
+				b.WriteString(layout.Truncate(buoyInfoStyle.Render(line), width))
 			}
 			if i < len(s.lines)-1 {
 				b.WriteString("\n")
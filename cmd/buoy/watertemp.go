@@ -0,0 +1,254 @@
+package buoy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/NimbleMarkets/ntcharts/linechart/timeserieslinechart"
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+)
+
+// waterTempWindow is how much of the archive the trend chart shows.
+const waterTempWindow = 30 * 24 * time.Hour
+
+// WaterTempReading is one archived water temperature observation.
+type WaterTempReading struct {
+	Time time.Time `json:"time"`
+	C    float64   `json:"c"`
+	N    int       `json:"n,omitempty"` // observations averaged into a compacted reading; 0 for one
+}
+
+// WaterTempArchive keeps hourly water temperatures per station under
+// <state dir>/watertemp so the seasonal trend survives between runs. Recent
+// readings live in <station>.json; older ones are compacted into
+// <station>.archive.json.gz (see Compact).
+type WaterTempArchive struct {
+	dir string
+}
+
+// NewWaterTempArchive creates an archive under stateDir (created if missing).
+func NewWaterTempArchive(stateDir string) (*WaterTempArchive, error) {
+	if stateDir == "" {
+		return nil, errors.New("empty state dir")
+	}
+	dir := filepath.Join(stateDir, "watertemp")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &WaterTempArchive{dir: dir}, nil
+}
+
+func (a *WaterTempArchive) path(station string) string {
+	return filepath.Join(a.dir, station+".json")
+}
+
+// List returns a station's archived readings, compacted and recent, oldest
+// first.
+func (a *WaterTempArchive) List(station string) ([]WaterTempReading, error) {
+	cold, err := a.readCold(station)
+	if err != nil {
+		return nil, err
+	}
+	hot, err := a.readHot(station)
+	if err != nil {
+		return nil, err
+	}
+	out := append(cold, hot...)
+	sort.Slice(out, func(i, j int) bool { return out[i].Time.Before(out[j].Time) })
+	return out, nil
+}
+
+func (a *WaterTempArchive) readHot(station string) ([]WaterTempReading, error) {
+	b, err := os.ReadFile(a.path(station))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []WaterTempReading
+	return out, json.Unmarshal(b, &out)
+}
+
+// writeHot replaces the recent archive atomically, like writeCold.
+func (a *WaterTempArchive) writeHot(station string, readings []WaterTempReading) error {
+	data, err := json.Marshal(readings)
+	if err != nil {
+		return err
+	}
+	tmp := a.path(station) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, a.path(station))
+}
+
+// Record merges readings into the station's recent archive, keeping at most
+// one per hour, compacts readings past CompactAfter and returns the full
+// history.
+func (a *WaterTempArchive) Record(station string, readings ...WaterTempReading) ([]WaterTempReading, error) {
+	existing, err := a.readHot(station)
+	if err != nil {
+		return nil, err
+	}
+	byHour := map[time.Time]WaterTempReading{}
+	for _, r := range append(existing, readings...) {
+		byHour[r.Time.UTC().Truncate(time.Hour)] = r
+	}
+	out := make([]WaterTempReading, 0, len(byHour))
+	for _, r := range byHour {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Time.Before(out[j].Time) })
+	if err := a.writeHot(station, out); err != nil {
+		return nil, err
+	}
+	if cutoff := time.Now().Add(-CompactAfter()); len(out) > 0 && out[0].Time.Before(cutoff) {
+		if _, err := a.Compact(station, cutoff); err != nil {
+			return nil, err
+		}
+	}
+	return a.List(station)
+}
+
+// GetWaterTempHistory returns every water temperature in the buoy's realtime
+// met file (about 45 days), used to seed an empty archive.
+func (s *dataService) GetWaterTempHistory(ctx context.Context) ([]WaterTempReading, error) {
+	rows, err := s.fetchMetRows(ctx, s.buoyStationID(), 0)
+	if err != nil {
+		return nil, err
+	}
+	var out []WaterTempReading
+	for _, r := range rows {
+		if c, ok := r.get("WTMP"); ok && c < 99 {
+			out = append(out, WaterTempReading{Time: r.time, C: c})
+		}
+	}
+	return out, nil
+}
+
+// archiveWaterTemp adds the latest reading to the archive, seeding it from
+// the realtime file first when it is nearly empty, and returns the history.
+// Errors only cost the chart, so they are swallowed.
+func archiveWaterTemp(ctx context.Context, svc Service, t Temperatures) []WaterTempReading {
+	c, ok := t.Water()
+	if !ok {
+		return nil
+	}
+	archive, err := NewWaterTempArchive(config.StateDir())
+	if err != nil {
+		return nil
+	}
+	station := t.stationId
+	readings := []WaterTempReading{{Time: t.time, C: c}}
+	if existing, _ := archive.List(station); len(existing) < 48 && !config.LowBandwidth() {
+		if seed, err := svc.GetWaterTempHistory(ctx); err == nil {
+			readings = append(seed, readings...)
+		}
+	}
+	history, err := archive.Record(station, readings...)
+	if err != nil {
+		return nil
+	}
+	return history
+}
+
+// dailyWaterTemps averages readings from the last waterTempWindow by local day.
+func dailyWaterTemps(history []WaterTempReading, now time.Time) []WaterTempReading {
+	type acc struct {
+		sum float64
+		n   int
+	}
+	days := map[time.Time]*acc{}
+	for _, r := range history {
+		if now.Sub(r.Time) > waterTempWindow {
+			continue
+		}
+		l := r.Time.In(time.Local)
+		day := time.Date(l.Year(), l.Month(), l.Day(), 12, 0, 0, 0, time.Local)
+		if days[day] == nil {
+			days[day] = &acc{}
+		}
+		days[day].sum += r.C
+		days[day].n++
+	}
+	out := make([]WaterTempReading, 0, len(days))
+	for d, a := range days {
+		out = append(out, WaterTempReading{Time: d, C: a.sum / float64(a.n)})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Time.Before(out[j].Time) })
+	return out
+}
+
+// renderWaterTempSection charts daily mean water temperature over the last
+// 30 days and notes when the suggested wetsuit changed, so seasonal
+// transitions stand out. It is skipped until two days are archived.
+func renderWaterTempSection(bd *BuoyData, now time.Time) section {
+	sec := newSection(i18n.T("Water Temp (30 days, %s)", i18n.TemperatureUnit()))
+	if bd == nil {
+		return sec
+	}
+	days := dailyWaterTemps(bd.waterHistory, now)
+	if len(days) < 2 {
+		return sec
+	}
+	minV, maxV := i18n.TemperatureValue(days[0].C), i18n.TemperatureValue(days[0].C)
+	for _, d := range days {
+		v := i18n.TemperatureValue(d.C)
+		minV, maxV = min(minV, v), max(maxV, v)
+	}
+	if maxV-minV < 2 { // keep day-to-day noise from filling the chart
+		mid := (maxV + minV) / 2
+		minV, maxV = mid-1, mid+1
+	}
+	first, last := days[0].Time, days[len(days)-1].Time
+	lc := timeserieslinechart.New(42, 6)
+	lc.SetTimeRange(first, last)
+	lc.SetViewTimeAndYRange(first, last, minV, maxV)
+	lc.Model.XLabelFormatter = func(i int, v float64) string { return time.Unix(int64(v), 0).In(time.Local).Format("01/02") }
+	lc.Model.YLabelFormatter = func(i int, v float64) string { return fmt.Sprintf("%.0f", v) }
+	for _, d := range days {
+		lc.Push(timeserieslinechart.TimePoint{Time: d.Time, Value: i18n.TemperatureValue(d.C)})
+	}
+	lc.DrawBraille()
+	sec.add(lc.View())
+
+	latest := days[len(days)-1]
+	weekAgo := days[0]
+	for _, d := range days {
+		if latest.Time.Sub(d.Time) <= 7*24*time.Hour {
+			weekAgo = d
+			break
+		}
+	}
+	delta := i18n.TemperatureValue(latest.C) - i18n.TemperatureValue(weekAgo.C)
+	sec.add(i18n.T("%+.1f%s over %d days", delta, i18n.TemperatureUnit(), int(latest.Time.Sub(weekAgo.Time).Hours()/24+0.5)))
+	if switchDay, from, to, ok := gearChange(days); ok {
+		sec.add(i18n.T("gear changed %s: %s → %s", i18n.Date(switchDay), from, to))
+	}
+	return sec
+}
+
+// gearChange finds the most recent day the suggested wetsuit (by water
+// temperature alone) changed.
+func gearChange(days []WaterTempReading) (day time.Time, from, to string, ok bool) {
+	gearFor := func(c float64) string {
+		g, _ := RecommendWetsuit(Temperatures{waterC: c, hasWater: true})
+		return g
+	}
+	for i := len(days) - 1; i > 0; i-- {
+		if prev, cur := gearFor(days[i-1].C), gearFor(days[i].C); prev != cur {
+			return days[i].Time, prev, cur, true
+		}
+	}
+	return time.Time{}, "", "", false
+}
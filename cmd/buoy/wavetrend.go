@@ -0,0 +1,99 @@
+package buoy
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/NimbleMarkets/ntcharts/linechart/timeserieslinechart"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/units"
+)
+
+// trendRows is how many .spec rows GetWaveSummary reads: a day of the usual
+// half-hourly observations. Hourly stations give more than a day, which
+// waveTrend trims.
+const trendRows = 49
+
+// trendSpan is how far back the wave height trend reaches.
+const trendSpan = 24 * time.Hour
+
+// trendWindow is how far back the trend line compares the latest height,
+// and trendSteady the change (m) below which the swell is called steady.
+const (
+	trendWindow = 6 * time.Hour
+	trendSteady = 0.15
+)
+
+// waveTrend turns newest-first .spec rows into the heights within trendSpan
+// of the newest, oldest first.
+func waveTrend(rows []WaveSummary) []WaveObservation {
+	if len(rows) == 0 {
+		return nil
+	}
+	since := rows[0].time.Add(-trendSpan)
+	var out []WaveObservation
+	for i := len(rows) - 1; i >= 0; i-- {
+		if r := rows[i]; !r.time.Before(since) {
+			out = append(out, WaveObservation{Time: r.time, WaveHeight: r.wvht})
+		}
+	}
+	return out
+}
+
+// renderWaveTrendSection charts the significant height over the last day and
+// says whether the swell is building or fading. It is skipped until there
+// are a few hours of observations.
+func renderWaveTrendSection(bd *BuoyData) section {
+	sec := newSection(i18n.T("Wave Height (24h, %s)", units.HeightUnit()))
+	if bd == nil || len(bd.waveTrend) < 2 {
+		return sec
+	}
+	obs := bd.waveTrend
+	first, last := obs[0].Time, obs[len(obs)-1].Time
+	if last.Sub(first) < 3*time.Hour {
+		return sec
+	}
+	minV, maxV := units.HeightValue(obs[0].WaveHeight), units.HeightValue(obs[0].WaveHeight)
+	for _, o := range obs {
+		minV, maxV = min(minV, units.HeightValue(o.WaveHeight)), max(maxV, units.HeightValue(o.WaveHeight))
+	}
+	if span := units.HeightValue(0.3); maxV-minV < span { // a flat line rather than magnified noise
+		mid := (maxV + minV) / 2
+		minV = math.Max(0, mid-span/2)
+		maxV = minV + span
+	}
+	lc := timeserieslinechart.New(42, 5)
+	lc.SetTimeRange(first, last)
+	lc.SetViewTimeAndYRange(first, last, minV, maxV)
+	lc.Model.XLabelFormatter = func(i int, v float64) string { return i18n.Time(time.Unix(int64(v), 0).In(time.Local)) }
+	lc.Model.YLabelFormatter = func(i int, v float64) string { return fmt.Sprintf("%.1f", v) }
+	for _, o := range obs {
+		lc.Push(timeserieslinechart.TimePoint{Time: o.Time, Value: units.HeightValue(o.WaveHeight)})
+	}
+	lc.DrawBraille()
+	sec.add(lc.View())
+
+	latest := obs[len(obs)-1]
+	earlier := obs[0]
+	for _, o := range obs {
+		if latest.Time.Sub(o.Time) <= trendWindow {
+			earlier = o
+			break
+		}
+	}
+	hours := int(latest.Time.Sub(earlier.Time).Hours() + 0.5)
+	if hours < 1 {
+		return sec
+	}
+	delta := latest.WaveHeight - earlier.WaveHeight
+	switch {
+	case delta >= trendSteady:
+		sec.add(i18n.T("building: %+.1f%s over %dh", units.HeightValue(delta), units.HeightUnit(), hours))
+	case delta <= -trendSteady:
+		sec.add(i18n.T("fading: %+.1f%s over %dh", units.HeightValue(delta), units.HeightUnit(), hours))
+	default:
+		sec.add(i18n.T("steady: %+.1f%s over %dh", units.HeightValue(delta), units.HeightUnit(), hours))
+	}
+	return sec
+}
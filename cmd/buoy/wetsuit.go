@@ -0,0 +1,45 @@
+package buoy
+
+import "github.com/spf13/viper"
+
+// gearBand maps a minimum effective water temperature (°C) to a suggestion.
+type gearBand struct {
+	minC float64
+	gear string
+}
+
+// gearBands are ordered warmest first.
+var gearBands = []gearBand{
+	{24, "boardshorts / bikini"},
+	{21, "spring suit or 2mm top"},
+	{18, "3/2"},
+	{15, "4/3"},
+	{12, "4/3 + booties"},
+	{9, "5/4 + booties + hood"},
+}
+
+const coldestGear = "6/5 hooded + gloves + booties"
+
+// RecommendWetsuit turns water and air temperature into a gear suggestion.
+// Comfort offsets come from config: `wetsuit.offset` (°C, positive if you run
+// warm, negative if you feel the cold) and `wetsuit.wind_chill` (°C subtracted
+// when the air is colder than the water).
+func RecommendWetsuit(t Temperatures) (string, bool) {
+	if !t.hasWater {
+		return "", false
+	}
+	effective := t.waterC + viper.GetFloat64("wetsuit.offset")
+	if t.hasAir && t.airC < t.waterC {
+		chill := 1.0
+		if viper.IsSet("wetsuit.wind_chill") {
+			chill = viper.GetFloat64("wetsuit.wind_chill")
+		}
+		effective -= chill
+	}
+	for _, b := range gearBands {
+		if effective >= b.minC {
+			return b.gear, true
+		}
+	}
+	return coldestGear, true
+}
@@ -0,0 +1,151 @@
+package buoy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/direction"
+)
+
+// MSToKnots converts the met file's m/s wind speeds to knots.
+const MSToKnots = 1.94384
+
+// WindSummary holds the latest wind and pressure readings from a buoy's
+// standard meteorological (.txt) file. Speeds are m/s, direction is degrees
+// true (where the wind blows from) and pressure is hPa.
+type WindSummary struct {
+	provider     string // registered provider name; empty is NOAA
+	stationId    string
+	time         time.Time
+	speed        float64
+	gust         float64
+	directionDeg float64
+	pressure     float64
+	hasGust      bool
+	hasDirection bool
+	hasPressure  bool
+}
+
+// windSummaryDTO is the exported representation used for JSON persistence.
+type windSummaryDTO struct {
+	Provider     string    `json:"provider,omitempty"` // empty for NOAA
+	StationID    string    `json:"station_id"`
+	Time         time.Time `json:"time"`
+	Speed        float64   `json:"speed_ms"`
+	Gust         *float64  `json:"gust_ms,omitempty"`
+	DirectionDeg *float64  `json:"direction_deg,omitempty"`
+	Direction    string    `json:"direction,omitempty"`
+	Pressure     *float64  `json:"pressure_hpa,omitempty"`
+}
+
+// MarshalJSON implements custom JSON encoding while keeping internal fields unexported.
+func (w WindSummary) MarshalJSON() ([]byte, error) {
+	dto := windSummaryDTO{Provider: w.provider, StationID: w.stationId, Time: w.time, Speed: w.speed}
+	if w.hasGust {
+		dto.Gust = &w.gust
+	}
+	if w.hasDirection {
+		dto.DirectionDeg = &w.directionDeg
+		dto.Direction = direction.Text(w.directionDeg)
+	}
+	if w.hasPressure {
+		dto.Pressure = &w.pressure
+	}
+	return json.Marshal(dto)
+}
+
+// UnmarshalJSON implements decoding from the DTO representation.
+func (w *WindSummary) UnmarshalJSON(b []byte) error {
+	var dto windSummaryDTO
+	if err := json.Unmarshal(b, &dto); err != nil {
+		return err
+	}
+	*w = WindSummary{provider: dto.Provider, stationId: dto.StationID, time: dto.Time, speed: dto.Speed}
+	if dto.Gust != nil {
+		w.gust, w.hasGust = *dto.Gust, true
+	}
+	if dto.DirectionDeg != nil {
+		w.directionDeg, w.hasDirection = *dto.DirectionDeg, true
+	} else if d, ok := direction.Degrees(dto.Direction); ok {
+		w.directionDeg, w.hasDirection = d, true
+	}
+	if dto.Pressure != nil {
+		w.pressure, w.hasPressure = *dto.Pressure, true
+	}
+	return nil
+}
+
+// IsZero reports whether no wind reading is present.
+func (w WindSummary) IsZero() bool { return w.time.IsZero() && w.speed == 0 }
+
+// Provider returns the name of the provider the reading came from.
+func (w WindSummary) Provider() string { return providerOrNOAA(w.provider) }
+
+// StationID returns the buoy station the reading came from.
+func (w WindSummary) StationID() string { return w.stationId }
+
+// Time returns the observation time (UTC).
+func (w WindSummary) Time() time.Time { return w.time }
+
+// Speed returns the mean wind speed in m/s.
+func (w WindSummary) Speed() float64 { return w.speed }
+
+// Gust returns the peak gust in m/s, if reported.
+func (w WindSummary) Gust() (float64, bool) { return w.gust, w.hasGust }
+
+// Direction returns where the wind blows from in degrees true, if reported.
+func (w WindSummary) Direction() (float64, bool) { return w.directionDeg, w.hasDirection }
+
+// Pressure returns sea level pressure in hPa, if reported.
+func (w WindSummary) Pressure() (float64, bool) { return w.pressure, w.hasPressure }
+
+// String renders e.g. "12kt G18 from WNW (290°) | 1015hPa"; "" when empty.
+func (w WindSummary) String() string {
+	if w.IsZero() {
+		return ""
+	}
+	out := fmt.Sprintf("%.0fkt", w.speed*MSToKnots)
+	if w.hasGust {
+		out += fmt.Sprintf(" G%.0f", w.gust*MSToKnots)
+	}
+	if w.hasDirection {
+		out += fmt.Sprintf(" from %s (%.0f°)", direction.Text(w.directionDeg), w.directionDeg)
+	}
+	if w.hasPressure {
+		out += fmt.Sprintf(" | %.0fhPa", w.pressure)
+	}
+	return out
+}
+
+// GetWindSummary returns the most recent wind speed, gust, direction and
+// pressure from the buoy's standard met file. Gust, direction and pressure
+// look back a few rows when temporarily missing, like temperatures do.
+func (s *dataService) GetWindSummary(ctx context.Context) (WindSummary, error) {
+	rows, err := s.fetchMetRows(ctx, s.buoyStationID(), 6)
+	if err != nil {
+		return WindSummary{}, err
+	}
+	w := WindSummary{stationId: s.buoyStationID()}
+	found := false
+	for _, r := range rows {
+		if v, ok := r.get("WSPD"); ok && !found {
+			w.speed, w.time, found = v, r.time, true
+		}
+		if v, ok := r.get("GST"); ok && !w.hasGust {
+			w.gust, w.hasGust = v, true
+		}
+		if v, ok := r.get("WDIR"); ok && !w.hasDirection && v < 999 {
+			w.directionDeg, w.hasDirection = v, true
+		}
+		if v, ok := r.get("PRES"); ok && !w.hasPressure && v < 9999 {
+			w.pressure, w.hasPressure = v, true
+		}
+	}
+	if !found {
+		return WindSummary{}, errors.New("no wind readings")
+	}
+	return w, nil
+}
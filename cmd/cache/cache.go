@@ -0,0 +1,86 @@
+// Package cache persists the last successful response for each data source
+// so the TUI can show something useful when the network is unavailable.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// ErrMiss is returned by Get when nothing has been cached under a key.
+var ErrMiss = errors.New("not cached")
+
+// Store keeps one JSON file per key under dir.
+type Store struct {
+	dir string
+}
+
+type envelope struct {
+	SavedAt time.Time       `json:"saved_at"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// NewStore creates a cache store under dir (created if missing).
+func NewStore(dir string) (*Store, error) {
+	if dir == "" {
+		return nil, errors.New("empty cache dir")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Store{dir: dir}, nil
+}
+
+var unsafeKey = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func (s *Store) path(key string) string {
+	return filepath.Join(s.dir, unsafeKey.ReplaceAllString(key, "_")+".json")
+}
+
+// Put stores v under key, stamped with the current time. The file is
+// replaced atomically so a crash never leaves a half-written entry.
+func (s *Store) Put(key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(envelope{SavedAt: time.Now().UTC(), Data: data})
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(key))
+}
+
+// Get decodes the value cached under key into v and returns when it was
+// saved. It returns ErrMiss when the key has never been stored.
+func (s *Store) Get(key string, v any) (time.Time, error) {
+	b, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, ErrMiss
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	var env envelope
+	if err := json.Unmarshal(b, &env); err != nil {
+		return time.Time{}, err
+	}
+	return env.SavedAt, json.Unmarshal(env.Data, v)
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/sumwatshade/surflog/cmd/analysis"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/units"
+)
+
+var calibrateCmd = &cobra.Command{
+	Use:   "calibrate",
+	Short: "Compare perceived wave heights with buoy readings",
+	Long: `Correlates the perceived height logged on each entry with the buoy wave
+summary saved alongside it, per spot and across the whole journal. For each
+perceived height it prints the average significant wave height (WVHT) and
+swell period the buoy reported, e.g. "head high averages 2.1m WVHT @ 14s" at
+one spot, so forecasts can be read in your own terms.
+
+r is the correlation between perceived and measured height: near 1 the buoy
+tracks what you see closely, near 0 the spot is shaped more by period,
+direction or tide than by raw size. Only entries with buoy data count.`,
+	Example: `  surflog calibrate
+  surflog calibrate --spot "Ocean Beach"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := loadEntries()
+		if err != nil {
+			return err
+		}
+		spot, _ := cmd.Flags().GetString("spot")
+		reports := analysis.BySpot(entries)
+		if spot != "" {
+			var kept []analysis.SpotReport
+			for _, r := range reports {
+				if strings.EqualFold(r.Spot, strings.TrimSpace(spot)) {
+					kept = append(kept, r)
+				}
+			}
+			if len(kept) == 0 {
+				return fmt.Errorf("no sessions with buoy data at %q", spot)
+			}
+			reports = kept
+		} else if all, ok := analysis.Overall(entries); ok && len(reports) > 1 {
+			reports = append(reports, all)
+		}
+		if len(reports) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No entries with both a perceived height and buoy data yet.")
+			return nil
+		}
+		for i, r := range reports {
+			if i > 0 {
+				fmt.Fprintln(cmd.OutOrStdout())
+			}
+			if err := writeCalibration(cmd.OutOrStdout(), r); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// writeCalibration prints one spot's perceived-height table.
+func writeCalibration(out io.Writer, r analysis.SpotReport) error {
+	head := fmt.Sprintf("%s: %d sessions with buoy data", r.Spot, r.Sessions)
+	if r.Correlation != nil {
+		head += fmt.Sprintf(", r=%.2f", *r.Correlation)
+	}
+	fmt.Fprintln(out, head)
+	w := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
+	for _, b := range r.Buckets {
+		fmt.Fprintf(w, "  %s\taverages %s WVHT @ %.0fs\t(%d)\n", strings.ToLower(create.HeightLabel(b.Perceived)), units.Height(b.AvgWVHT), b.AvgPeriod, b.Sessions)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if r.SweetSpot != nil {
+		fmt.Fprintf(out, "  works best on %s swell (%.1fx buoy height)\n", r.SweetSpot.Label, r.SweetSpot.Ratio)
+	}
+	return nil
+}
+
+func init() {
+	calibrateCmd.Flags().String("spot", "", "only this spot")
+	rootCmd.AddCommand(calibrateCmd)
+}
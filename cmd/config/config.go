@@ -0,0 +1,160 @@
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// JournalDir returns the configured journal directory with a leading ~
+// expanded and relative paths made absolute. Empty when unset.
+func JournalDir() string {
+	return ExpandPath(viper.GetString("journal.dir"))
+}
+
+// FriendsDir returns where entries imported from friends' bundles are kept:
+// a friends/ directory inside the journal, which the journal itself skips.
+func FriendsDir() string {
+	if dir := JournalDir(); dir != "" {
+		return filepath.Join(dir, "friends")
+	}
+	return ""
+}
+
+// User returns the name entries are attributed to: the `user.name` config key,
+// falling back to $USER.
+func User() string {
+	if u := strings.TrimSpace(viper.GetString("user.name")); u != "" {
+		return u
+	}
+	return strings.TrimSpace(os.Getenv("USER"))
+}
+
+// StateDir returns the directory for runtime state (snoozes, caches, timers).
+func StateDir() string {
+	return ExpandPath(viper.GetString("state.dir"))
+}
+
+// CacheDir returns the directory holding the last good NOAA responses
+// (`cache.dir`, ~/.surflog/cache by default).
+func CacheDir() string {
+	return ExpandPath(viper.GetString("cache.dir"))
+}
+
+// QuiverDir returns where surfboards are kept (`quiver.dir`,
+// ~/.surflog/quiver by default).
+func QuiverDir() string {
+	return ExpandPath(viper.GetString("quiver.dir"))
+}
+
+// ExpandPath expands a leading ~ to the home directory and makes relative
+// paths absolute against the working directory.
+func ExpandPath(dir string) string {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return ""
+	}
+	if strings.HasPrefix(dir, "~") {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, strings.TrimPrefix(dir, "~"))
+		}
+	} else if !filepath.IsAbs(dir) {
+		if wd, err := os.Getwd(); err == nil {
+			dir = filepath.Join(wd, dir)
+		}
+	}
+	return dir
+}
+
+// Default coordinates (Ocean Beach, San Francisco) used when `location.lat`
+// and `location.lon` are not configured.
+const (
+	defaultLat = 37.7594
+	defaultLon = -122.5107
+)
+
+// Location returns the configured home lat/lon used for solar calculations
+// and forecasts when a spot has no coordinates of its own.
+func Location() (lat, lon float64) {
+	if lat, lon, ok := Home(); ok {
+		return lat, lon
+	}
+	return defaultLat, defaultLon
+}
+
+// Home returns the configured home location; ok is false when `location.lat`
+// and `location.lon` are unset, rather than falling back like Location.
+func Home() (lat, lon float64, ok bool) {
+	if !viper.IsSet("location.lat") || !viper.IsSet("location.lon") {
+		return 0, 0, false
+	}
+	return viper.GetFloat64("location.lat"), viper.GetFloat64("location.lon"), true
+}
+
+// Metered reports whether the connection is flagged as metered
+// (`network.metered` or --metered); background refreshes are skipped then.
+func Metered() bool {
+	return viper.GetBool("network.metered")
+}
+
+// LowBandwidth reports whether only the minimal products should be fetched
+// (`network.low_bandwidth` or --low-bandwidth), e.g. on a tethered connection.
+func LowBandwidth() bool {
+	return viper.GetBool("network.low_bandwidth")
+}
+
+// RefreshInterval returns the auto-refresh interval for a kind of data
+// (`refresh.waves`, `refresh.tides`, `refresh.forecasts`), or def when unset.
+func RefreshInterval(kind string, def time.Duration) time.Duration {
+	if d := viper.GetDuration("refresh." + kind); d > 0 {
+		return d
+	}
+	return def
+}
+
+// String returns a trimmed config string value.
+func String(key string) string {
+	return strings.TrimSpace(viper.GetString(key))
+}
+
+// File returns the config file in use, or ~/.surflog.yaml when none was found
+// (the file Save creates).
+func File() string {
+	if f := viper.ConfigFileUsed(); f != "" {
+		return f
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".surflog.yaml")
+}
+
+// Save sets key for this run and writes it to the config file, creating the
+// file if needed. Only the file's own settings are rewritten, so defaults,
+// flags and environment overrides are not baked in (comments are lost).
+func Save(key string, value any) error {
+	path := File()
+	if path == "" {
+		return errors.New("no config file location")
+	}
+	v := viper.New()
+	v.SetConfigFile(path)
+	if filepath.Ext(path) == "" {
+		v.SetConfigType("yaml")
+	}
+	if err := v.ReadInConfig(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	v.Set(key, value)
+	if err := v.WriteConfigAs(path); err != nil {
+		return err
+	}
+	viper.Set(key, value)
+	return nil
+}
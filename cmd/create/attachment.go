@@ -0,0 +1,11 @@
+package create
+
+// Attachment is a file kept with an entry (a photo, GPS track, ...). Copied
+// files live under <journal dir>/attachments/<entry id>/ and Path is relative
+// to that directory; referenced files stay where they are and Path is
+// absolute.
+type Attachment struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Copied bool   `json:"copied,omitempty"`
+}
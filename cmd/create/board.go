@@ -0,0 +1,25 @@
+package create
+
+import (
+	"github.com/charmbracelet/huh"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/quiver"
+)
+
+// boardField returns a board selector bound to value, or nil when the quiver
+// is empty so the form doesn't ask.
+func boardField(value *string) huh.Field {
+	svc, err := quiver.NewDefaultService()
+	if err != nil {
+		return nil
+	}
+	boards, err := svc.List()
+	if err != nil || len(boards) == 0 {
+		return nil
+	}
+	opts := []huh.Option[string]{huh.NewOption(i18n.T("No board"), "")}
+	for _, b := range boards {
+		opts = append(opts, huh.NewOption(b.String(), b.ID))
+	}
+	return huh.NewSelect[string]().Title(i18n.T("Board")).Options(opts...).Value(value)
+}
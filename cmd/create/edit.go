@@ -0,0 +1,105 @@
+package create
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+)
+
+// EditForm edits the hand-entered fields of a saved entry (spot, session
+// time, height, duration, waves, rating, board, tags and comments). Snapshot data is left untouched.
+type EditForm struct {
+	entry       Entry
+	form        *huh.Form
+	spotStr     string
+	timeStr     string
+	heightStr   string
+	wavesStr    string
+	rating      int
+	tagsStr     string
+	durationStr string
+	boardID     string
+	commentsStr string
+}
+
+// NewEditForm returns a form pre-filled from e.
+func NewEditForm(e Entry) *EditForm {
+	f := &EditForm{
+		entry:       e,
+		spotStr:     e.Spot,
+		timeStr:     e.SessionAt.Local().Format("2006-01-02 15:04"),
+		heightStr:   e.WaveHeight,
+		rating:      e.Rating,
+		tagsStr:     FormatTags(e.Tags),
+		boardID:     e.BoardID,
+		commentsStr: e.Comments,
+	}
+	if e.WaveCount > 0 {
+		f.wavesStr = strconv.Itoa(e.WaveCount)
+	}
+	if e.DurationMin > 0 {
+		f.durationStr = FormatDuration(e.DurationMin)
+	}
+	fields := []huh.Field{
+		huh.NewInput().Title(i18n.T("Spot")).Value(&f.spotStr).Validate(validateSpot),
+		huh.NewInput().Title(i18n.T("Session time")).Placeholder("YYYY-MM-DD HH:MM").Value(&f.timeStr).Validate(validateSessionTime),
+		huh.NewSelect[string]().Title(i18n.T("Perceived Wave Height")).Options(heightSelectOptions()...).Value(&f.heightStr),
+		huh.NewInput().Title(i18n.T("Duration (optional)")).Placeholder("1h45m").Value(&f.durationStr).Validate(validateDuration),
+		huh.NewInput().Title(i18n.T("Waves caught (optional)")).Placeholder("12").Value(&f.wavesStr).Validate(validateWaveCount),
+		huh.NewSelect[int]().Title(i18n.T("Rating")).Options(ratingSelectOptions()...).Value(&f.rating),
+	}
+	if board := boardField(&f.boardID); board != nil {
+		fields = append(fields, board)
+	}
+	fields = append(fields,
+		huh.NewInput().Title(i18n.T("Tags (optional)")).Placeholder("#dawn-patrol #glassy").Value(&f.tagsStr),
+		huh.NewText().Title(i18n.T("Comments")).Value(&f.commentsStr),
+	)
+	f.form = huh.NewForm(huh.NewGroup(fields...)).WithShowHelp(false).WithTheme(oceanTheme())
+	return f
+}
+
+// ID is the entry being edited.
+func (f *EditForm) ID() string { return f.entry.ID }
+
+// Init focuses the first field.
+func (f *EditForm) Init() tea.Cmd { return f.form.Init() }
+
+// Update forwards msg to the form; done is true once it has been submitted.
+func (f *EditForm) Update(msg tea.Msg) (done bool, cmd tea.Cmd) {
+	updated, cmd := f.form.Update(msg)
+	if hf, ok := updated.(*huh.Form); ok {
+		f.form = hf
+	}
+	return f.form.State == huh.StateCompleted, cmd
+}
+
+// View renders the form.
+func (f *EditForm) View() string { return f.form.View() }
+
+// Apply copies the edited fields onto e.
+func (f *EditForm) Apply(e *Entry) {
+	e.Spot = strings.TrimSpace(f.spotStr)
+	if at, ok := ParseSessionTime(f.timeStr); ok {
+		e.SessionAt = at
+	}
+	e.WaveHeight = f.heightStr
+	e.WaveCount, _ = parseWaveCount(f.wavesStr)
+	e.DurationMin, _ = parseOptionalDuration(f.durationStr)
+	e.Rating = f.rating
+	e.BoardID = f.boardID
+	e.Tags = nil
+	e.AddTags(ParseTags(f.tagsStr)...)
+	e.Comments = f.commentsStr
+}
+
+func validateSpot(v string) error {
+	if strings.TrimSpace(v) == "" {
+		return errors.New(i18n.T("spot required"))
+	}
+	return nil
+}
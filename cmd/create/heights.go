@@ -0,0 +1,67 @@
+package create
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+)
+
+// heightPresets relabel the canonical HeightOptions (same order) for surfers
+// who think in numeric buckets. Entries always store the canonical value.
+var heightPresets = map[string][]string{
+	"feet":   {"0-1ft", "1-2ft", "2-3ft", "3-4ft", "4-5ft", "5-6ft", "6ft+"},
+	"meters": {"<0.3m", "0.3-0.6m", "0.6-1m", "1-1.3m", "1.3-1.6m", "1.6-2m", "2m+"},
+}
+
+// HeightLabels returns the display labels for HeightOptions, chosen by the
+// `wave.heights` config key: "body" (default), "feet", "meters", or a list of
+// exactly len(HeightOptions) custom labels, smallest first.
+func HeightLabels() []string {
+	if custom := viper.GetStringSlice("wave.heights"); len(custom) == len(HeightOptions) {
+		return custom
+	}
+	if preset, ok := heightPresets[strings.ToLower(strings.TrimSpace(viper.GetString("wave.heights")))]; ok {
+		return preset
+	}
+	labels := make([]string, len(HeightOptions))
+	for i, h := range HeightOptions {
+		labels[i] = i18n.T(h)
+	}
+	return labels
+}
+
+// HeightLabel returns the configured display label for a stored height value;
+// unknown (legacy or free-form) values are returned unchanged.
+func HeightLabel(value string) string {
+	labels := HeightLabels()
+	for i, h := range HeightOptions {
+		if strings.EqualFold(h, value) {
+			return labels[i]
+		}
+	}
+	return value
+}
+
+// NormalizeHeight maps either a canonical value or a configured label to the
+// canonical value stored on entries.
+func NormalizeHeight(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	labels := HeightLabels()
+	for i, h := range HeightOptions {
+		if strings.EqualFold(h, s) || strings.EqualFold(labels[i], s) {
+			return h, true
+		}
+	}
+	return s, false
+}
+
+func heightSelectOptions() []huh.Option[string] {
+	labels := HeightLabels()
+	opts := make([]huh.Option[string], 0, len(HeightOptions))
+	for i, h := range HeightOptions {
+		opts = append(opts, huh.NewOption(labels[i], h))
+	}
+	return opts
+}
@@ -1,27 +1,56 @@
 package create
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sumwatshade/surflog/cmd/airquality"
 	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/spots"
 )
 
 // Entry represents a single surf journal entry.
 // ID is assigned by the journal service when creating a new entry.
+//
+// The conditions snapshot (WaveSummary, Wind, WaterTempC, TideFt, AQI and
+// Sources) is recorded once, in the units the providers report, and is not
+// rewritten afterwards: edits only touch the hand-entered fields (see
+// EditForm) and backfill only fills an empty snapshot. Display units, scores
+// and anything else derived from it are recomputed when rendered, so changing
+// them never changes a saved session.
 type Entry struct {
-	ID          string           `json:"id"`
-	Spot        string           `json:"spot"`
-	WaveHeight  string           `json:"wave_height"`
-	WaveSummary buoy.WaveSummary `json:"wave_summary"`
-	SessionAt   time.Time        `json:"session_at"`
-	Comments    string           `json:"comments"`
-	CreatedAt   string           `json:"created_at"`
+	ID          string            `json:"id"`
+	Spot        string            `json:"spot"`
+	Author      string            `json:"author,omitempty"`
+	WaveHeight  string            `json:"wave_height"`
+	WaveSummary buoy.WaveSummary  `json:"wave_summary"`
+	Wind        *buoy.WindSummary `json:"wind,omitempty"`
+	WaterTempC  *float64          `json:"water_temp_c,omitempty"` // buoy water temperature (°C)
+	TideFt      *float64          `json:"tide_ft,omitempty"`      // predicted tide level (ft above MLLW) at the session start
+	SessionAt   time.Time         `json:"session_at"`
+	DurationMin int               `json:"duration_min,omitempty"`
+	WaveCount   int               `json:"wave_count,omitempty"` // waves caught, from memory or a watch track
+	Rating      int               `json:"rating,omitempty"`     // 1–5 stars; 0 = unrated
+	BoardID     string            `json:"board_id,omitempty"`   // board ridden, from the quiver
+	AQI         int               `json:"aqi,omitempty"`
+	Sources     []Source          `json:"sources,omitempty"` // provenance of snapshot data
+	Comments    string            `json:"comments"`
+	Tags        []string          `json:"tags,omitempty"`
+	Attachments []Attachment      `json:"attachments,omitempty"`
+	SharedBy    *Share            `json:"shared_by,omitempty"` // set on entries imported from a friend's bundle
+	CreatedAt   string            `json:"created_at"`
 }
 
-// Height options for perceived wave height.
+// Height options for perceived wave height. These are the values stored on
+// entries; see HeightLabels for how they are displayed.
 var HeightOptions = []string{"Ankle", "Knee", "Waist", "Chest", "Shoulder", "Head", "Overhead"}
 
 // Model using huh form
@@ -30,28 +59,71 @@ type Model struct {
 	form           *huh.Form
 	spotInput      *huh.Input // keep reference to first input to force focus
 	waveService    buoy.Service
+	ctx            context.Context // bounds snapshot fetches; see SetContext
+	spotService    spots.Service
+	notesFor       string // spot name the cached notes belong to
+	notes          string
 	waveErr        error
 	waveFetched    bool
 	timeStr        string
+	defaultTimeStr string // suggested timeStr, replaced while untouched
 	spotStr        string
+	stationStr     string    // manual buoy station override for one-off sessions
+	stationErr     error     // why stationStr is not used, when it is set
+	station        string    // station the current wave summary request is for ("" = default)
+	stationSpot    string    // saved spot whose providers that request uses ("" = none)
+	waveAt         time.Time // session time the current wave summary request is for (zero = latest)
 	heightStr      string
+	wavesStr       string // optional wave count
+	rating         int    // 0 = unrated
+	tagsStr        string // e.g. "#dawn-patrol glassy"
+	durationStr    string // optional session length, e.g. "1h45m"
+	boardID        string // "" = no board
 	commentsStr    string
 	persisted      bool
 	completed      bool // form has been completed
 	confirmed      bool // user confirmed save
 	lastTimeParsed string
+	restored       bool // form was pre-filled from a saved draft
 }
 
 func NewModel() *Model {
 	m := &Model{waveService: buoy.NewService()}
-	now := time.Now()
-	def := time.Date(now.Year(), now.Month(), now.Day(), 7, 30, 0, 0, now.Location())
-	m.timeStr = def.Format("2006-01-02 15:04")
+	if svc, err := spots.NewDefaultService(); err == nil {
+		m.spotService = svc
+	}
+	m.timeStr = DefaultSessionTime(time.Now(), nil).Format("2006-01-02 15:04")
+	m.defaultTimeStr = m.timeStr
 	m.heightStr = HeightOptions[0]
 	m.buildForm()
 	return m
 }
 
+// SetPastSessions lets the default session time learn the usual start from
+// past entries. A time the user has typed or a draft restored is kept.
+func (m *Model) SetPastSessions(past []Entry) {
+	if m == nil || m.timeStr != m.defaultTimeStr {
+		return
+	}
+	m.timeStr = DefaultSessionTime(time.Now(), past).Format("2006-01-02 15:04")
+	m.defaultTimeStr = m.timeStr
+}
+
+// SetContext bounds the form's network fetches so they are abandoned when
+// ctx is cancelled (e.g. on quit).
+func (m *Model) SetContext(ctx context.Context) {
+	if m != nil {
+		m.ctx = ctx
+	}
+}
+
+func (m *Model) context() context.Context {
+	if m == nil || m.ctx == nil {
+		return context.Background()
+	}
+	return m.ctx
+}
+
 // Focus first input (spot) for convenience.
 func (m *Model) Focus() {
 	if m == nil || m.form == nil {
@@ -65,27 +137,29 @@ func (m *Model) Focus() {
 }
 
 func (m *Model) buildForm() {
-	spot := huh.NewInput().Title("Spot").Value(&m.spotStr)
+	spot := huh.NewInput().Title(i18n.T("Spot")).Value(&m.spotStr).Suggestions(m.spotNames())
 	m.spotInput = spot
-	m.form = huh.NewForm(
-		huh.NewGroup(
-			spot,
-			huh.NewSelect[string]().Title("Perceived Wave Height").Options(selectOptions(HeightOptions)...).Value(&m.heightStr),
-			huh.NewText().Title("Comments").Value(&m.commentsStr),
-		),
-	).WithShowHelp(false).WithTheme(oceanTheme())
+	fields := []huh.Field{
+		spot,
+		huh.NewInput().Title(i18n.T("Session time")).Placeholder("YYYY-MM-DD HH:MM").Value(&m.timeStr).Validate(validateSessionTime),
+		huh.NewSelect[string]().Title(i18n.T("Perceived Wave Height")).Options(heightSelectOptions()...).Value(&m.heightStr),
+		huh.NewInput().Title(i18n.T("Duration (optional)")).Placeholder("1h45m").Value(&m.durationStr).Validate(validateDuration),
+		huh.NewInput().Title(i18n.T("Waves caught (optional)")).Placeholder("12").Value(&m.wavesStr).Validate(validateWaveCount),
+		huh.NewSelect[int]().Title(i18n.T("Rating")).Options(ratingSelectOptions()...).Value(&m.rating),
+	}
+	if board := boardField(&m.boardID); board != nil {
+		fields = append(fields, board)
+	}
+	fields = append(fields,
+		huh.NewInput().Title(i18n.T("Tags (optional)")).Placeholder("#dawn-patrol #glassy").Value(&m.tagsStr),
+		huh.NewText().Title(i18n.T("Comments")).Value(&m.commentsStr),
+		huh.NewInput().Title(i18n.T("Buoy station (optional)")).Placeholder(i18n.T("spot or default")).Value(&m.stationStr),
+	)
+	m.form = huh.NewForm(huh.NewGroup(fields...)).WithShowHelp(false).WithTheme(oceanTheme())
 	// Explicit first-field focus.
 	m.Focus()
 }
 
-func selectOptions(vals []string) []huh.Option[string] {
-	opts := make([]huh.Option[string], 0, len(vals))
-	for _, v := range vals {
-		opts = append(opts, huh.NewOption(v, v))
-	}
-	return opts
-}
-
 func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	if m == nil {
 		return nil
@@ -106,34 +180,325 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		m.Entry.WaveHeight = m.heightStr
 		m.Entry.Comments = m.commentsStr
 		m.Entry.SessionAt = parseTimeOrDefault(m.timeStr)
+		m.Entry.WaveCount, _ = parseWaveCount(m.wavesStr)
+		m.Entry.DurationMin, _ = parseOptionalDuration(m.durationStr)
+		m.Entry.Rating = m.rating
+		m.Entry.BoardID = m.boardID
+		m.Entry.Tags = nil
+		m.Entry.AddTags(ParseTags(m.tagsStr)...)
 		return cmd
 	}
-	if !m.waveFetched && m.timeStr != m.lastTimeParsed {
-		if _, err := time.Parse("2006-01-02 15:04", m.timeStr); err == nil {
+	if st, sp := m.wantStation(), m.savedSpot().Name; st != m.station || sp != m.stationSpot {
+		m.station, m.stationSpot = st, sp
+		m.resetWave()
+		return tea.Batch(cmd, m.fetchWaveSummaryCmd())
+	}
+	if m.timeStr != m.lastTimeParsed {
+		if _, ok := ParseSessionTime(m.timeStr); ok {
+			first := m.lastTimeParsed == ""
 			m.lastTimeParsed = m.timeStr
-			return tea.Batch(cmd, m.fetchWaveSummaryCmd())
+			if first {
+				return tea.Batch(cmd, m.fetchWaveSummaryCmd(), fetchAQICmd(m.context()))
+			}
+			// only refetch when the edit changes which observation we want
+			if !m.waveTime().Equal(m.waveAt) {
+				m.resetWave()
+				return tea.Batch(cmd, m.fetchWaveSummaryCmd())
+			}
 		}
 	}
 	return cmd
 }
 
-func parseTimeOrDefault(v string) time.Time {
-	if t, err := time.Parse("2006-01-02 15:04", v); err == nil {
-		return t
+// backdateAfter is how long ago a session must have started before its
+// conditions are looked up historically instead of using the latest reading.
+const backdateAfter = time.Hour
+
+// ParseSessionTime accepts "YYYY-MM-DD HH:MM" or "HH:MM" (today), local time.
+func ParseSessionTime(v string) (time.Time, bool) {
+	v = strings.TrimSpace(v)
+	if t, err := time.ParseInLocation("2006-01-02 15:04", v, time.Local); err == nil {
+		return t, true
 	}
 	if t2, err := time.Parse("15:04", v); err == nil {
 		now := time.Now()
-		return time.Date(now.Year(), now.Month(), now.Day(), t2.Hour(), t2.Minute(), 0, 0, now.Location())
+		return time.Date(now.Year(), now.Month(), now.Day(), t2.Hour(), t2.Minute(), 0, 0, now.Location()), true
+	}
+	return time.Time{}, false
+}
+
+func validateSessionTime(v string) error {
+	if _, ok := ParseSessionTime(v); !ok {
+		return errors.New(i18n.T("use YYYY-MM-DD HH:MM or HH:MM"))
+	}
+	return nil
+}
+
+// parseWaveCount reads the optional wave count; blank means not recorded.
+func parseWaveCount(v string) (int, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, nil
 	}
-	now := time.Now()
-	return time.Date(now.Year(), now.Month(), now.Day(), 7, 30, 0, 0, now.Location())
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, errors.New(i18n.T("enter a whole number of waves"))
+	}
+	return n, nil
+}
+
+func validateWaveCount(v string) error {
+	_, err := parseWaveCount(v)
+	return err
+}
+
+// ParseDuration reads a session length in minutes from minutes ("90"), Go
+// durations ("1h45m") or h:mm ("1:45").
+func ParseDuration(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 {
+		return n, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return int(d.Minutes()), nil
+	}
+	if h, m, ok := strings.Cut(s, ":"); ok {
+		hh, err1 := strconv.Atoi(h)
+		mm, err2 := strconv.Atoi(m)
+		if err1 == nil && err2 == nil && hh >= 0 && mm >= 0 && mm < 60 {
+			return hh*60 + mm, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid duration %q", s)
+}
+
+// parseOptionalDuration reads the form's duration; blank means untimed.
+func parseOptionalDuration(v string) (int, error) {
+	if strings.TrimSpace(v) == "" {
+		return 0, nil
+	}
+	n, err := ParseDuration(v)
+	if err != nil {
+		return 0, errors.New(i18n.T("use minutes, 1h45m or 1:45"))
+	}
+	return n, nil
+}
+
+func validateDuration(v string) error {
+	_, err := parseOptionalDuration(v)
+	return err
+}
+
+func parseTimeOrDefault(v string) time.Time {
+	if t, ok := ParseSessionTime(v); ok {
+		return t
+	}
+	return DefaultSessionTime(time.Now(), nil)
+}
+
+// Backdated reports whether a session starting at at is old enough that its
+// conditions should be looked up historically.
+func Backdated(at time.Time) bool { return time.Since(at) > backdateAfter }
+
+// waveTime is the time conditions should be looked up for: the session time
+// when it is backdated, otherwise zero for the latest observation.
+func (m *Model) waveTime() time.Time {
+	if at := parseTimeOrDefault(m.timeStr); Backdated(at) {
+		return at
+	}
+	return time.Time{}
+}
+
+// resetWave drops the current wave snapshot before it is re-fetched.
+func (m *Model) resetWave() {
+	m.Entry.WaveSummary = buoy.WaveSummary{}
+	m.Entry.Wind = nil
+	m.Entry.WaterTempC = nil
+	m.Entry.clearSource("waves")
+	m.Entry.clearSource("wind")
+	m.Entry.clearSource("water")
+	m.waveFetched = false
+	m.waveErr = nil
 }
 
+// fetchWaveSummaryCmd snapshots conditions for the form's spot. A saved
+// spot or a station override goes through the provider registry, so a
+// spot read from CDIP or Open-Meteo is snapshotted from there too;
+// otherwise the model's own service is used.
 func (m *Model) fetchWaveSummaryCmd() tea.Cmd {
+	station, svc, ctx := m.station, m.waveService, m.context()
+	sp := m.savedSpot()
+	if sp.Name != "" || station != "" {
+		svc = buoy.NewServiceForSpot(sp, station)
+	}
+	at := m.waveTime()
+	m.waveAt = at
 	return func() tea.Msg {
-		ws, err := m.waveService.GetWaveSummary()
-		return waveSummaryMsg{Summary: ws, Err: err}
+		if !at.IsZero() {
+			// only the latest wind and water readings are available, so
+			// backdated sessions get none rather than wrong ones
+			ws, err := svc.GetWaveSummaryAt(ctx, at)
+			return waveSummaryMsg{Summary: ws, Err: err, Station: station, Spot: sp.Name, At: at, FetchedAt: time.Now()}
+		}
+		ws, err := svc.GetWaveSummary(ctx)
+		msg := waveSummaryMsg{Summary: ws, Err: err, Station: station, Spot: sp.Name, FetchedAt: time.Now()}
+		if w, werr := svc.GetWindSummary(ctx); werr == nil {
+			msg.Wind = &w
+		}
+		if t, terr := svc.GetTemperatures(ctx); terr == nil {
+			if c, ok := t.Water(); ok {
+				msg.WaterTempC = &c
+			}
+		}
+		return msg
+	}
+}
+
+// wantStation returns the station override the entry should be snapshotted
+// from once it is valid for the spot's waves provider, otherwise "" for the
+// selected spot's stations or the default. An invalid override is kept in
+// stationErr for the view.
+func (m *Model) wantStation() string {
+	m.stationErr = nil
+	o := strings.ToUpper(strings.TrimSpace(m.stationStr))
+	if o == "" {
+		return ""
 	}
+	if err := buoy.ValidateWaveStation(m.savedSpot(), o); err != nil {
+		m.stationErr = err
+		return ""
+	}
+	return o
+}
+
+// savedSpot returns the form's spot when it is a saved one, otherwise a
+// zero spot (the configured stations and providers).
+func (m *Model) savedSpot() spots.Spot {
+	if m.spotService == nil || strings.TrimSpace(m.spotStr) == "" {
+		return spots.Spot{}
+	}
+	sp, err := m.spotService.Get(m.spotStr)
+	if err != nil {
+		return spots.Spot{}
+	}
+	return sp
+}
+
+// Draft returns the in-progress entry built from the current form values.
+// ok is false when nothing worth keeping has been entered or it was saved.
+func (m *Model) Draft() (Entry, bool) {
+	if m == nil || m.persisted || (m.completed && m.confirmed) {
+		return Entry{}, false
+	}
+	if strings.TrimSpace(m.spotStr) == "" && strings.TrimSpace(m.commentsStr) == "" {
+		return Entry{}, false
+	}
+	e := m.Entry
+	e.Spot = m.spotStr
+	e.WaveHeight = m.heightStr
+	e.Comments = m.commentsStr
+	e.SessionAt = parseTimeOrDefault(m.timeStr)
+	e.WaveCount, _ = parseWaveCount(m.wavesStr)
+	e.DurationMin, _ = parseOptionalDuration(m.durationStr)
+	e.Rating = m.rating
+	e.BoardID = m.boardID
+	e.Tags = nil
+	e.AddTags(ParseTags(m.tagsStr)...)
+	return e, true
+}
+
+// ApplyDraft pre-fills the form from a previously saved draft.
+func (m *Model) ApplyDraft(e Entry) {
+	if m == nil {
+		return
+	}
+	m.spotStr = e.Spot
+	m.commentsStr = e.Comments
+	if e.WaveHeight != "" {
+		m.heightStr = e.WaveHeight
+	}
+	if !e.SessionAt.IsZero() {
+		m.timeStr = e.SessionAt.Format("2006-01-02 15:04")
+	}
+	m.Entry.DurationMin = e.DurationMin
+	if e.DurationMin > 0 {
+		m.durationStr = FormatDuration(e.DurationMin)
+	}
+	if e.WaveCount > 0 {
+		m.wavesStr = strconv.Itoa(e.WaveCount)
+	}
+	m.rating = e.Rating
+	m.boardID = e.BoardID
+	m.tagsStr = FormatTags(e.Tags)
+	m.restored = true
+	m.buildForm()
+}
+
+// spotNames lists saved spots for the spot field's suggestions (tab to accept).
+func (m *Model) spotNames() []string {
+	if m.spotService == nil {
+		return nil
+	}
+	list, err := m.spotService.List()
+	if err != nil {
+		return nil
+	}
+	spots.ByTravel(list) // nearest first
+	names := make([]string, 0, len(list))
+	for _, sp := range list {
+		names = append(names, sp.Name)
+	}
+	return names
+}
+
+// SpotNotes returns standing notes for the spot currently typed in the form.
+func (m *Model) SpotNotes() string {
+	if m == nil || m.spotService == nil || strings.TrimSpace(m.spotStr) == "" {
+		return ""
+	}
+	if m.notesFor != m.spotStr {
+		m.notesFor = m.spotStr
+		m.notes = ""
+		if sp, err := m.spotService.Get(m.spotStr); err == nil {
+			m.notes = strings.TrimSpace(sp.Notes)
+		}
+	}
+	return m.notes
+}
+
+// FormatDuration renders a session length in minutes as e.g. "1h45m".
+func FormatDuration(min int) string {
+	if min < 0 {
+		return ""
+	}
+	if min < 60 {
+		return fmt.Sprintf("%dm", min)
+	}
+	return fmt.Sprintf("%dh%02dm", min/60, min%60)
+}
+
+// WavesPerHour returns the catch rate for waves caught over minutes in the
+// water; ok is false when either is missing.
+func WavesPerHour(waves, minutes int) (float64, bool) {
+	if waves <= 0 || minutes <= 0 {
+		return 0, false
+	}
+	return float64(waves) * 60 / float64(minutes), true
+}
+
+// fetchAQICmd snapshots air quality at the active location for the entry.
+func fetchAQICmd(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		lat, lon := spots.ActiveLocation()
+		r, err := airquality.NewService().Current(ctx, lat, lon)
+		return aqiMsg{Reading: r, Err: err, Source: AQISource(lat, lon, time.Now())}
+	}
+}
+
+type aqiMsg struct {
+	Reading airquality.Reading
+	Err     error
+	Source  Source
 }
 
 // IsDraft indicates form not yet completed.
@@ -150,8 +515,14 @@ func (m *Model) MarkPersisted() {
 }
 
 type waveSummaryMsg struct {
-	Summary buoy.WaveSummary
-	Err     error
+	Summary    buoy.WaveSummary
+	Err        error
+	Station    string            // requested station; stale results are dropped
+	Spot       string            // requested saved spot, likewise
+	At         time.Time         // requested session time (zero = latest); stale results are dropped
+	Wind       *buoy.WindSummary // latest wind, fetched alongside; nil when unavailable
+	WaterTempC *float64          // latest water temperature, likewise
+	FetchedAt  time.Time
 }
 
 // oceanTheme builds a custom ocean-colored theme matching application palette.
@@ -0,0 +1,107 @@
+package create
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/buoy"
+)
+
+// Provider names recorded in entry provenance.
+const (
+	ProviderNDBC      = "NOAA NDBC"
+	ProviderCOOPS     = "NOAA CO-OPS"
+	ProviderOpenMeteo = "Open-Meteo Air Quality"
+	// Modelled waves and wind from buoy.ProviderOpenMeteo.
+	ProviderOpenMeteoMarine  = "Open-Meteo Marine"
+	ProviderOpenMeteoWeather = "Open-Meteo Forecast"
+	// Waves from buoy.ProviderCDIP.
+	ProviderCDIP = "CDIP"
+)
+
+// Source records where one piece of an entry's snapshot data came from, so
+// old entries stay interpretable after stations or providers change.
+type Source struct {
+	Kind      string    `json:"kind"` // "waves", "wind", "water", "tide", "aqi"
+	Provider  string    `json:"provider"`
+	Station   string    `json:"station,omitempty"` // station ID or "lat,lon"
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// String renders e.g. "waves: NOAA NDBC 46274 @ 2025-01-02T07:30:00Z".
+func (s Source) String() string {
+	out := s.Kind + ": " + s.Provider
+	if s.Station != "" {
+		out += " " + s.Station
+	}
+	if !s.FetchedAt.IsZero() {
+		out += " @ " + s.FetchedAt.Format(time.RFC3339)
+	}
+	return out
+}
+
+// Share records where an entry imported from a friend's bundle came from.
+// Imported entries live in the friends journal, never the user's own.
+type Share struct {
+	From       string    `json:"from"`        // who sealed the bundle
+	SharedAt   time.Time `json:"shared_at"`   // when the bundle was sealed
+	ImportedAt time.Time `json:"imported_at"` // when it was imported here
+}
+
+// SetSource records provenance for a kind of data, replacing any earlier
+// source of the same kind.
+func (e *Entry) SetSource(s Source) {
+	for i := range e.Sources {
+		if e.Sources[i].Kind == s.Kind {
+			e.Sources[i] = s
+			return
+		}
+	}
+	e.Sources = append(e.Sources, s)
+}
+
+func (e *Entry) clearSource(kind string) {
+	kept := e.Sources[:0]
+	for _, s := range e.Sources {
+		if s.Kind != kind {
+			kept = append(kept, s)
+		}
+	}
+	e.Sources = kept
+}
+
+// WaveSource describes a wave summary snapshot fetched at the given time.
+func WaveSource(ws buoy.WaveSummary, fetchedAt time.Time) Source {
+	provider := ProviderNDBC
+	switch ws.Provider() {
+	case buoy.ProviderOpenMeteo:
+		provider = ProviderOpenMeteoMarine
+	case buoy.ProviderCDIP:
+		provider = ProviderCDIP
+	}
+	return Source{Kind: "waves", Provider: provider, Station: ws.StationID(), FetchedAt: fetchedAt.UTC().Truncate(time.Second)}
+}
+
+// WindSource describes a wind snapshot fetched at the given time.
+func WindSource(w buoy.WindSummary, fetchedAt time.Time) Source {
+	provider := ProviderNDBC
+	if w.Provider() == buoy.ProviderOpenMeteo {
+		provider = ProviderOpenMeteoWeather
+	}
+	return Source{Kind: "wind", Provider: provider, Station: w.StationID(), FetchedAt: fetchedAt.UTC().Truncate(time.Second)}
+}
+
+// WaterSource describes a water temperature snapshot from a buoy station.
+func WaterSource(station string, fetchedAt time.Time) Source {
+	return Source{Kind: "water", Provider: ProviderNDBC, Station: station, FetchedAt: fetchedAt.UTC().Truncate(time.Second)}
+}
+
+// TideSource describes a predicted tide level from a tide station.
+func TideSource(station string, fetchedAt time.Time) Source {
+	return Source{Kind: "tide", Provider: ProviderCOOPS, Station: station, FetchedAt: fetchedAt.UTC().Truncate(time.Second)}
+}
+
+// AQISource describes an air quality snapshot for a location.
+func AQISource(lat, lon float64, fetchedAt time.Time) Source {
+	return Source{Kind: "aqi", Provider: ProviderOpenMeteo, Station: fmt.Sprintf("%.3f,%.3f", lat, lon), FetchedAt: fetchedAt.UTC().Truncate(time.Second)}
+}
@@ -0,0 +1,28 @@
+package create
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+)
+
+// MaxRating is the top of the 1–5 star session rating; 0 means unrated.
+const MaxRating = 5
+
+// Stars renders a rating as filled and empty stars, e.g. "★★★☆☆". Unrated
+// (or out of range) ratings render as "".
+func Stars(rating int) string {
+	if rating < 1 || rating > MaxRating {
+		return ""
+	}
+	return strings.Repeat("★", rating) + strings.Repeat("☆", MaxRating-rating)
+}
+
+func ratingSelectOptions() []huh.Option[int] {
+	opts := []huh.Option[int]{huh.NewOption(i18n.T("Unrated"), 0)}
+	for r := MaxRating; r >= 1; r-- {
+		opts = append(opts, huh.NewOption(Stars(r), r))
+	}
+	return opts
+}
@@ -0,0 +1,59 @@
+package create
+
+import (
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/solar"
+	"github.com/sumwatshade/surflog/cmd/spots"
+)
+
+// fallbackStart is the default session start (07:30) before there is any
+// history to learn from.
+const fallbackStart = 7*time.Hour + 30*time.Minute
+
+// DefaultSessionTime suggests a session start for a new entry. In daylight
+// it is now rounded down to the half hour, since sessions are usually logged
+// straight after; at night it is the most common start time in past, today
+// (or yesterday, before dawn).
+func DefaultSessionTime(now time.Time, past []Entry) time.Time {
+	if daytime(now) {
+		return now.Truncate(time.Minute).Add(-time.Duration(now.Minute()%30) * time.Minute)
+	}
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	at := day.Add(usualStart(past))
+	if at.After(now) {
+		at = at.AddDate(0, 0, -1)
+	}
+	return at
+}
+
+// daytime reports whether now is between sunrise and sunset at the active
+// spot, or between 06:00 and 20:00 where the sun can't be placed.
+func daytime(now time.Time) bool {
+	lat, lon := spots.ActiveLocation()
+	rise, okRise := solar.Sunrise(now, lat, lon)
+	set, okSet := solar.Sunset(now, lat, lon)
+	if !okRise || !okSet {
+		return now.Hour() >= 6 && now.Hour() < 20
+	}
+	return !now.Before(rise) && now.Before(set)
+}
+
+// usualStart is the most common session start in past, as an offset from
+// midnight rounded to the half hour. Ties go to the earlier time.
+func usualStart(past []Entry) time.Duration {
+	counts := map[time.Duration]int{}
+	best, bestN := fallbackStart, 0
+	for _, e := range past {
+		if e.SessionAt.IsZero() {
+			continue
+		}
+		t := e.SessionAt.Local()
+		slot := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute()/30*30)*time.Minute
+		counts[slot]++
+		if n := counts[slot]; n > bestN || (n == bestN && slot < best) {
+			best, bestN = slot, n
+		}
+	}
+	return best
+}
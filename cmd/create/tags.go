@@ -0,0 +1,77 @@
+package create
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// NormalizeTag lower-cases and trims a tag; spaces become dashes.
+func NormalizeTag(t string) string {
+	return strings.Join(strings.Fields(strings.ToLower(t)), "-")
+}
+
+// HasTag reports whether the entry carries tag (case-insensitive).
+func (e Entry) HasTag(tag string) bool {
+	tag = NormalizeTag(tag)
+	for _, t := range e.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTags adds tags not already present, keeping Tags sorted. It reports
+// whether anything changed.
+func (e *Entry) AddTags(tags ...string) bool {
+	changed := false
+	for _, t := range tags {
+		if t = NormalizeTag(t); t != "" && !e.HasTag(t) {
+			e.Tags = append(e.Tags, t)
+			changed = true
+		}
+	}
+	sort.Strings(e.Tags)
+	return changed
+}
+
+// RemoveTags drops the given tags and reports whether anything changed.
+func (e *Entry) RemoveTags(tags ...string) bool {
+	drop := map[string]bool{}
+	for _, t := range tags {
+		drop[NormalizeTag(t)] = true
+	}
+	kept := e.Tags[:0]
+	for _, t := range e.Tags {
+		if !drop[t] {
+			kept = append(kept, t)
+		}
+	}
+	changed := len(kept) != len(e.Tags)
+	e.Tags = kept
+	if len(e.Tags) == 0 {
+		e.Tags = nil
+	}
+	return changed
+}
+
+// ParseTags splits free-form tag input such as "#dawn-patrol, glassy" on
+// commas and whitespace, dropping any leading '#'.
+func ParseTags(s string) []string {
+	var out []string
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		if t := NormalizeTag(strings.TrimLeft(f, "#")); t != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// FormatTags renders tags for editing, e.g. "#dawn-patrol #glassy".
+func FormatTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return "#" + strings.Join(tags, " #")
+}
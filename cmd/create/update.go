@@ -2,6 +2,7 @@ package create
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sumwatshade/surflog/cmd/spots"
 )
 
 type InitFormMsg struct{}
@@ -15,15 +16,39 @@ func UpdateModel(m *Model, msg tea.Msg) (*Model, tea.Cmd) {
 		if m == nil {
 			m = NewModel()
 		}
-		m.spotStr = ""
+		if !m.restored { // keep a restored draft's values
+			m.spotStr = ""
+			if sp, ok := spots.Current(); ok {
+				m.spotStr = sp.Name
+			}
+		}
+		m.restored = false
 		return m, func() tea.Msg {
 			return FormReadyMsg{}
 		}
+	case aqiMsg:
+		if msg.Err == nil && m != nil {
+			m.Entry.AQI = msg.Reading.AQI
+			m.Entry.SetSource(msg.Source)
+		}
+		return m, nil
 	case waveSummaryMsg:
+		if m == nil || msg.Station != m.station || msg.Spot != m.stationSpot || !msg.At.Equal(m.waveAt) {
+			return m, nil
+		}
 		if msg.Err != nil {
 			m.waveErr = msg.Err
 		} else {
 			m.Entry.WaveSummary = msg.Summary
+			m.Entry.SetSource(WaveSource(msg.Summary, msg.FetchedAt))
+			if msg.Wind != nil {
+				m.Entry.Wind = msg.Wind
+				m.Entry.SetSource(WindSource(*msg.Wind, msg.FetchedAt))
+			}
+			if msg.WaterTempC != nil {
+				m.Entry.WaterTempC = msg.WaterTempC
+				m.Entry.SetSource(WaterSource(msg.Summary.StationID(), msg.FetchedAt))
+			}
 			m.waveFetched = true
 		}
 		return m, nil
@@ -38,7 +63,9 @@ func UpdateModel(m *Model, msg tea.Msg) (*Model, tea.Cmd) {
 				return m, nil
 			}
 			if s == "n" || s == "esc" { // discard and reset
-				return NewModel(), nil
+				fresh := NewModel()
+				fresh.SetContext(m.ctx)
+				return fresh, nil
 			}
 		}
 	}
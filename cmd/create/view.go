@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sumwatshade/surflog/cmd/i18n"
 )
 
 var createTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("44"))
@@ -16,29 +17,61 @@ var highlight = lipgloss.NewStyle().Foreground(lipgloss.Color("159")).Bold(true)
 // View renders the huh form state and supplemental wave info
 func View(m *Model) string {
 	if m == nil {
-		return createTitleStyle.Render("New Entry") + "\n" + faint.Render("(initializing)")
+		return createTitleStyle.Render(i18n.T("New Entry")) + "\n" + faint.Render(i18n.T("(initializing)"))
 	}
 	b := &strings.Builder{}
-	fmt.Fprintln(b, createTitleStyle.Render("New Entry"))
+	fmt.Fprintln(b, createTitleStyle.Render(i18n.T("New Entry")))
 
+	if m.stationErr != nil {
+		fmt.Fprintln(b, errStyle.Render(m.stationErr.Error()))
+	}
 	if m.waveErr != nil {
-		fmt.Fprintln(b, errStyle.Render("Wave fetch error: "+m.waveErr.Error()))
+		fmt.Fprintln(b, errStyle.Render(i18n.T("Wave fetch error: %s", m.waveErr.Error())))
 	}
 
 	if m.waveFetched && m.Entry.WaveSummary.String() != "" {
-		fmt.Fprintln(b, faint.Render("\nWave: ")+m.Entry.WaveSummary.String())
+		fmt.Fprintln(b, faint.Render("\n"+i18n.T("Wave: "))+m.Entry.WaveSummary.String())
+		if m.Entry.Wind != nil {
+			fmt.Fprintln(b, faint.Render(i18n.T("Wind: "))+m.Entry.Wind.String())
+		}
+		if m.Entry.WaterTempC != nil {
+			fmt.Fprintln(b, faint.Render(i18n.T("water %s", i18n.Temperature(*m.Entry.WaterTempC))))
+		}
+		if st := m.Entry.WaveSummary.StationID(); st != "" && (m.station != "" || m.stationSpot != "") {
+			fmt.Fprintln(b, faint.Render(i18n.T("Station: %s", st)))
+		}
+		if !m.waveAt.IsZero() {
+			fmt.Fprintln(b, faint.Render(i18n.T("Observed %s (backdated session)", i18n.DateTime(m.Entry.WaveSummary.Time().In(time.Local)))))
+		}
 	}
-	fmt.Fprintln(b, faint.Render("\nDate: ")+m.Entry.SessionAt.Format(time.Kitchen))
+	sessionAt := m.Entry.SessionAt
+	if sessionAt.IsZero() {
+		sessionAt = parseTimeOrDefault(m.timeStr)
+	}
+	date := i18n.DateTime(sessionAt)
+	minutes, _ := parseOptionalDuration(m.durationStr)
+	if minutes > 0 {
+		date += " (" + FormatDuration(minutes) + ")"
+	}
+	if n, err := parseWaveCount(m.wavesStr); err == nil {
+		if rate, ok := WavesPerHour(n, minutes); ok {
+			date += " " + i18n.T("%.1f waves/h", rate)
+		}
+	}
+	fmt.Fprintln(b, faint.Render("\n"+i18n.T("Date: "))+date)
 
 	if m.form != nil {
 		fmt.Fprintln(b, m.form.View())
 	}
+	if notes := m.SpotNotes(); notes != "" {
+		fmt.Fprintln(b, faint.Render(i18n.T("Spot notes: "))+notes)
+	}
 	if m.completed && !m.persisted {
 		if !m.confirmed {
-			fmt.Fprintf(b, "\nReview: %s | %s | %s\n", m.Entry.Spot, m.Entry.SessionAt.Format(time.Kitchen), m.Entry.WaveHeight)
-			fmt.Fprintln(b, highlight.Render("Press 'y' to confirm save or 'n' to discard & start over."))
+			fmt.Fprintf(b, "\n%s\n", i18n.T("Review: %s | %s | %s", m.Entry.Spot, i18n.DateTime(m.Entry.SessionAt), HeightLabel(m.Entry.WaveHeight)))
+			fmt.Fprintln(b, highlight.Render(i18n.T("Press 'y' to confirm save or 'n' to discard & start over.")))
 		} else {
-			fmt.Fprintf(b, "\nConfirmed. Saving entry...\n")
+			fmt.Fprintf(b, "\n%s\n", i18n.T("Confirmed. Saving entry..."))
 		}
 	}
 	return b.String()
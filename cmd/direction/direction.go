@@ -0,0 +1,71 @@
+// Package direction converts between compass text ("WNW") and degrees true so
+// every data source can share one canonical representation. Degrees are the
+// canonical form; text is derived for display.
+package direction
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// points are the 16 compass points clockwise from north, 22.5° apart.
+var points = []string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+
+// Normalize wraps degrees into [0, 360).
+func Normalize(deg float64) float64 {
+	deg = math.Mod(deg, 360)
+	if deg < 0 {
+		deg += 360
+	}
+	return deg
+}
+
+// Text returns the nearest 16-point compass name for deg.
+func Text(deg float64) string {
+	i := int(math.Round(Normalize(deg)/22.5)) % len(points)
+	return points[i]
+}
+
+// Degrees parses compass text ("WNW", case-insensitive) or a number of
+// degrees ("285", "285°") into degrees true. ok is false for anything else,
+// including NOAA's missing-value markers ("MM", "N/A").
+func Degrees(s string) (float64, bool) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" {
+		return 0, false
+	}
+	for i, p := range points {
+		if s == p {
+			return float64(i) * 22.5, true
+		}
+	}
+	if v, err := strconv.ParseFloat(strings.TrimSuffix(s, "°"), 64); err == nil && v >= 0 && v <= 360 {
+		return Normalize(v), true
+	}
+	return 0, false
+}
+
+// Diff returns the smallest angle between two directions, in [0, 180].
+func Diff(a, b float64) float64 {
+	d := math.Abs(Normalize(a) - Normalize(b))
+	if d > 180 {
+		d = 360 - d
+	}
+	return d
+}
+
+// Mean returns the circular mean of directions; ok is false when empty or
+// when the directions cancel out.
+func Mean(degs []float64) (float64, bool) {
+	var x, y float64
+	for _, d := range degs {
+		r := d * math.Pi / 180
+		x += math.Cos(r)
+		y += math.Sin(r)
+	}
+	if len(degs) == 0 || math.Hypot(x, y) < 1e-9 {
+		return 0, false
+	}
+	return Normalize(math.Atan2(y, x) * 180 / math.Pi), true
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/journal"
+	"github.com/sumwatshade/surflog/cmd/spots"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the journal as CSV, Markdown or JSON",
+	Long: `Writes every journal entry (or those matching --filter), newest first. CSV
+and Markdown flatten the wave summary, wind and water temperature into
+columns, ready for a spreadsheet; JSON is an array of entries as stored.
+
+Without --out the export is written to stdout. The journal view's 'e' key
+writes the same export using the export.format and export.dir settings.
+--share replaces private spot names with their aliases, in the spot column
+and in comments.
+
+--anonymize writes a research dataset instead, oldest first, as csv or JSON:
+conditions, perceived height, duration, waves caught and rating only. Spot
+names become labels like "spot-1" and author, comments, tags, boards,
+attachments and buoy stations are dropped, so the file can be shared with
+surf-science projects.`,
+	Example: `  surflog export --format markdown -o ~/surf.md
+  surflog export --share --filter 'rating:>3' -o best.csv
+  surflog export --anonymize --format json -o research.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		anonymize, _ := cmd.Flags().GetBool("anonymize")
+		write := journal.WriteTable
+		if anonymize {
+			write = journal.WriteAnonymized
+			if f := strings.ToLower(format); f != "csv" && f != "json" {
+				return fmt.Errorf("--anonymize writes %s, not %q", strings.Join(journal.AnonymousFormats, " or "), format)
+			}
+		} else if err := journal.CheckTableFormat(format); err != nil {
+			return err
+		}
+		filter, _ := cmd.Flags().GetString("filter")
+		q, err := journal.ParseQuery(filter)
+		if err != nil {
+			return err
+		}
+		entries, err := loadEntries()
+		if err != nil {
+			return err
+		}
+		journal.SortBySession(entries)
+		var red *spots.Redactor
+		if share, _ := cmd.Flags().GetBool("share"); share {
+			red = spots.DefaultRedactor()
+		}
+		selected := make([]create.Entry, 0, len(entries))
+		for _, e := range entries {
+			if !q.Match(e) {
+				continue
+			}
+			if red != nil {
+				e.Spot, e.Comments = red.Name(e.Spot), red.Text(e.Comments)
+			}
+			selected = append(selected, e)
+		}
+		out, _ := cmd.Flags().GetString("out")
+		if out == "" || out == "-" {
+			return write(cmd.OutOrStdout(), format, selected)
+		}
+		f, err := os.Create(config.ExpandPath(out))
+		if err != nil {
+			return err
+		}
+		if err := write(f, format, selected); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "exported %d entries to %s\n", len(selected), out)
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().String("format", "csv", "output format: "+strings.Join(journal.TableFormats, ", "))
+	exportCmd.Flags().StringP("out", "o", "", "file to write (default stdout)")
+	exportCmd.Flags().String("filter", "", "which entries to export (default all)")
+	exportCmd.Flags().Bool("share", false, "replace private spot names with their aliases")
+	exportCmd.Flags().Bool("anonymize", false, "strip spot names and personal text for sharing with research projects")
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/forecast"
+	"github.com/sumwatshade/surflog/cmd/recommend"
+	"github.com/sumwatshade/surflog/cmd/units"
+)
+
+// forecastCmd groups the forecast archive subcommands.
+var forecastCmd = &cobra.Command{
+	Use:   "forecast",
+	Short: "Archive forecasts and check how well they verified",
+}
+
+var forecastSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Fetch and archive forecasts for your spots (e.g. from cron)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		n, err := recommend.NewModel(nil).Record(cmd.Context())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "fetched %d forecast(s)\n", n)
+		return nil
+	},
+}
+
+var forecastAccuracyCmd = &cobra.Command{
+	Use:   "accuracy [station]",
+	Short: "Compare archived forecasts with buoy observations, per provider",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		archive, err := forecast.NewArchive(config.StateDir())
+		if err != nil {
+			return err
+		}
+		station := ""
+		if len(args) == 1 {
+			station = args[0]
+		}
+		snaps, err := archive.List(station)
+		if err != nil {
+			return err
+		}
+		if len(snaps) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "no archived forecasts yet; open best bets or run `surflog forecast snapshot`")
+			return nil
+		}
+		byStation := map[string][]forecast.Snapshot{}
+		for _, s := range snaps {
+			byStation[s.Station] = append(byStation[s.Station], s)
+		}
+		stations := make([]string, 0, len(byStation))
+		for st := range byStation {
+			stations = append(stations, st)
+		}
+		sort.Strings(stations)
+
+		tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "STATION\tPROVIDER\tLEAD\tPAIRS\tHEIGHT MAE\tBIAS\tPERIOD MAE")
+		scored := 0
+		for _, st := range stations {
+			obs, err := buoy.NewServiceForStations(st, "").GetWaveObservations(cmd.Context())
+			if err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "skipping %s: %v\n", st, err)
+				continue
+			}
+			conv := make([]forecast.Observation, len(obs))
+			for i, o := range obs {
+				conv[i] = forecast.Observation{Time: o.Time, WaveHeight: o.WaveHeight, Period: o.DominantPeriod}
+			}
+			for _, a := range forecast.Score(byStation[st], conv) {
+				period := "-"
+				if a.PeriodPairs > 0 {
+					period = fmt.Sprintf("%.1fs", a.PeriodMAE)
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%+.1f%s\t%s\n", a.Station, a.Provider, a.Lead, a.Pairs, units.Height(a.HeightMAE), units.HeightValue(a.HeightBias), units.HeightUnit(), period)
+				scored++
+			}
+		}
+		if scored == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "no archived forecast hours have been observed yet")
+			return nil
+		}
+		return tw.Flush()
+	},
+}
+
+func init() {
+	forecastCmd.AddCommand(forecastSnapshotCmd, forecastAccuracyCmd)
+	rootCmd.AddCommand(forecastCmd)
+}
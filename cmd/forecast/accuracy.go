@@ -0,0 +1,130 @@
+package forecast
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// maxObservationGap is how far a buoy observation may be from a forecast hour
+// and still be paired with it.
+const maxObservationGap = 30 * time.Minute
+
+// Observation is a measured sea state to score forecasts against.
+type Observation struct {
+	Time       time.Time
+	WaveHeight float64 // significant wave height (m)
+	Period     float64 // dominant period (s); 0 when not reported
+}
+
+// leadBands group errors by how far ahead the forecast was issued.
+var leadBands = []struct {
+	label    string
+	min, max time.Duration
+}{
+	{"day 1", 0, 24 * time.Hour},
+	{"day 2", 24 * time.Hour, 48 * time.Hour},
+	{"day 3+", 48 * time.Hour, math.MaxInt64},
+}
+
+// Accuracy summarises one provider's errors at one station for a lead band.
+// Bias is forecast minus observed, so a positive HeightBias means the
+// provider over-calls the surf.
+type Accuracy struct {
+	Provider    string
+	Station     string
+	Lead        string
+	Pairs       int
+	HeightMAE   float64 // m
+	HeightBias  float64 // m
+	PeriodPairs int
+	PeriodMAE   float64 // s
+}
+
+// Score pairs every archived forecast hour that has already happened with the
+// nearest observation and reports errors per provider, station and lead band.
+// obs are the observations for the snapshots' station.
+func Score(snaps []Snapshot, obs []Observation) []Accuracy {
+	sorted := append([]Observation(nil), obs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	type key struct{ provider, station string }
+	acc := map[key][]Accuracy{}
+	for _, s := range snaps {
+		k := key{s.Provider, s.Station}
+		if acc[k] == nil {
+			acc[k] = make([]Accuracy, len(leadBands))
+			for i, b := range leadBands {
+				acc[k][i] = Accuracy{Provider: s.Provider, Station: s.Station, Lead: b.label}
+			}
+		}
+		for _, p := range s.Points {
+			lead := p.Time.Sub(s.IssuedAt)
+			if lead < 0 {
+				continue
+			}
+			o, ok := nearestObservation(sorted, p.Time)
+			if !ok {
+				continue
+			}
+			for i, b := range leadBands {
+				if lead < b.min || lead >= b.max {
+					continue
+				}
+				a := &acc[k][i]
+				diff := p.WaveHeight - o.WaveHeight
+				a.Pairs++
+				a.HeightMAE += math.Abs(diff)
+				a.HeightBias += diff
+				if o.Period > 0 && p.SwellPeriod > 0 {
+					a.PeriodPairs++
+					a.PeriodMAE += math.Abs(p.SwellPeriod - o.Period)
+				}
+			}
+		}
+	}
+
+	var out []Accuracy
+	for _, bands := range acc {
+		for _, a := range bands {
+			if a.Pairs == 0 {
+				continue
+			}
+			a.HeightMAE /= float64(a.Pairs)
+			a.HeightBias /= float64(a.Pairs)
+			if a.PeriodPairs > 0 {
+				a.PeriodMAE /= float64(a.PeriodPairs)
+			}
+			out = append(out, a)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Station != out[j].Station {
+			return out[i].Station < out[j].Station
+		}
+		if out[i].Provider != out[j].Provider {
+			return out[i].Provider < out[j].Provider
+		}
+		return out[i].Lead < out[j].Lead
+	})
+	return out
+}
+
+// nearestObservation finds the observation closest to t in time-sorted obs.
+func nearestObservation(obs []Observation, t time.Time) (Observation, bool) {
+	i := sort.Search(len(obs), func(i int) bool { return !obs[i].Time.Before(t) })
+	best, bestGap := Observation{}, time.Duration(math.MaxInt64)
+	for _, j := range []int{i - 1, i} {
+		if j < 0 || j >= len(obs) {
+			continue
+		}
+		gap := obs[j].Time.Sub(t)
+		if gap < 0 {
+			gap = -gap
+		}
+		if gap < bestGap {
+			best, bestGap = obs[j], gap
+		}
+	}
+	return best, bestGap <= maxObservationGap
+}
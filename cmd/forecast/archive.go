@@ -0,0 +1,106 @@
+package forecast
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// snapshotInterval is the minimum spacing between archived snapshots for the
+// same provider and station, so reopening the bets view does not pile them up.
+const snapshotInterval = 3 * time.Hour
+
+// snapshotRetention matches how far back realtime buoy observations go;
+// older snapshots can no longer be scored.
+const snapshotRetention = 45 * 24 * time.Hour
+
+// Snapshot is a forecast as issued by a provider, archived so it can later be
+// compared with what the buoy actually measured.
+type Snapshot struct {
+	Provider string    `json:"provider"`
+	Station  string    `json:"station"`         // buoy station the forecast is scored against
+	Label    string    `json:"label,omitempty"` // spot name (or "Home")
+	IssuedAt time.Time `json:"issued_at"`
+	Points   []Point   `json:"points"`
+}
+
+// Archive keeps forecast snapshots as one JSON file each under
+// <state dir>/forecasts.
+type Archive struct {
+	dir string
+}
+
+// NewArchive creates a snapshot archive under stateDir (created if missing).
+func NewArchive(stateDir string) (*Archive, error) {
+	if stateDir == "" {
+		return nil, errors.New("empty state dir")
+	}
+	dir := filepath.Join(stateDir, "forecasts")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Archive{dir: dir}, nil
+}
+
+// Save archives a snapshot unless one for the same provider and station was
+// issued within snapshotInterval. Snapshots past retention are pruned.
+// It reports whether the snapshot was written.
+func (a *Archive) Save(s Snapshot) (bool, error) {
+	if s.Station == "" || len(s.Points) == 0 {
+		return false, errors.New("snapshot needs a station and points")
+	}
+	existing, err := a.List("")
+	if err != nil {
+		return false, err
+	}
+	for _, e := range existing {
+		if s.IssuedAt.Sub(e.IssuedAt) > snapshotRetention {
+			_ = os.Remove(a.path(e))
+			continue
+		}
+		if e.Provider == s.Provider && e.Station == s.Station && s.IssuedAt.Sub(e.IssuedAt) < snapshotInterval {
+			return false, nil
+		}
+	}
+	s.IssuedAt = s.IssuedAt.UTC().Truncate(time.Minute)
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(a.path(s), data, 0o644)
+}
+
+// List returns archived snapshots for station (all stations when empty),
+// oldest first. Unreadable files are skipped.
+func (a *Archive) List(station string) ([]Snapshot, error) {
+	files, err := filepath.Glob(filepath.Join(a.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var out []Snapshot
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		var s Snapshot
+		if json.Unmarshal(b, &s) != nil {
+			continue
+		}
+		if station == "" || s.Station == station {
+			out = append(out, s)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].IssuedAt.Before(out[j].IssuedAt) })
+	return out, nil
+}
+
+func (a *Archive) path(s Snapshot) string {
+	provider := strings.ToLower(strings.ReplaceAll(s.Provider, " ", "-"))
+	return filepath.Join(a.dir, fmt.Sprintf("%s_%s_%s.json", provider, s.Station, s.IssuedAt.UTC().Format("20060102T1504")))
+}
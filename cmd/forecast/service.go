@@ -0,0 +1,118 @@
+package forecast
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/direction"
+	"github.com/sumwatshade/surflog/cmd/netclient"
+)
+
+// Point is a single hourly marine forecast value. Heights are meters, periods
+// seconds and directions degrees true (where the swell comes from).
+type Point struct {
+	Time           time.Time `json:"time"`
+	WaveHeight     float64   `json:"wave_height_m"`
+	SwellHeight    float64   `json:"swell_height_m"`
+	SwellPeriod    float64   `json:"swell_period_s"`
+	SwellDirection float64   `json:"swell_direction_deg"`
+}
+
+// SwellCompass returns the swell direction as compass text (e.g. "WNW").
+func (p Point) SwellCompass() string { return direction.Text(p.SwellDirection) }
+
+// Forecast is an hourly series for one location.
+type Forecast struct {
+	Lat    float64 `json:"lat"`
+	Lon    float64 `json:"lon"`
+	Points []Point `json:"points"`
+}
+
+// Service fetches marine forecasts for a coordinate.
+type Service interface {
+	Get(ctx context.Context, lat, lon float64, days int) (Forecast, error)
+	// Name identifies the provider in archived snapshots and accuracy reports.
+	Name() string
+}
+
+// ProviderOpenMeteo is the name of the Open-Meteo Marine provider.
+const ProviderOpenMeteo = "Open-Meteo Marine"
+
+var _ Service = (*openMeteoService)(nil)
+
+// openMeteoService queries the free Open-Meteo Marine API (no key required).
+type openMeteoService struct {
+	client *http.Client
+}
+
+// NewService returns an Open-Meteo backed forecast service.
+func NewService() Service {
+	return &openMeteoService{client: netclient.Shared()}
+}
+
+func (s *openMeteoService) Name() string { return ProviderOpenMeteo }
+
+func (s *openMeteoService) Get(ctx context.Context, lat, lon float64, days int) (Forecast, error) {
+	if days <= 0 {
+		days = 3
+	}
+	url := fmt.Sprintf("https://marine-api.open-meteo.com/v1/marine?latitude=%.4f&longitude=%.4f"+
+		"&hourly=wave_height,swell_wave_height,swell_wave_period,swell_wave_direction&forecast_days=%d&timezone=GMT",
+		lat, lon, days)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Forecast{}, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return Forecast{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Forecast{}, errors.New("unexpected status code: " + resp.Status)
+	}
+
+	var parsed struct {
+		Hourly struct {
+			Time           []string   `json:"time"`
+			WaveHeight     []*float64 `json:"wave_height"`
+			SwellHeight    []*float64 `json:"swell_wave_height"`
+			SwellPeriod    []*float64 `json:"swell_wave_period"`
+			SwellDirection []*float64 `json:"swell_wave_direction"`
+		} `json:"hourly"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return Forecast{}, err
+	}
+	h := parsed.Hourly
+	fc := Forecast{Lat: lat, Lon: lon}
+	for i, ts := range h.Time {
+		t, err := time.ParseInLocation("2006-01-02T15:04", ts, time.UTC)
+		if err != nil {
+			continue
+		}
+		p := Point{Time: t}
+		ok := true
+		for _, f := range []struct {
+			src []*float64
+			dst *float64
+		}{{h.WaveHeight, &p.WaveHeight}, {h.SwellHeight, &p.SwellHeight}, {h.SwellPeriod, &p.SwellPeriod}, {h.SwellDirection, &p.SwellDirection}} {
+			if i >= len(f.src) || f.src[i] == nil {
+				ok = false
+				break
+			}
+			*f.dst = *f.src[i]
+		}
+		if ok {
+			fc.Points = append(fc.Points, p)
+		}
+	}
+	if len(fc.Points) == 0 {
+		return Forecast{}, errors.New("no forecast data for location")
+	}
+	return fc, nil
+}
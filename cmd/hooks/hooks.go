@@ -0,0 +1,117 @@
+// Package hooks runs user-configured shell commands on lifecycle events, an
+// escape hatch for integrations surflog has no built-in for:
+//
+//	hooks:
+//	  on_entry_created: ~/bin/notify.sh
+//	  on_alert: curl -s -d @- https://example.com/surf
+//
+// Each hook runs with sh -c, gets the event's JSON on stdin and
+// SURFLOG_EVENT in its environment. Output and failures are appended to
+// hooks.log in the state directory, since the TUI owns the terminal.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/config"
+)
+
+// Events hooks can be configured for, as `hooks.on_<event>`.
+const (
+	EntryCreated = "entry_created" // stdin: the saved entry
+	EntryUpdated = "entry_updated" // stdin: the entry after the change
+	EntryDeleted = "entry_deleted" // stdin: the entry as it was
+	Alert        = "alert"         // stdin: the rule, message and reading
+)
+
+// AlertEvent is the payload of the alert hook: a watch rule that started
+// matching, or "conditions" for a big jump spotted by the TUI's refresh.
+type AlertEvent struct {
+	Rule    string             `json:"rule"`
+	Message string             `json:"message"`
+	Reading map[string]float64 `json:"reading,omitempty"` // rule fields, see surflog watch --help
+	Time    time.Time          `json:"time"`
+}
+
+// timeout bounds how long a hook may run. CLI commands wait for it; the
+// TUI runs entry hooks in the background.
+const timeout = time.Minute
+
+// waitDelay is how long a hook's output is still read once it has exited or
+// timed out, in case a child it left in the background holds it open.
+const waitDelay = 5 * time.Second
+
+// Command returns the hook configured for event, or "".
+func Command(event string) string {
+	return strings.TrimSpace(viper.GetString("hooks.on_" + event))
+}
+
+// LogPath is where hook output and failures are recorded.
+func LogPath() string {
+	return filepath.Join(config.StateDir(), "hooks.log")
+}
+
+// Run runs event's hook, if one is configured, with payload as JSON on stdin
+// and env (e.g. SURFLOG_ENTRY_ID=...) on top of SURFLOG_EVENT. Output goes
+// to the hook log; a failure is logged there too and returned.
+func Run(ctx context.Context, event string, payload any, env ...string) error {
+	hook := Command(event)
+	if hook == "" {
+		return nil
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", hook)
+	cmd.Env = append(append(os.Environ(), "SURFLOG_EVENT="+event), env...)
+	cmd.Stdin = bytes.NewReader(data)
+	var out bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &out
+	cmd.WaitDelay = waitDelay
+	err = cmd.Run()
+	if errors.Is(err, exec.ErrWaitDelay) {
+		err = nil // the hook itself succeeded; a child still held its output
+	}
+	if err != nil {
+		err = fmt.Errorf("%s hook: %w", event, err)
+	}
+	record(event, out.Bytes(), err)
+	return err
+}
+
+// record appends a hook's output and any failure to the log, best effort.
+func record(event string, output []byte, err error) {
+	if len(output) == 0 && err == nil {
+		return
+	}
+	if dir := config.StateDir(); dir == "" || os.MkdirAll(dir, 0o755) != nil {
+		return
+	}
+	f, ferr := os.OpenFile(LogPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if ferr != nil {
+		return
+	}
+	defer f.Close()
+	stamp := time.Now().Format(time.RFC3339)
+	for _, line := range strings.Split(strings.TrimRight(string(output), "\n"), "\n") {
+		if line != "" {
+			fmt.Fprintf(f, "%s %s: %s\n", stamp, event, line)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(f, "%s %v\n", stamp, err)
+	}
+}
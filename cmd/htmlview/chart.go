@@ -0,0 +1,69 @@
+package htmlview
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"strings"
+
+	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/units"
+)
+
+// bar is one row of a horizontal bar chart.
+type bar struct {
+	Label string
+	Value float64
+	Note  string // printed after the bar
+}
+
+const (
+	chartWidth  = 520
+	labelWidth  = 110
+	noteWidth   = 90
+	rowHeight   = 26
+	barHeight   = 16
+	chartColour = "#00afaf"
+)
+
+// barChart draws a horizontal bar chart as inline SVG, scaled to the largest
+// value.
+func barChart(bars []bar) template.HTML {
+	if len(bars) == 0 {
+		return ""
+	}
+	maxV := 0.0
+	for _, b := range bars {
+		if b.Value > maxV {
+			maxV = b.Value
+		}
+	}
+	if maxV <= 0 {
+		maxV = 1
+	}
+	span := float64(chartWidth - labelWidth - noteWidth)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg width="%d" height="%d" role="img">`, chartWidth, len(bars)*rowHeight)
+	for i, b := range bars {
+		y := i * rowHeight
+		w := b.Value / maxV * span
+		fmt.Fprintf(&sb, `<text x="0" y="%d">%s</text>`, y+barHeight-3, html.EscapeString(b.Label))
+		fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%.1f" height="%d" rx="3" fill="%s"/>`, labelWidth, y, w, barHeight, chartColour)
+		fmt.Fprintf(&sb, `<text x="%.1f" y="%d">%s</text>`, float64(labelWidth)+w+6, y+barHeight-3, html.EscapeString(b.Note))
+	}
+	sb.WriteString(`</svg>`)
+	return template.HTML(sb.String())
+}
+
+// waveChart compares an entry's significant, swell and wind-wave heights.
+func waveChart(ws buoy.WaveSummary) template.HTML {
+	bars := []bar{{i18n.T("Significant"), ws.SignificantHeight(), units.Height(ws.SignificantHeight())}}
+	if ws.SwellHeight() > 0 {
+		bars = append(bars, bar{i18n.T("Swell"), ws.SwellHeight(), fmt.Sprintf("%s @ %.0fs", units.Height(ws.SwellHeight()), ws.SwellPeriod())})
+	}
+	if ws.WindWaveHeight() > 0 {
+		bars = append(bars, bar{i18n.T("Wind waves"), ws.WindWaveHeight(), fmt.Sprintf("%s @ %.0fs", units.Height(ws.WindWaveHeight()), ws.WindWavePeriod())})
+	}
+	return barChart(bars)
+}
@@ -0,0 +1,168 @@
+// Package htmlview renders journal entries and spot reports as standalone HTML
+// pages (with inline SVG charts) and opens them in the system browser, for
+// comfortable reading and printing.
+package htmlview
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/analysis"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/units"
+)
+
+//go:embed page.html
+var pageTemplate string
+
+var page = template.Must(template.New("page").Funcs(template.FuncMap{"T": i18n.T, "height": units.Height}).Parse(pageTemplate))
+
+type pageData struct {
+	Kind      string // "entry" or "report"
+	Title     string
+	Generated string
+	Entry     entryData
+	Report    reportData
+}
+
+type entryData struct {
+	Spot, When, Duration, Author, Height string
+	AQI                                  int
+	Tags                                 []string
+	Comments                             string
+	Conditions                           string
+	Wind                                 string
+	Water                                string
+	Chart                                template.HTML
+	Sources                              []string
+}
+
+type reportData struct {
+	Spot        string
+	Sessions    int
+	HeightChart template.HTML
+	Buckets     []bucketRow
+	BandChart   template.HTML
+	Bands       []bandRow
+}
+
+type bucketRow struct {
+	Label     string
+	AvgWVHT   float64
+	AvgPeriod float64
+	Sessions  int
+}
+
+type bandRow struct {
+	Label    string
+	AvgWVHT  float64
+	Ratio    float64
+	Sessions int
+	Sweet    bool
+}
+
+// Entry renders a journal entry as an HTML page.
+func Entry(e create.Entry) ([]byte, error) {
+	d := entryData{
+		Spot:     e.Spot,
+		Author:   e.Author,
+		Height:   create.HeightLabel(e.WaveHeight),
+		AQI:      e.AQI,
+		Tags:     e.Tags,
+		Comments: e.Comments,
+	}
+	if !e.SessionAt.IsZero() {
+		d.When = i18n.DateTime(e.SessionAt.In(time.Local))
+	}
+	if e.DurationMin > 0 {
+		d.Duration = create.FormatDuration(e.DurationMin)
+	}
+	if ws := e.WaveSummary; !ws.IsZero() {
+		d.Conditions = ws.String()
+		d.Chart = waveChart(ws)
+	}
+	if e.Wind != nil {
+		d.Wind = e.Wind.String()
+	}
+	if e.WaterTempC != nil {
+		d.Water = i18n.Temperature(*e.WaterTempC)
+	}
+	for _, s := range e.Sources {
+		d.Sources = append(d.Sources, s.String())
+	}
+	return render(pageData{Kind: "entry", Title: e.Spot, Entry: d})
+}
+
+// Report renders a spot's observed-vs-perceived report as an HTML page.
+func Report(r analysis.SpotReport) ([]byte, error) {
+	d := reportData{Spot: r.Spot, Sessions: r.Sessions}
+	var heights, ratios []bar
+	for _, bk := range r.Buckets {
+		label := create.HeightLabel(bk.Perceived)
+		d.Buckets = append(d.Buckets, bucketRow{label, bk.AvgWVHT, bk.AvgPeriod, bk.Sessions})
+		heights = append(heights, bar{label, bk.AvgWVHT, units.Height(bk.AvgWVHT)})
+	}
+	for _, bd := range r.Bands {
+		sweet := r.SweetSpot != nil && bd.Label == r.SweetSpot.Label
+		d.Bands = append(d.Bands, bandRow{bd.Label, bd.AvgWVHT, bd.Ratio, bd.Sessions, sweet})
+		ratios = append(ratios, bar{bd.Label, bd.Ratio, fmt.Sprintf("%.1fx", bd.Ratio)})
+	}
+	d.HeightChart = barChart(heights)
+	d.BandChart = barChart(ratios)
+	return render(pageData{Kind: "report", Title: r.Spot, Report: d})
+}
+
+func render(d pageData) ([]byte, error) {
+	d.Generated = i18n.DateTime(time.Now())
+	var buf bytes.Buffer
+	if err := page.ExecuteTemplate(&buf, "page", d); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Open writes html to a temp file and opens it in the default browser,
+// returning the file path.
+func Open(html []byte) (string, error) {
+	f, err := os.CreateTemp("", "surflog-*.html")
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(html); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	return f.Name(), openBrowser(f.Name())
+}
+
+// openBrowser hands path to the platform's default opener without waiting for
+// the browser to exit.
+func openBrowser(path string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", path)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", path)
+	case "linux", "freebsd", "openbsd", "netbsd":
+		cmd = exec.Command("xdg-open", path)
+	default:
+		return errors.New("don't know how to open a browser on " + runtime.GOOS)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }() // reap the opener
+	return nil
+}
@@ -0,0 +1,593 @@
+package i18n
+
+import (
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// translations maps English message keys to their localized form. Missing keys
+// fall back to the English text.
+var translations = map[language.Tag]map[string]string{
+	language.Spanish: {
+		// app chrome
+		"journal":      "diario",
+		"create":       "crear",
+		"journal view": "ver diario",
+		"create entry": "crear entrada",
+		"help":         "ayuda",
+		"quit":         "salir",
+		// journal
+		"Journal":       "Diario",
+		"Journal Entry": "Entrada del diario",
+		"Loading...":    "Cargando...",
+		"No entries yet. Press 'c' to create one.": "Aún no hay entradas. Pulsa 'c' para crear una.",
+		"Delete entry '%s'? (y/n)":                 "¿Eliminar la entrada '%s'? (y/n)",
+		"journal unavailable":                      "diario no disponible",
+		// create
+		"New Entry":                  "Nueva entrada",
+		"(initializing)":             "(iniciando)",
+		"Wave fetch error: %s":       "Error al obtener olas: %s",
+		"Wave: ":                     "Olas: ",
+		"Date: ":                     "Fecha: ",
+		"Spot":                       "Pico",
+		"Perceived Wave Height":      "Altura de ola percibida",
+		"Comments":                   "Comentarios",
+		"Tags (optional)":            "Etiquetas (opcional)",
+		"Duration (optional)":        "Duración (opcional)",
+		"use minutes, 1h45m or 1:45": "usa minutos, 1h45m o 1:45",
+		"Review: %s | %s | %s":       "Revisión: %s | %s | %s",
+		"Press 'y' to confirm save or 'n' to discard & start over.": "Pulsa 'y' para guardar o 'n' para descartar y empezar de nuevo.",
+		"Confirmed. Saving entry...":                                "Confirmado. Guardando entrada...",
+		"Spot notes: ":                                              "Notas del pico: ",
+		"Notes: %s":                                                 "Notas: %s",
+		// buoy
+		"Current Wave Conditions":  "Condiciones actuales",
+		"Tide (%s)":                "Marea (%s)",
+		"No data":                  "Sin datos",
+		"No tide data":             "Sin datos de marea",
+		"Insufficient tide points": "Puntos de marea insuficientes",
+		"No parsable tide times":   "Horas de marea no válidas",
+		"Predicted tide":           "Marea prevista",
+		"Current time":             "Hora actual",
+		"Gear":                     "Equipo",
+		"water %s":                 "agua %s",
+		"tide %s":                  "marea %s",
+		"air %s":                   "aire %s",
+		"suggested: %s":            "sugerido: %s",
+		"No buoy configured yet. Configure in $HOME/.surflog.yaml": "Aún no hay boya configurada. Configúrala en $HOME/.surflog.yaml",
+		"%s sig (swell %s @ %s %s / wind %s @ %s %s)":              "%s sig (mar de fondo %s @ %s %s / viento %s @ %s %s)",
+		"steep %s | avg %s | mean %d° %s @ %s":                     "pendiente %s | media %s | dir %d° %s @ %s",
+		"min %.2f / max %.2f | %s - %s %s":                         "mín %.2f / máx %.2f | %s - %s %s",
+		"Next %s":                                                  "Próxima %s",
+		"high %s (%s)":                                             "pleamar %s (%s)",
+		"low %s (%s)":                                              "bajamar %s (%s)",
+		"High / low tide":                                          "Pleamar / bajamar",
+		"light %s–%s":                                              "luz %s–%s",
+		"sun %s–%s":                                                "sol %s–%s",
+		"Dark / twilight":                                          "Noche / crepúsculo",
+		"%s swell %s | %.0f km away, %.0fh in transit":             "mar de fondo de %s %s | a %.0f km, %.0fh de tránsito",
+		"Journal writing":                                          "Escritura del diario",
+		"No comments written yet.":                                 "Aún no hay comentarios escritos.",
+		"%d words over %d of %d sessions (avg %.0f)":               "%d palabras en %d de %d sesiones (media %.0f)",
+		"writing streak: %d sessions (best %d)":                    "racha de escritura: %d sesiones (mejor %d)",
+		"1 session this month has an empty comment":                "1 sesión este mes tiene el comentario vacío",
+		"%d sessions this month have empty comments":               "%d sesiones este mes tienen comentarios vacíos",
+		"Wave Height (24h, %s)":                                    "Altura de ola (24h, %s)",
+		"building: %+.1f%s over %dh":                               "creciendo: %+.1f%s en %dh",
+		"fading: %+.1f%s over %dh":                                 "bajando: %+.1f%s en %dh",
+		"steady: %+.1f%s over %dh":                                 "estable: %+.1f%s en %dh",
+		"Directions":                                               "Direcciones",
+		"swell %s %s":                                              "mar de fondo %s %s",
+		"wind waves %s %s":                                         "mar de viento %s %s",
+		"wind %s %.0fkt":                                           "viento %s %.0fkt",
+		"yesterday":                                                "ayer",
+		"today":                                                    "hoy",
+		"tomorrow":                                                 "mañana",
+		"tide day":                                                 "día de marea",
+		"%dh %02dm of light left (sunset %s)":                      "quedan %dh %02dm de luz (puesta de sol %s)",
+		"best bets":                                                "mejores opciones",
+		"Best Bets":                                                "Mejores opciones",
+		"Home":                                                     "Casa",
+		"Forecast error: %s":                                       "Error de pronóstico: %s",
+		"No forecast windows available.":                           "No hay ventanas de pronóstico.",
+		"%s @ %.0fs":                                               "%s @ %.0fs",
+		"Scored against the conditions you usually log.":           "Puntuado según las condiciones que sueles registrar.",
+		"Ranked a point lower per %d min of travel.":               "Un punto menos por cada %d min de viaje.",
+		"best bets view":                                           "ver mejores opciones",
+		"start/stop timer":                                         "iniciar/parar cronómetro",
+		"Journal (mine)":                                           "Diario (mío)",
+		"Journal (by rating)":                                      "Diario (por valoración)",
+		"Journal (mine, by rating)":                                "Diario (mío, por valoración)",
+		"Rating":                                                   "Valoración",
+		"Unrated":                                                  "Sin valorar",
+		"Pick a buoy":                                              "Elige una boya",
+		"Pick a tide station":                                      "Elige una estación de mareas",
+		"(%.0f km radius)":                                         "(radio de %.0f km)",
+		"No stations in view; press - to zoom out.":                "No hay estaciones a la vista; pulsa - para alejar.",
+		"you":          "tú",
+		"buoy":         "boya",
+		"tide station": "estación de mareas",
+		"arrows move • tab next nearest • +/- zoom • enter pick • esc skip": "flechas mover • tab siguiente más cercana • +/- zoom • enter elegir • esc omitir",
+		"by %s":                                  "por %s",
+		"Air Quality":                            "Calidad del aire",
+		"AQI %d (%s) | PM2.5 %.0f µg/m³":         "ICA %d (%s) | PM2.5 %.0f µg/m³",
+		"good":                                   "buena",
+		"moderate":                               "moderada",
+		"unhealthy for sensitive groups":         "dañina para grupos sensibles",
+		"unhealthy":                              "dañina",
+		"very unhealthy":                         "muy dañina",
+		"hazardous":                              "peligrosa",
+		"AQI %d":                                 "ICA %d",
+		"spot report":                            "informe del spot",
+		"spot report view":                       "vista de informe del spot",
+		"Observed vs Perceived":                  "Observado vs percibido",
+		"Not enough entries with buoy data yet.": "Aún no hay suficientes entradas con datos de boya.",
+		"(%d/%d, ←/→ to switch, o to open in browser)":      "(%d/%d, ←/→ para cambiar, o para abrir en el navegador)",
+		"%d sessions with buoy data":                        "%d sesiones con datos de boya",
+		"Perceived height":                                  "Altura percibida",
+		"%s @ %.0fs avg (%d)":                               "%s @ %.0fs prom. (%d)",
+		"Swell period":                                      "Periodo del swell",
+		"%s avg, %.1fx face/buoy (%d)":                      "%s prom., %.1fx cara/boya (%d)",
+		"Sweet spot: %s swell (%.1fx buoy height)":          "Punto ideal: swell de %s (%.1fx altura de boya)",
+		"Log more sessions to find this spot's sweet spot.": "Registra más sesiones para encontrar el punto ideal de este spot.",
+		"Your recap for %s":                                 "Tu resumen de %s",
+		"Sessions: %d":                                      "Sesiones: %d",
+		"Time in the water: %s":                             "Tiempo en el agua: %s",
+		"Top spot: %s":                                      "Spot favorito: %s",
+		"Best day: %s, %s at %s":                            "Mejor día: %s, %s en %s",
+		"Export failed: %v":                                 "Error al exportar: %v",
+		"Saved snippet to %s":                               "Resumen guardado en %s",
+		"e export snippet • enter/esc dismiss":              "e exportar resumen • enter/esc cerrar",
+		"(%d/%d • n/p next/prev • e edit • o open in browser • esc to go back)": "(%d/%d • n/p siguiente/anterior • e editar • o abrir en el navegador • esc para volver)",
+		"Buoy station (optional)":                  "Estación de boya (opcional)",
+		"spot or default":                          "del spot o predeterminada",
+		"Station: %s":                              "Estación: %s",
+		"Data sources:":                            "Fuentes de datos:",
+		"No conditions recorded.":                  "Sin condiciones registradas.",
+		"Looking up buoy history...":               "Buscando historial de la boya...",
+		"esc to cancel":                            "esc para cancelar",
+		"cancelled":                                "cancelado",
+		"Backfill failed: %v":                      "Error al completar: %v",
+		"Press 'f' to backfill from buoy history.": "Pulsa 'f' para completar con el historial de la boya.",
+		"substituting %s (%s offline)":             "sustituyendo %s (%s sin conexión)",
+		"substituting %s (%s offline since %s)":    "sustituyendo %s (%s sin conexión desde %s)",
+		"tide chart/table":                         "marea gráfico/tabla",
+		"next spot":                                "siguiente spot",
+		"Session time":                             "Hora de la sesión",
+		"use YYYY-MM-DD HH:MM or HH:MM":            "usa AAAA-MM-DD HH:MM o HH:MM",
+		"Observed %s (backdated session)":          "Observado %s (sesión pasada)",
+		"Could not open browser: %v":               "No se pudo abrir el navegador: %v",
+		"Opened %s":                                "Abierto %s",
+		"Generated by surflog on %s":               "Generado por surflog el %s",
+		"Perceived: %s":                            "Percibido: %s",
+		"Conditions":                               "Condiciones",
+		"Perceived":                                "Percibido",
+		"Buoy height":                              "Altura de boya",
+		"Period":                                   "Periodo",
+		"Sessions":                                 "Sesiones",
+		"Face/buoy":                                "Cara/boya",
+		"Significant":                              "Significativa",
+		"Swell":                                    "Mar de fondo",
+		"Wind waves":                               "Olas de viento",
+		"Wind":                                     "Viento",
+		"Wind: ":                                   "Viento: ",
+		"@ %s":                                     "@ %s",
+		"%.0fkt":                                   "%.0f nudos",
+		"gusting %.0fkt":                           "rachas de %.0f nudos",
+		"clocks go forward %s at %s (%s → %s)":     "los relojes se adelantan %s a las %s (%s → %s)",
+		"clocks go back %s at %s (%s → %s)":        "los relojes se atrasan %s a las %s (%s → %s)",
+		"week":                                     "semana",
+		"Week":                                     "Semana",
+		"week planner":                             "plan semanal",
+		"Stations":                                 "Estaciones",
+		"stations":                                 "estaciones",
+		"station browser":                          "explorar estaciones",
+		"Could not save %s: %v":                    "No se pudo guardar %s: %v",
+		"Saved %s %s to %s":                        "Guardada %s %s en %s",
+		"Saved %s %s; %s keeps its own":            "Guardada %s %s; %s mantiene la suya",
+		"Waves %s (%s)":                            "Olas %s (%s)",
+		"Wind %s (%s)":                             "Viento %s (%s)",
+		"Predicted %s now":                         "Previsto %s ahora",
+		"Next %s %s at %s":                         "Próxima %s %s a las %s",
+		"Loading station list...":                  "Cargando lista de estaciones...",
+		"/ to search by name or ID":                "/ para buscar por nombre o ID",
+		"Loading latest reading...":                "Cargando última lectura...",
+		"no recent reading":                        "sin lectura reciente",
+		"enter: use this station · /: search":      "enter: usar esta estación · /: buscar",
+		"NDBC station":                             "estación NDBC",
+		"wave buoy":                                "boya de olas",
+		"configured":                               "configurada",
+		"AM":                                       "mañana",
+		"PM":                                       "tarde",
+		"Tides":                                    "Mareas",
+		"busy":                                     "ocupado",
+		"Set week.free in the config to plan around your free mornings and evenings.": "Define week.free en la configuración para planificar según tus mañanas y tardes libres.",
+		"Best spot per free window, scored against the conditions you usually log.":   "Mejor spot por franja libre, puntuado según las condiciones que sueles registrar.",
+		"Swell Forecast (%d days)":            "Pronóstico de swell (%d días)",
+		"Swell Forecast (hourly)":             "Pronóstico de swell (por hora)",
+		"No forecast data":                    "Sin datos de pronóstico",
+		"Swell height (%s)":                   "Altura del swell (%s)",
+		"%-8s %.1f-%.1f%s @ %s %s (peak %s)":  "%-8s %.1f-%.1f%s @ %s %s (máx. %s)",
+		"swell forecast daily/hourly":         "pronóstico de swell diario/por hora",
+		"Mon":                                 "lun",
+		"Tue":                                 "mar",
+		"Wed":                                 "mié",
+		"Thu":                                 "jue",
+		"Fri":                                 "vie",
+		"Sat":                                 "sáb",
+		"Sun":                                 "dom",
+		"Boards by conditions":                "Tablas según condiciones",
+		"No rated sessions with a board yet.": "Aún no hay sesiones valoradas con tabla.",
+		"No board stands out in any conditions yet.":         "Ninguna tabla destaca todavía en ninguna condición.",
+		"▲ %s shines on %s days: %.1f★ vs %.1f★ (%d)":        "▲ %s brilla en días de %s: %.1f★ frente a %.1f★ (%d)",
+		"▼ %s underperforms on %s days: %.1f★ vs %.1f★ (%d)": "▼ %s rinde peor en días de %s: %.1f★ frente a %.1f★ (%d)",
+		"(deleted board)":   "(tabla eliminada)",
+		"retrying...":       "reintentando...",
+		"press %s to retry": "pulsa %s para reintentar",
+		"timed out: the connection may be slow; try a longer --timeout": "tiempo agotado: la conexión puede ser lenta; prueba un --timeout mayor",
+		"can't reach the server: check your connection or proxy":        "no se puede contactar el servidor: revisa tu conexión o proxy",
+		"fix http.proxy or http.tls.ca_file in your config":             "corrige http.proxy o http.tls.ca_file en tu configuración",
+		"network error: check your connection or proxy":                 "error de red: revisa tu conexión o proxy",
+		"rate limited: wait a few minutes before retrying":              "demasiadas peticiones: espera unos minutos antes de reintentar",
+		"the data server is having trouble; try again shortly":          "el servidor de datos tiene problemas; reinténtalo en breve",
+		"retry waves":                          "reintentar olas",
+		"retry wind":                           "reintentar viento",
+		"retry tide":                           "reintentar marea",
+		"retry forecast":                       "reintentar pronóstico",
+		"%s %s → %s (%+.0f%%)":                 "%s %s → %s (%+.0f%%)",
+		"wind switched onshore (%s → %s)":      "el viento roló a tierra (%s → %s)",
+		"wind swung %s → %s":                   "el viento giró %s → %s",
+		"from %s (%.0f°)":                      "del %s (%.0f°)",
+		"pressure %.0fhPa @ %s":                "presión %.0fhPa @ %s",
+		"Water Temp (30 days, %s)":             "Temp. del agua (30 días, %s)",
+		"%+.1f%s over %d days":                 "%+.1f%s en %d días",
+		"gear changed %s: %s → %s":             "cambio de traje %s: %s → %s",
+		"Exported %d entries to %s":            "%d entradas exportadas a %s",
+		"Edit Entry":                           "Editar entrada",
+		"(enter to save • esc to cancel)":      "(enter para guardar • esc para cancelar)",
+		"Save failed: %v":                      "Error al guardar: %v",
+		"No board":                             "Sin tabla",
+		"Board":                                "Tabla",
+		"on %s":                                "con %s",
+		"quiver view":                          "vista de tablas",
+		"stats":                                "estadísticas",
+		"stats view":                           "vista de estadísticas",
+		"Stats":                                "Estadísticas",
+		"No sessions logged yet.":              "Aún no hay sesiones registradas.",
+		"Sessions per month":                   "Sesiones por mes",
+		"Most surfed spots":                    "Spots más surfeados",
+		"Average rating":                       "Valoración media",
+		"No rated sessions yet.":               "Aún no hay sesiones valoradas.",
+		"Buoy height by perceived height":      "Altura de boya según altura percibida",
+		"No sessions with buoy data yet.":      "Aún no hay sesiones con datos de boya.",
+		"busiest month: %d sessions":           "mes con más actividad: %d sesiones",
+		"Jan":                                  "ene",
+		"Feb":                                  "feb",
+		"Mar":                                  "mar",
+		"Apr":                                  "abr",
+		"May":                                  "may",
+		"Jun":                                  "jun",
+		"Jul":                                  "jul",
+		"Aug":                                  "ago",
+		"Sep":                                  "sep",
+		"Oct":                                  "oct",
+		"Nov":                                  "nov",
+		"Dec":                                  "dic",
+		"quiver":                               "tablas",
+		"Quiver":                               "Tablas",
+		"Quiver unavailable: %v":               "Tablas no disponibles: %v",
+		"Delete failed: %v":                    "Error al eliminar: %v",
+		"Deleted %s":                           "%s eliminada",
+		"Saved %s":                             "%s guardada",
+		"Board name":                           "Nombre de la tabla",
+		"Dimensions (optional)":                "Medidas (opcional)",
+		"Volume in litres (optional)":          "Volumen en litros (opcional)",
+		"name required":                        "el nombre es obligatorio",
+		"enter litres, e.g. 33.5":              "introduce litros, p. ej. 33.5",
+		"New board":                            "Nueva tabla",
+		"Edit board":                           "Editar tabla",
+		"enter to save • esc to cancel":        "enter para guardar • esc para cancelar",
+		"No boards yet. Press 'n' to add one.": "Aún no hay tablas. Pulsa 'n' para añadir una.",
+		"· %d sessions":                        "· %d sesiones",
+		"Delete board '%s'? (y/n)":             "¿Eliminar la tabla '%s'? (y/n)",
+		"n new • e edit • x delete":            "n nueva • e editar • x eliminar",
+		"Saved changes":                        "Cambios guardados",
+		"spot required":                        "falta el spot",
+		"⚠ %d unsaved, retrying":               "⚠ %d sin guardar, reintentando",
+	},
+	language.Portuguese: {
+		// app chrome
+		"journal":      "diário",
+		"create":       "criar",
+		"journal view": "ver diário",
+		"create entry": "criar entrada",
+		"help":         "ajuda",
+		"quit":         "sair",
+		// journal
+		"Journal":       "Diário",
+		"Journal Entry": "Entrada do diário",
+		"Loading...":    "Carregando...",
+		"No entries yet. Press 'c' to create one.": "Nenhuma entrada ainda. Pressione 'c' para criar uma.",
+		"Delete entry '%s'? (y/n)":                 "Excluir a entrada '%s'? (y/n)",
+		"journal unavailable":                      "diário indisponível",
+		// create
+		"New Entry":                  "Nova entrada",
+		"(initializing)":             "(iniciando)",
+		"Wave fetch error: %s":       "Erro ao buscar ondas: %s",
+		"Wave: ":                     "Ondas: ",
+		"Date: ":                     "Data: ",
+		"Spot":                       "Pico",
+		"Perceived Wave Height":      "Altura de onda percebida",
+		"Comments":                   "Comentários",
+		"Tags (optional)":            "Etiquetas (opcional)",
+		"Duration (optional)":        "Duração (opcional)",
+		"use minutes, 1h45m or 1:45": "use minutos, 1h45m ou 1:45",
+		"Review: %s | %s | %s":       "Revisão: %s | %s | %s",
+		"Press 'y' to confirm save or 'n' to discard & start over.": "Pressione 'y' para salvar ou 'n' para descartar e recomeçar.",
+		"Confirmed. Saving entry...":                                "Confirmado. Salvando entrada...",
+		"Spot notes: ":                                              "Notas do pico: ",
+		"Notes: %s":                                                 "Notas: %s",
+		// buoy
+		"Current Wave Conditions":  "Condições atuais",
+		"Tide (%s)":                "Maré (%s)",
+		"No data":                  "Sem dados",
+		"No tide data":             "Sem dados de maré",
+		"Insufficient tide points": "Pontos de maré insuficientes",
+		"No parsable tide times":   "Horários de maré inválidos",
+		"Predicted tide":           "Maré prevista",
+		"Current time":             "Hora atual",
+		"Gear":                     "Equipamento",
+		"water %s":                 "água %s",
+		"tide %s":                  "maré %s",
+		"air %s":                   "ar %s",
+		"suggested: %s":            "sugerido: %s",
+		"No buoy configured yet. Configure in $HOME/.surflog.yaml": "Nenhuma boia configurada ainda. Configure em $HOME/.surflog.yaml",
+		"%s sig (swell %s @ %s %s / wind %s @ %s %s)":              "%s sig (ondulação %s @ %s %s / vento %s @ %s %s)",
+		"steep %s | avg %s | mean %d° %s @ %s":                     "inclinação %s | média %s | dir %d° %s @ %s",
+		"min %.2f / max %.2f | %s - %s %s":                         "mín %.2f / máx %.2f | %s - %s %s",
+		"Next %s":                                                  "Próxima %s",
+		"high %s (%s)":                                             "preamar %s (%s)",
+		"low %s (%s)":                                              "baixa-mar %s (%s)",
+		"High / low tide":                                          "Preamar / baixa-mar",
+		"light %s–%s":                                              "luz %s–%s",
+		"sun %s–%s":                                                "sol %s–%s",
+		"Dark / twilight":                                          "Noite / crepúsculo",
+		"%s swell %s | %.0f km away, %.0fh in transit":             "ondulação de %s %s | a %.0f km, %.0fh de trânsito",
+		"Journal writing":                                          "Escrita do diário",
+		"No comments written yet.":                                 "Nenhum comentário escrito ainda.",
+		"%d words over %d of %d sessions (avg %.0f)":               "%d palavras em %d de %d sessões (média %.0f)",
+		"writing streak: %d sessions (best %d)":                    "sequência de escrita: %d sessões (melhor %d)",
+		"1 session this month has an empty comment":                "1 sessão este mês tem comentário vazio",
+		"%d sessions this month have empty comments":               "%d sessões este mês têm comentários vazios",
+		"Wave Height (24h, %s)":                                    "Altura das ondas (24h, %s)",
+		"building: %+.1f%s over %dh":                               "aumentando: %+.1f%s em %dh",
+		"fading: %+.1f%s over %dh":                                 "diminuindo: %+.1f%s em %dh",
+		"steady: %+.1f%s over %dh":                                 "estável: %+.1f%s em %dh",
+		"Directions":                                               "Direções",
+		"swell %s %s":                                              "ondulação %s %s",
+		"wind waves %s %s":                                         "vagas de vento %s %s",
+		"wind %s %.0fkt":                                           "vento %s %.0fkt",
+		"yesterday":                                                "ontem",
+		"today":                                                    "hoje",
+		"tomorrow":                                                 "amanhã",
+		"tide day":                                                 "dia de maré",
+		"%dh %02dm of light left (sunset %s)":                      "restam %dh %02dm de luz (pôr do sol %s)",
+		"best bets":                                                "melhores apostas",
+		"Best Bets":                                                "Melhores apostas",
+		"Home":                                                     "Casa",
+		"Forecast error: %s":                                       "Erro de previsão: %s",
+		"No forecast windows available.":                           "Nenhuma janela de previsão disponível.",
+		"%s @ %.0fs":                                               "%s @ %.0fs",
+		"Scored against the conditions you usually log.":           "Pontuado com base nas condições que você costuma registrar.",
+		"Ranked a point lower per %d min of travel.":               "Um ponto a menos a cada %d min de viagem.",
+		"best bets view":                                           "ver melhores apostas",
+		"start/stop timer":                                         "iniciar/parar cronômetro",
+		"Journal (mine)":                                           "Diário (meu)",
+		"Journal (by rating)":                                      "Diário (por avaliação)",
+		"Journal (mine, by rating)":                                "Diário (meu, por avaliação)",
+		"Rating":                                                   "Avaliação",
+		"Unrated":                                                  "Sem avaliação",
+		"Pick a buoy":                                              "Escolha uma boia",
+		"Pick a tide station":                                      "Escolha uma estação de marés",
+		"(%.0f km radius)":                                         "(raio de %.0f km)",
+		"No stations in view; press - to zoom out.":                "Nenhuma estação à vista; pressione - para afastar.",
+		"you":          "você",
+		"buoy":         "boia",
+		"tide station": "estação de marés",
+		"arrows move • tab next nearest • +/- zoom • enter pick • esc skip": "setas mover • tab próxima mais perto • +/- zoom • enter escolher • esc pular",
+		"by %s":                                  "por %s",
+		"Air Quality":                            "Qualidade do ar",
+		"AQI %d (%s) | PM2.5 %.0f µg/m³":         "IQA %d (%s) | PM2.5 %.0f µg/m³",
+		"good":                                   "boa",
+		"moderate":                               "moderada",
+		"unhealthy for sensitive groups":         "insalubre para grupos sensíveis",
+		"unhealthy":                              "insalubre",
+		"very unhealthy":                         "muito insalubre",
+		"hazardous":                              "perigosa",
+		"AQI %d":                                 "IQA %d",
+		"spot report":                            "relatório do pico",
+		"spot report view":                       "visão do relatório do pico",
+		"Observed vs Perceived":                  "Observado vs percebido",
+		"Not enough entries with buoy data yet.": "Ainda não há entradas suficientes com dados da boia.",
+		"(%d/%d, ←/→ to switch, o to open in browser)":      "(%d/%d, ←/→ para trocar, o para abrir no navegador)",
+		"%d sessions with buoy data":                        "%d sessões com dados da boia",
+		"Perceived height":                                  "Altura percebida",
+		"%s @ %.0fs avg (%d)":                               "%s @ %.0fs méd. (%d)",
+		"Swell period":                                      "Período do swell",
+		"%s avg, %.1fx face/buoy (%d)":                      "%s méd., %.1fx face/boia (%d)",
+		"Sweet spot: %s swell (%.1fx buoy height)":          "Ponto ideal: swell de %s (%.1fx altura da boia)",
+		"Log more sessions to find this spot's sweet spot.": "Registre mais sessões para encontrar o ponto ideal deste pico.",
+		"Your recap for %s":                                 "Seu resumo de %s",
+		"Sessions: %d":                                      "Sessões: %d",
+		"Time in the water: %s":                             "Tempo na água: %s",
+		"Top spot: %s":                                      "Pico favorito: %s",
+		"Best day: %s, %s at %s":                            "Melhor dia: %s, %s em %s",
+		"Export failed: %v":                                 "Falha ao exportar: %v",
+		"Saved snippet to %s":                               "Resumo salvo em %s",
+		"e export snippet • enter/esc dismiss":              "e exportar resumo • enter/esc fechar",
+		"(%d/%d • n/p next/prev • e edit • o open in browser • esc to go back)": "(%d/%d • n/p próxima/anterior • e editar • o abrir no navegador • esc para voltar)",
+		"Buoy station (optional)":                  "Estação da boia (opcional)",
+		"spot or default":                          "do pico ou padrão",
+		"Station: %s":                              "Estação: %s",
+		"Data sources:":                            "Fontes de dados:",
+		"No conditions recorded.":                  "Nenhuma condição registrada.",
+		"Looking up buoy history...":               "Buscando histórico da boia...",
+		"esc to cancel":                            "esc para cancelar",
+		"cancelled":                                "cancelado",
+		"Backfill failed: %v":                      "Falha ao completar: %v",
+		"Press 'f' to backfill from buoy history.": "Pressione 'f' para completar com o histórico da boia.",
+		"substituting %s (%s offline)":             "substituindo %s (%s fora do ar)",
+		"substituting %s (%s offline since %s)":    "substituindo %s (%s fora do ar desde %s)",
+		"tide chart/table":                         "maré gráfico/tabela",
+		"next spot":                                "próximo pico",
+		"Session time":                             "Horário da sessão",
+		"use YYYY-MM-DD HH:MM or HH:MM":            "use AAAA-MM-DD HH:MM ou HH:MM",
+		"Observed %s (backdated session)":          "Observado %s (sessão passada)",
+		"Could not open browser: %v":               "Não foi possível abrir o navegador: %v",
+		"Opened %s":                                "Aberto %s",
+		"Generated by surflog on %s":               "Gerado pelo surflog em %s",
+		"Perceived: %s":                            "Percebido: %s",
+		"Conditions":                               "Condições",
+		"Perceived":                                "Percebido",
+		"Buoy height":                              "Altura da boia",
+		"Period":                                   "Período",
+		"Sessions":                                 "Sessões",
+		"Face/buoy":                                "Face/boia",
+		"Significant":                              "Significativa",
+		"Swell":                                    "Ondulação",
+		"Wind waves":                               "Ondas de vento",
+		"Wind":                                     "Vento",
+		"Wind: ":                                   "Vento: ",
+		"@ %s":                                     "@ %s",
+		"%.0fkt":                                   "%.0f nós",
+		"gusting %.0fkt":                           "rajadas de %.0f nós",
+		"clocks go forward %s at %s (%s → %s)":     "os relógios adiantam %s às %s (%s → %s)",
+		"clocks go back %s at %s (%s → %s)":        "os relógios atrasam %s às %s (%s → %s)",
+		"week":                                     "semana",
+		"Week":                                     "Semana",
+		"week planner":                             "plano semanal",
+		"Stations":                                 "Estações",
+		"stations":                                 "estações",
+		"station browser":                          "explorar estações",
+		"Could not save %s: %v":                    "Não foi possível salvar %s: %v",
+		"Saved %s %s to %s":                        "Salva %s %s em %s",
+		"Saved %s %s; %s keeps its own":            "Salva %s %s; %s mantém a sua",
+		"Waves %s (%s)":                            "Ondas %s (%s)",
+		"Wind %s (%s)":                             "Vento %s (%s)",
+		"Predicted %s now":                         "Previsto %s agora",
+		"Next %s %s at %s":                         "Próxima %s %s às %s",
+		"Loading station list...":                  "Carregando lista de estações...",
+		"/ to search by name or ID":                "/ para buscar por nome ou ID",
+		"Loading latest reading...":                "Carregando última leitura...",
+		"no recent reading":                        "sem leitura recente",
+		"enter: use this station · /: search":      "enter: usar esta estação · /: buscar",
+		"NDBC station":                             "estação NDBC",
+		"wave buoy":                                "boia de ondas",
+		"configured":                               "configurada",
+		"AM":                                       "manhã",
+		"PM":                                       "tarde",
+		"Tides":                                    "Marés",
+		"busy":                                     "ocupado",
+		"Set week.free in the config to plan around your free mornings and evenings.": "Defina week.free na configuração para planejar conforme suas manhãs e tardes livres.",
+		"Best spot per free window, scored against the conditions you usually log.":   "Melhor pico por janela livre, pontuado pelas condições que você costuma registrar.",
+		"Swell Forecast (%d days)":            "Previsão de swell (%d dias)",
+		"Swell Forecast (hourly)":             "Previsão de swell (por hora)",
+		"No forecast data":                    "Sem dados de previsão",
+		"Swell height (%s)":                   "Altura do swell (%s)",
+		"%-8s %.1f-%.1f%s @ %s %s (peak %s)":  "%-8s %.1f-%.1f%s @ %s %s (pico %s)",
+		"swell forecast daily/hourly":         "previsão de swell diária/por hora",
+		"Mon":                                 "seg",
+		"Tue":                                 "ter",
+		"Wed":                                 "qua",
+		"Thu":                                 "qui",
+		"Fri":                                 "sex",
+		"Sat":                                 "sáb",
+		"Sun":                                 "dom",
+		"Boards by conditions":                "Pranchas por condições",
+		"No rated sessions with a board yet.": "Ainda não há sessões avaliadas com prancha.",
+		"No board stands out in any conditions yet.":         "Nenhuma prancha se destaca ainda em nenhuma condição.",
+		"▲ %s shines on %s days: %.1f★ vs %.1f★ (%d)":        "▲ %s brilha em dias de %s: %.1f★ contra %.1f★ (%d)",
+		"▼ %s underperforms on %s days: %.1f★ vs %.1f★ (%d)": "▼ %s rende menos em dias de %s: %.1f★ contra %.1f★ (%d)",
+		"(deleted board)":   "(prancha excluída)",
+		"retrying...":       "tentando de novo...",
+		"press %s to retry": "pressione %s para tentar de novo",
+		"timed out: the connection may be slow; try a longer --timeout": "tempo esgotado: a conexão pode estar lenta; tente um --timeout maior",
+		"can't reach the server: check your connection or proxy":        "não foi possível contatar o servidor: verifique sua conexão ou proxy",
+		"fix http.proxy or http.tls.ca_file in your config":             "corrija http.proxy ou http.tls.ca_file na sua configuração",
+		"network error: check your connection or proxy":                 "erro de rede: verifique sua conexão ou proxy",
+		"rate limited: wait a few minutes before retrying":              "muitas requisições: espere alguns minutos antes de tentar de novo",
+		"the data server is having trouble; try again shortly":          "o servidor de dados está com problemas; tente de novo em breve",
+		"retry waves":                          "tentar ondas de novo",
+		"retry wind":                           "tentar vento de novo",
+		"retry tide":                           "tentar maré de novo",
+		"retry forecast":                       "tentar previsão de novo",
+		"%s %s → %s (%+.0f%%)":                 "%s %s → %s (%+.0f%%)",
+		"wind switched onshore (%s → %s)":      "o vento virou maral (%s → %s)",
+		"wind swung %s → %s":                   "o vento girou %s → %s",
+		"from %s (%.0f°)":                      "de %s (%.0f°)",
+		"pressure %.0fhPa @ %s":                "pressão %.0fhPa @ %s",
+		"Water Temp (30 days, %s)":             "Temp. da água (30 dias, %s)",
+		"%+.1f%s over %d days":                 "%+.1f%s em %d dias",
+		"gear changed %s: %s → %s":             "troca de roupa %s: %s → %s",
+		"Exported %d entries to %s":            "%d entradas exportadas para %s",
+		"Edit Entry":                           "Editar entrada",
+		"(enter to save • esc to cancel)":      "(enter para salvar • esc para cancelar)",
+		"Save failed: %v":                      "Falha ao salvar: %v",
+		"No board":                             "Sem prancha",
+		"Board":                                "Prancha",
+		"on %s":                                "com %s",
+		"quiver view":                          "ver pranchas",
+		"stats":                                "estatísticas",
+		"stats view":                           "visão de estatísticas",
+		"Stats":                                "Estatísticas",
+		"No sessions logged yet.":              "Nenhuma sessão registrada ainda.",
+		"Sessions per month":                   "Sessões por mês",
+		"Most surfed spots":                    "Picos mais surfados",
+		"Average rating":                       "Avaliação média",
+		"No rated sessions yet.":               "Nenhuma sessão avaliada ainda.",
+		"Buoy height by perceived height":      "Altura da boia por altura percebida",
+		"No sessions with buoy data yet.":      "Nenhuma sessão com dados de boia ainda.",
+		"busiest month: %d sessions":           "mês mais movimentado: %d sessões",
+		"Jan":                                  "jan",
+		"Feb":                                  "fev",
+		"Mar":                                  "mar",
+		"Apr":                                  "abr",
+		"May":                                  "mai",
+		"Jun":                                  "jun",
+		"Jul":                                  "jul",
+		"Aug":                                  "ago",
+		"Sep":                                  "set",
+		"Oct":                                  "out",
+		"Nov":                                  "nov",
+		"Dec":                                  "dez",
+		"quiver":                               "pranchas",
+		"Quiver":                               "Pranchas",
+		"Quiver unavailable: %v":               "Pranchas indisponíveis: %v",
+		"Delete failed: %v":                    "Falha ao excluir: %v",
+		"Deleted %s":                           "%s excluída",
+		"Saved %s":                             "%s salva",
+		"Board name":                           "Nome da prancha",
+		"Dimensions (optional)":                "Medidas (opcional)",
+		"Volume in litres (optional)":          "Volume em litros (opcional)",
+		"name required":                        "nome obrigatório",
+		"enter litres, e.g. 33.5":              "informe litros, ex. 33.5",
+		"New board":                            "Nova prancha",
+		"Edit board":                           "Editar prancha",
+		"enter to save • esc to cancel":        "enter para salvar • esc para cancelar",
+		"No boards yet. Press 'n' to add one.": "Nenhuma prancha ainda. Pressione 'n' para adicionar.",
+		"· %d sessions":                        "· %d sessões",
+		"Delete board '%s'? (y/n)":             "Excluir a prancha '%s'? (y/n)",
+		"n new • e edit • x delete":            "n nova • e editar • x excluir",
+		"Saved changes":                        "Alterações salvas",
+		"spot required":                        "pico obrigatório",
+		"⚠ %d unsaved, retrying":               "⚠ %d não salvas, tentando de novo",
+	},
+}
+
+func registerCatalog() {
+	for t, msgs := range translations {
+		for k, v := range msgs {
+			_ = message.SetString(t, k, v)
+		}
+	}
+}
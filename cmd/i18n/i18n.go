@@ -0,0 +1,144 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/units"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// Supported lists the locales with a message catalog. English is the fallback.
+var Supported = []language.Tag{language.English, language.Spanish, language.Portuguese}
+
+var (
+	once    sync.Once
+	tag     language.Tag
+	printer *message.Printer
+)
+
+// resolve picks the locale from the `locale` config key, falling back to the
+// LC_ALL / LC_MESSAGES / LANG environment variables. It runs on first use so
+// config has already been loaded.
+func resolve() {
+	once.Do(func() {
+		registerCatalog()
+		raw := strings.TrimSpace(viper.GetString("locale"))
+		for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+			if raw != "" {
+				break
+			}
+			raw = strings.TrimSpace(os.Getenv(env))
+		}
+		tag = match(raw)
+		printer = message.NewPrinter(tag)
+	})
+}
+
+// match maps a POSIX-style locale ("es_ES.UTF-8") onto a supported tag.
+func match(raw string) language.Tag {
+	if i := strings.IndexAny(raw, ".@"); i >= 0 {
+		raw = raw[:i]
+	}
+	raw = strings.ReplaceAll(raw, "_", "-")
+	if raw == "" || raw == "C" || raw == "POSIX" {
+		return language.English
+	}
+	t, err := language.Parse(raw)
+	if err != nil {
+		return language.English
+	}
+	base, _ := t.Base()
+	for _, s := range Supported {
+		if sb, _ := s.Base(); sb == base {
+			return s
+		}
+	}
+	return language.English
+}
+
+// Locale returns the active locale tag.
+func Locale() language.Tag {
+	resolve()
+	return tag
+}
+
+// T translates a message key (the English text) and formats any args using the
+// active locale, including localized decimal separators.
+func T(key string, args ...any) string {
+	resolve()
+	return printer.Sprintf(key, args...)
+}
+
+// DateTime formats t as a short date and time using the configured or
+// locale-derived layouts.
+func DateTime(t time.Time) string {
+	return t.Format(DateLayout() + " " + TimeLayout())
+}
+
+// Date formats t as a short date.
+func Date(t time.Time) string {
+	return t.Format(DateLayout())
+}
+
+// Time formats t as a clock time honouring the 12/24-hour preference.
+func Time(t time.Time) string {
+	return t.Format(TimeLayout())
+}
+
+// DateLayout returns the Go layout used for dates. The `display.date_format`
+// config key overrides the locale default.
+func DateLayout() string {
+	if l := strings.TrimSpace(viper.GetString("display.date_format")); l != "" {
+		return l
+	}
+	switch Locale() {
+	case language.Spanish, language.Portuguese:
+		return "02/01/2006"
+	default:
+		return "2006-01-02"
+	}
+}
+
+// Temperature formats a Celsius reading in the unit chosen by
+// `display.temperature` ("F" or "C", defaulting to the `units` system's),
+// e.g. "58°F".
+func Temperature(c float64) string {
+	return fmt.Sprintf("%.0f%s", TemperatureValue(c), TemperatureUnit())
+}
+
+// TemperatureValue converts a Celsius reading to the display unit, for charts.
+func TemperatureValue(c float64) float64 {
+	if celsius() {
+		return c
+	}
+	return c*9/5 + 32
+}
+
+// TemperatureUnit returns "°C" or "°F", as Temperature uses.
+func TemperatureUnit() string {
+	if celsius() {
+		return "°C"
+	}
+	return "°F"
+}
+
+func celsius() bool { return units.Celsius() }
+
+// TimeLayout returns the Go layout used for clock times. `display.time_format`
+// overrides everything; otherwise `display.clock` (12 or 24) selects the style,
+// defaulting to 24-hour.
+func TimeLayout() string {
+	if l := strings.TrimSpace(viper.GetString("display.time_format")); l != "" {
+		return l
+	}
+	if viper.GetInt("display.clock") == 12 {
+		return "3:04PM"
+	}
+	return "15:04"
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/journal"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <path>",
+	Short: "Import sessions logged elsewhere (CSV or Surfline JSON)",
+	Long: `Reads sessions from another log and adds them to the journal with new IDs.
+Sessions at the same spot and minute as an existing entry are skipped, so
+re-running an import is harmless.
+
+csv            header row required; recognised columns include spot/location,
+               session_at or date + time, height/size (option name or feet),
+               duration (minutes, 1h30m or 1:30), waves, comments/notes, tags
+               and author. ` + "`surflog export`" + ` output round-trips.
+surfline-json  a Surfline session export (a list of sessions, or {"sessions": [...]})
+
+Friends' encrypted bundles are imported with ` + "`surflog import bundle`" + `,
+and a folder of dated Markdown notes with ` + "`surflog import notes`" + `.`,
+	Example: `  surflog import --format csv sessions.csv --dry-run`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		f, err := os.Open(config.ExpandPath(args[0]))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		entries, bad, err := journal.ParseExternal(f, format)
+		if err != nil {
+			return err
+		}
+		rows := make([]int, 0, len(bad))
+		for row := range bad {
+			rows = append(rows, row)
+		}
+		sort.Ints(rows)
+		for _, row := range rows {
+			fmt.Fprintf(cmd.ErrOrStderr(), "skipping row %d: %v\n", row, bad[row])
+		}
+		dst, err := journal.NewDefaultService()
+		if err != nil {
+			return err
+		}
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		rep, err := journal.ImportExternal(dst, entries, dryRun)
+		if err != nil {
+			return err
+		}
+		for i, err := range rep.Failed {
+			fmt.Fprintf(cmd.ErrOrStderr(), "! session %d: %v\n", i, err)
+		}
+		verb := "created"
+		if dryRun {
+			verb = "would create"
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %d, skipped %d duplicates, %d unreadable, %d failed\n",
+			verb, len(rep.Created), len(rep.Skipped), len(bad), len(rep.Failed))
+		return nil
+	},
+}
+
+var importNotesCmd = &cobra.Command{
+	Use:   "notes <dir>",
+	Short: "Import a folder of dated Markdown notes",
+	Long: `Creates an entry for each Markdown note under dir whose file name matches
+--pattern, dated from the pattern's first group (parsed with --date-format)
+and with the note text as comments. Optional YAML front matter sets spot,
+time (HH:MM), height and tags; otherwise a leading "# Heading" names the spot,
+falling back to --spot. Notes already imported (same spot and time) are
+skipped.`,
+	Example: `  surflog import notes ~/notes/surf --dry-run
+  surflog import notes ~/diary --pattern 'surf-(\d{8})' --date-format 20060102 --spot "Ocean Beach"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pattern, _ := cmd.Flags().GetString("pattern")
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("--pattern: %w", err)
+		}
+		layout, _ := cmd.Flags().GetString("date-format")
+		spot, _ := cmd.Flags().GetString("spot")
+		entries, bad, err := journal.ParseNotes(config.ExpandPath(args[0]), journal.NotesOptions{Pattern: re, DateLayout: layout, Spot: spot})
+		if err != nil {
+			return err
+		}
+		paths := make([]string, 0, len(bad))
+		for path := range bad {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			fmt.Fprintf(cmd.ErrOrStderr(), "skipping %s: %v\n", path, bad[path])
+		}
+		dst, err := journal.NewDefaultService()
+		if err != nil {
+			return err
+		}
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		rep, err := journal.ImportExternal(dst, entries, dryRun)
+		if err != nil {
+			return err
+		}
+		for i, err := range rep.Failed {
+			fmt.Fprintf(cmd.ErrOrStderr(), "! note %d: %v\n", i, err)
+		}
+		verb := "created"
+		if dryRun {
+			verb = "would create"
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %d, skipped %d duplicates, %d unreadable, %d failed\n",
+			verb, len(rep.Created), len(rep.Skipped), len(bad), len(rep.Failed))
+		return nil
+	},
+}
+
+func init() {
+	importNotesCmd.Flags().String("pattern", journal.DefaultNotesPattern, "regexp matching note file names; its first group is the date")
+	importNotesCmd.Flags().String("date-format", "2006-01-02", "Go layout of the captured date")
+	importNotesCmd.Flags().String("spot", "", "spot for notes that don't name one")
+	importNotesCmd.Flags().Bool("dry-run", false, "report what would be imported without writing")
+	importCmd.AddCommand(importNotesCmd)
+	importCmd.Flags().String("format", "csv", "input format: "+strings.Join(journal.ImportFormats, ", "))
+	importCmd.Flags().Bool("dry-run", false, "report what would be imported without writing")
+	rootCmd.AddCommand(importCmd)
+}
@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/journal"
+)
+
+// journalCmd groups non-interactive journal maintenance subcommands.
+var journalCmd = &cobra.Command{
+	Use:   "journal",
+	Short: "Work with the journal outside the TUI",
+}
+
+var journalAuthorsCmd = &cobra.Command{
+	Use:   "authors",
+	Short: "Show per-author session stats for a shared journal",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := loadEntries()
+		if err != nil {
+			return err
+		}
+		type stats struct {
+			sessions    int
+			minutes     int
+			waves       int
+			waveMinutes int // time in sessions with a wave count
+			last        time.Time
+		}
+		by := map[string]*stats{}
+		for _, e := range entries {
+			name := e.Author
+			if name == "" {
+				name = "(unknown)"
+			}
+			st := by[name]
+			if st == nil {
+				st = &stats{}
+				by[name] = st
+			}
+			st.sessions++
+			st.minutes += e.DurationMin
+			if e.WaveCount > 0 && e.DurationMin > 0 {
+				st.waves += e.WaveCount
+				st.waveMinutes += e.DurationMin
+			}
+			if e.SessionAt.After(st.last) {
+				st.last = e.SessionAt
+			}
+		}
+		names := make([]string, 0, len(by))
+		for n := range by {
+			names = append(names, n)
+		}
+		sort.Slice(names, func(i, j int) bool { return by[names[i]].sessions > by[names[j]].sessions })
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 2, 2, ' ', 0)
+		fmt.Fprintln(w, "AUTHOR\tSESSIONS\tTIME\tWAVES/H\tLAST")
+		for _, n := range names {
+			st := by[n]
+			last := ""
+			if !st.last.IsZero() {
+				last = st.last.Format("2006-01-02")
+			}
+			rate := "-"
+			if r, ok := create.WavesPerHour(st.waves, st.waveMinutes); ok {
+				rate = fmt.Sprintf("%.1f", r)
+			}
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", n, st.sessions, create.FormatDuration(st.minutes), rate, last)
+		}
+		return w.Flush()
+	},
+}
+
+var journalMergeCmd = &cobra.Command{
+	Use:   "merge <other-dir>",
+	Short: "Import entries from another journal directory",
+	Long: `Imports entries from another journal directory (e.g. synced from a second
+machine), skipping entries whose ID already exists and near-duplicates
+(same spot within 30 minutes).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		srcDir := config.ExpandPath(args[0])
+		src, err := journal.NewFileService(srcDir)
+		if err != nil {
+			return err
+		}
+		incoming, err := src.List()
+		if err != nil {
+			return err
+		}
+		// never import files that don't match the entry schema
+		invalid, err := journal.ValidateDir(srcDir)
+		if err != nil {
+			return err
+		}
+		if len(invalid) > 0 {
+			bad := map[string]bool{}
+			for _, v := range invalid {
+				fmt.Fprintf(cmd.ErrOrStderr(), "invalid, skipping %v\n", v)
+				bad[v.ID] = true
+			}
+			kept := incoming[:0]
+			for _, e := range incoming {
+				if !bad[e.ID] {
+					kept = append(kept, e)
+				}
+			}
+			incoming = kept
+		}
+		dst, err := journal.NewDefaultService()
+		if err != nil {
+			return err
+		}
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		rep, err := journal.Merge(dst, incoming, dryRun)
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		for _, e := range rep.Imported {
+			fmt.Fprintf(out, "+ %s  %s\n", e.SessionAt.Format("2006-01-02 15:04"), e.Spot)
+		}
+		for _, e := range rep.SkippedNearDup {
+			fmt.Fprintf(out, "~ %s  %s (near-duplicate)\n", e.SessionAt.Format("2006-01-02 15:04"), e.Spot)
+		}
+		for id, err := range rep.Failed {
+			fmt.Fprintf(out, "! %s: %v\n", id, err)
+		}
+		verb := "merged"
+		if dryRun {
+			verb = "would merge"
+		}
+		fmt.Fprintf(out, "%s %d, skipped %d existing, %d near-duplicates, %d failed\n",
+			verb, len(rep.Imported), len(rep.SkippedID), len(rep.SkippedNearDup), len(rep.Failed))
+		return nil
+	},
+}
+
+var journalTagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Add or remove tags across many entries at once",
+	Long: `Adds and/or removes tags on every entry matching --filter, e.g.
+
+  surflog journal tag --add winter --filter "after:2024-12-01 before:2025-03-01"
+
+Filter terms: before:YYYY-MM-DD after:YYYY-MM-DD spot:<name> author:<name>
+tag:<tag> (or #tag) height:<value> swell:<dir> rating:<n|>n|<=n>, plus bare
+words and "quoted phrases" searched in the spot, comments, wave summary and tags.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		add, _ := cmd.Flags().GetStringSlice("add")
+		remove, _ := cmd.Flags().GetStringSlice("remove")
+		if len(add) == 0 && len(remove) == 0 {
+			return errors.New("nothing to do: pass --add and/or --remove")
+		}
+		filter, _ := cmd.Flags().GetString("filter")
+		q, err := journal.ParseQuery(filter)
+		if err != nil {
+			return err
+		}
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		svc, err := journal.NewDefaultService()
+		if err != nil {
+			return err
+		}
+		entries, err := svc.List()
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		changed := 0
+		for _, e := range entries {
+			if !q.Match(e) {
+				continue
+			}
+			preview := e
+			preview.Tags = append([]string(nil), e.Tags...)
+			a, r := preview.AddTags(add...), preview.RemoveTags(remove...)
+			if !a && !r {
+				continue
+			}
+			changed++
+			fmt.Fprintf(out, "%s  %-20s [%s] -> [%s]\n", e.SessionAt.Format("2006-01-02 15:04"), e.Spot,
+				strings.Join(e.Tags, ","), strings.Join(preview.Tags, ","))
+			if dryRun {
+				continue
+			}
+			if _, err := svc.Update(e.ID, func(u *create.Entry) error {
+				u.AddTags(add...)
+				u.RemoveTags(remove...)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		verb := "updated"
+		if dryRun {
+			verb = "would update"
+		}
+		fmt.Fprintf(out, "%s %d entries\n", verb, changed)
+		return nil
+	},
+}
+
+var journalValidateCmd = &cobra.Command{
+	Use:   "validate [dir]",
+	Short: "Check stored entry files against the entry JSON Schema",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := config.JournalDir()
+		if len(args) == 1 {
+			dir = config.ExpandPath(args[0])
+		}
+		invalid, err := journal.ValidateDir(dir)
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		for _, v := range invalid {
+			fmt.Fprintln(out, filepath.Base(v.Path))
+			for _, p := range v.Problems {
+				fmt.Fprintln(out, "  -", p)
+			}
+		}
+		if len(invalid) > 0 {
+			return fmt.Errorf("%d invalid entry files", len(invalid))
+		}
+		fmt.Fprintln(out, "all entries valid")
+		return nil
+	},
+}
+
+var journalSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema for journal entry files",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := cmd.OutOrStdout().Write(journal.EntrySchema)
+		return err
+	},
+}
+
+// loadEntries lists all entries from the configured journal directory.
+func loadEntries() ([]create.Entry, error) {
+	svc, err := journal.NewDefaultService()
+	if err != nil {
+		return nil, err
+	}
+	return svc.List()
+}
+
+func init() {
+	journalMergeCmd.Flags().Bool("dry-run", false, "report what would be merged without writing")
+	journalTagCmd.Flags().StringSlice("add", nil, "tags to add (comma-separated or repeated)")
+	journalTagCmd.Flags().StringSlice("remove", nil, "tags to remove")
+	journalTagCmd.Flags().String("filter", "", "which entries to change (default all)")
+	journalTagCmd.Flags().Bool("dry-run", false, "preview changes without writing")
+	journalCmd.AddCommand(journalAuthorsCmd, journalMergeCmd, journalTagCmd, journalValidateCmd, journalSchemaCmd)
+	rootCmd.AddCommand(journalCmd)
+}
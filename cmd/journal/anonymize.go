@@ -0,0 +1,157 @@
+package journal
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/sumwatshade/surflog/cmd/create"
+)
+
+// AnonymousRecord is one session in an anonymized research export. It keeps
+// the conditions and how the session went but nothing that names the surfer
+// or the spot: no IDs, author, spot name, buoy station, board, tags,
+// comments or attachments. Spots become opaque labels ("spot-1") that group
+// sessions at the same break within a single export only.
+type AnonymousRecord struct {
+	Spot                 string   `json:"spot"`
+	Date                 string   `json:"date"`        // local session date, YYYY-MM-DD
+	Hour                 int      `json:"hour"`        // local hour of day
+	WaveHeight           string   `json:"wave_height"` // perceived, one of create.HeightOptions
+	DurationMin          int      `json:"duration_min,omitempty"`
+	WaveCount            int      `json:"wave_count,omitempty"`
+	Rating               int      `json:"rating,omitempty"`
+	SignificantHeightM   *float64 `json:"significant_height_m,omitempty"`
+	SwellHeightM         *float64 `json:"swell_height_m,omitempty"`
+	SwellPeriodS         *float64 `json:"swell_period_s,omitempty"`
+	SwellDirection       string   `json:"swell_direction,omitempty"`
+	WindWaveHeightM      *float64 `json:"wind_wave_height_m,omitempty"`
+	WindWavePeriodS      *float64 `json:"wind_wave_period_s,omitempty"`
+	AveragePeriodS       *float64 `json:"average_period_s,omitempty"`
+	MeanWaveDirectionDeg *int     `json:"mean_wave_direction_deg,omitempty"`
+	WindSpeedMS          *float64 `json:"wind_speed_ms,omitempty"`
+	WindGustMS           *float64 `json:"wind_gust_ms,omitempty"`
+	WindDirectionDeg     *float64 `json:"wind_direction_deg,omitempty"`
+	PressureHPa          *float64 `json:"pressure_hpa,omitempty"`
+	WaterTempC           *float64 `json:"water_temp_c,omitempty"`
+	AQI                  int      `json:"aqi,omitempty"`
+}
+
+// AnonymousFormats are the formats accepted by WriteAnonymized.
+var AnonymousFormats = []string{"csv", "json"}
+
+// Anonymize strips personal details from entries, oldest first. Spot labels
+// are numbered in order of first visit.
+func Anonymize(entries []create.Entry) []AnonymousRecord {
+	sorted := append([]create.Entry(nil), entries...)
+	SortBySession(sorted)
+	labels := map[string]string{}
+	out := make([]AnonymousRecord, 0, len(sorted))
+	for i := len(sorted) - 1; i >= 0; i-- {
+		e := sorted[i]
+		key := strings.ToLower(strings.TrimSpace(e.Spot))
+		if _, ok := labels[key]; !ok {
+			labels[key] = fmt.Sprintf("spot-%d", len(labels)+1)
+		}
+		at := sessionTime(e).Local()
+		r := AnonymousRecord{
+			Spot:        labels[key],
+			Date:        at.Format("2006-01-02"),
+			Hour:        at.Hour(),
+			WaveHeight:  e.WaveHeight,
+			DurationMin: e.DurationMin,
+			WaveCount:   e.WaveCount,
+			Rating:      e.Rating,
+			WaterTempC:  e.WaterTempC,
+			AQI:         e.AQI,
+		}
+		if ws := e.WaveSummary; !ws.IsZero() {
+			r.SignificantHeightM = ptr(ws.SignificantHeight())
+			r.SwellHeightM = ptr(ws.SwellHeight())
+			r.SwellPeriodS = ptr(ws.SwellPeriod())
+			r.SwellDirection = ws.SwellDirection()
+			r.WindWaveHeightM = ptr(ws.WindWaveHeight())
+			r.WindWavePeriodS = ptr(ws.WindWavePeriod())
+			r.AveragePeriodS = ptr(ws.AveragePeriod())
+			r.MeanWaveDirectionDeg = ptr(ws.MeanWaveDirection())
+		}
+		if w := e.Wind; w != nil && !w.IsZero() {
+			r.WindSpeedMS = ptr(w.Speed())
+			r.WindGustMS = optional(w.Gust())
+			r.WindDirectionDeg = optional(w.Direction())
+			r.PressureHPa = optional(w.Pressure())
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func optional(v float64, ok bool) *float64 {
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// anonymousColumns are the csv columns, matching AnonymousRecord's JSON
+// names.
+var anonymousColumns = []string{
+	"spot", "date", "hour", "wave_height", "duration_min", "wave_count", "rating",
+	"significant_height_m", "swell_height_m", "swell_period_s", "swell_direction",
+	"wind_wave_height_m", "wind_wave_period_s", "average_period_s", "mean_wave_direction_deg",
+	"wind_speed_ms", "wind_gust_ms", "wind_direction_deg", "pressure_hpa", "water_temp_c", "aqi",
+}
+
+func (r AnonymousRecord) row() []string {
+	num := func(v *float64) string {
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*v, 'f', -1, 64)
+	}
+	count := func(n int) string {
+		if n == 0 {
+			return ""
+		}
+		return strconv.Itoa(n)
+	}
+	deg := ""
+	if r.MeanWaveDirectionDeg != nil {
+		deg = strconv.Itoa(*r.MeanWaveDirectionDeg)
+	}
+	return []string{
+		r.Spot, r.Date, strconv.Itoa(r.Hour), r.WaveHeight, count(r.DurationMin), count(r.WaveCount), count(r.Rating),
+		num(r.SignificantHeightM), num(r.SwellHeightM), num(r.SwellPeriodS), r.SwellDirection,
+		num(r.WindWaveHeightM), num(r.WindWavePeriodS), num(r.AveragePeriodS), deg,
+		num(r.WindSpeedMS), num(r.WindGustMS), num(r.WindDirectionDeg), num(r.PressureHPa), num(r.WaterTempC), count(r.AQI),
+	}
+}
+
+// WriteAnonymized anonymizes entries and writes them as csv or a JSON array.
+func WriteAnonymized(w io.Writer, format string, entries []create.Entry) error {
+	records := Anonymize(entries)
+	switch strings.ToLower(format) {
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write(anonymousColumns); err != nil {
+			return err
+		}
+		for _, r := range records {
+			if err := cw.Write(r.row()); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	}
+	return fmt.Errorf("unknown anonymized format %q (want %s)", format, strings.Join(AnonymousFormats, ", "))
+}
@@ -0,0 +1,197 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sumwatshade/surflog/cmd/create"
+)
+
+// attachmentsDirName is the journal subdirectory holding copied attachments,
+// one folder per entry ID.
+const attachmentsDirName = "attachments"
+
+// AttachmentsDir returns <journalDir>/attachments.
+func AttachmentsDir(journalDir string) string {
+	return filepath.Join(journalDir, attachmentsDirName)
+}
+
+// AttachmentPath resolves where an entry's attachment lives on disk.
+func AttachmentPath(journalDir, entryID string, a create.Attachment) string {
+	if !a.Copied {
+		return a.Path
+	}
+	return filepath.Join(AttachmentsDir(journalDir), entryID, a.Path)
+}
+
+// Attach adds src to entry id and saves it. With copyFile the file is copied
+// into the entry's attachments folder (renamed if the name is taken);
+// otherwise the entry only references src by absolute path.
+func Attach(svc Service, journalDir, id, src string, copyFile bool) (create.Attachment, error) {
+	abs, err := filepath.Abs(src)
+	if err != nil {
+		return create.Attachment{}, err
+	}
+	if fi, err := os.Stat(abs); err != nil {
+		return create.Attachment{}, err
+	} else if fi.IsDir() {
+		return create.Attachment{}, fmt.Errorf("%s is a directory", src)
+	}
+	if _, err := svc.Get(id); err != nil {
+		return create.Attachment{}, err
+	}
+	a := create.Attachment{Name: filepath.Base(abs), Path: abs}
+	if copyFile {
+		dir := filepath.Join(AttachmentsDir(journalDir), id)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return create.Attachment{}, err
+		}
+		name := freeName(dir, a.Name)
+		if err := copyFileTo(abs, filepath.Join(dir, name)); err != nil {
+			return create.Attachment{}, err
+		}
+		a.Path, a.Copied = name, true
+	}
+	if _, err := svc.Update(id, func(e *create.Entry) error {
+		e.Attachments = append(e.Attachments, a)
+		return nil
+	}); err != nil {
+		if a.Copied {
+			_ = os.Remove(AttachmentPath(journalDir, id, a))
+		}
+		return create.Attachment{}, err
+	}
+	return a, nil
+}
+
+// freeName returns name, or name-1, name-2, ... when it already exists in dir.
+func freeName(dir, name string) string {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	candidate := name
+	for i := 1; ; i++ {
+		if _, err := os.Stat(filepath.Join(dir, candidate)); errors.Is(err, os.ErrNotExist) {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s-%d%s", base, i, ext)
+	}
+}
+
+func copyFileTo(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		_ = os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
+
+// MissingAttachment is an attachment whose file can no longer be found.
+type MissingAttachment struct {
+	EntryID    string
+	Attachment create.Attachment
+}
+
+// CheckAttachments compares the attachments folder with the entries that
+// reference it. orphans are files (or whole entry folders) no entry refers
+// to; missing are attachments whose files are gone.
+func CheckAttachments(journalDir string, entries []create.Entry) (orphans []string, missing []MissingAttachment, err error) {
+	referenced := map[string]map[string]bool{} // entry id -> copied file names
+	for _, e := range entries {
+		for _, a := range e.Attachments {
+			if _, serr := os.Stat(AttachmentPath(journalDir, e.ID, a)); serr != nil {
+				missing = append(missing, MissingAttachment{EntryID: e.ID, Attachment: a})
+			}
+			if a.Copied {
+				if referenced[e.ID] == nil {
+					referenced[e.ID] = map[string]bool{}
+				}
+				referenced[e.ID][a.Path] = true
+			}
+		}
+	}
+	root := AttachmentsDir(journalDir)
+	dirs, err := os.ReadDir(root)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, missing, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, d := range dirs {
+		path := filepath.Join(root, d.Name())
+		names, ok := referenced[d.Name()]
+		if !d.IsDir() || !ok {
+			orphans = append(orphans, path)
+			continue
+		}
+		files, err := os.ReadDir(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, f := range files {
+			if !names[f.Name()] {
+				orphans = append(orphans, filepath.Join(path, f.Name()))
+			}
+		}
+	}
+	return orphans, missing, nil
+}
+
+// ExportReport summarises a journal export.
+type ExportReport struct {
+	Entries     int
+	Attachments int
+	Missing     []MissingAttachment // attachments that could not be copied
+}
+
+// Export writes entries as JSON files into dst. With withAttachments every
+// attachment (copied or referenced) is copied to dst/attachments/<id>/ so the
+// export is self-contained; otherwise attachments are left out entirely.
+func Export(dst, journalDir string, entries []create.Entry, withAttachments bool) (ExportReport, error) {
+	var rep ExportReport
+	if err := os.MkdirAll(dst, 0o755); err != nil {
+		return rep, err
+	}
+	for _, e := range entries {
+		var kept []create.Attachment
+		if withAttachments {
+			for _, a := range e.Attachments {
+				dir := filepath.Join(AttachmentsDir(dst), e.ID)
+				if err := os.MkdirAll(dir, 0o755); err != nil {
+					return rep, err
+				}
+				name := freeName(dir, a.Name)
+				if err := copyFileTo(AttachmentPath(journalDir, e.ID, a), filepath.Join(dir, name)); err != nil {
+					rep.Missing = append(rep.Missing, MissingAttachment{EntryID: e.ID, Attachment: a})
+					continue
+				}
+				kept = append(kept, create.Attachment{Name: a.Name, Path: name, Copied: true})
+				rep.Attachments++
+			}
+		}
+		e.Attachments = kept
+		data, err := jsonCodec.marshal(e)
+		if err != nil {
+			return rep, err
+		}
+		if err := os.WriteFile(filepath.Join(dst, e.ID+jsonCodec.ext), data, 0o644); err != nil {
+			return rep, err
+		}
+		rep.Entries++
+	}
+	return rep, nil
+}
@@ -0,0 +1,142 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/netclient"
+	"github.com/sumwatshade/surflog/cmd/spots"
+)
+
+// backfillMsg carries a historical wave lookup for an entry saved without one.
+type backfillMsg struct {
+	id        string
+	summary   buoy.WaveSummary
+	fetchedAt time.Time
+	err       error
+}
+
+// backfillStation picks the buoy to look up: the one recorded in the entry's
+// provenance, else the spot's mapped station, else "" for the default.
+func backfillStation(e create.Entry) string {
+	for _, src := range e.Sources {
+		if src.Kind == "waves" && src.Station != "" {
+			return src.Station
+		}
+	}
+	if svc, err := spots.NewDefaultService(); err == nil {
+		if sp, err := svc.Get(e.Spot); err == nil {
+			return strings.TrimSpace(sp.Station)
+		}
+	}
+	return ""
+}
+
+// backfillProgressMsg carries archive download progress for a running
+// lookup; ch yields the next report.
+type backfillProgressMsg struct {
+	id       string
+	progress netclient.Progress
+	ch       <-chan netclient.Progress
+}
+
+// backfillCmd looks up conditions at the entry's session time. Older times
+// download a monthly or yearly archive, so progress is streamed back while it
+// runs and esc cancels it.
+func (j *Journal) backfillCmd(e create.Entry) tea.Cmd {
+	if e.SessionAt.IsZero() {
+		j.backfillErr = errors.New("entry has no session time")
+		return nil
+	}
+	j.backfilling = e.ID
+	j.backfillErr = nil
+	j.backfillProgress = ""
+	svc := buoy.NewServiceForStations(backfillStation(e), "")
+	ctx, cancel := context.WithCancel(j.context())
+	j.cancelBackfill = cancel
+	ch := make(chan netclient.Progress, 1)
+	ctx = netclient.WithProgress(ctx, func(p netclient.Progress) {
+		select {
+		case ch <- p:
+		default: // the view is behind; it will catch up on the next report
+		}
+	})
+	lookup := func() tea.Msg {
+		defer close(ch)
+		defer cancel()
+		ws, err := svc.GetWaveSummaryAt(ctx, e.SessionAt)
+		return backfillMsg{id: e.ID, summary: ws, fetchedAt: time.Now(), err: err}
+	}
+	return tea.Batch(lookup, waitBackfillProgress(e.ID, ch))
+}
+
+// waitBackfillProgress waits for the next progress report, ending quietly
+// when the lookup finishes.
+func waitBackfillProgress(id string, ch <-chan netclient.Progress) tea.Cmd {
+	return func() tea.Msg {
+		p, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return backfillProgressMsg{id: id, progress: p, ch: ch}
+	}
+}
+
+// applyBackfillProgress shows a report and listens for the next one.
+func (j *Journal) applyBackfillProgress(msg backfillProgressMsg) tea.Cmd {
+	if msg.id == j.backfilling {
+		j.backfillProgress = msg.progress.String()
+	}
+	return waitBackfillProgress(msg.id, msg.ch)
+}
+
+// stopBackfill cancels the running lookup; its result is then ignored.
+func (j *Journal) stopBackfill() {
+	if j.cancelBackfill != nil {
+		j.cancelBackfill()
+	}
+	j.backfilling = ""
+	j.backfillErr = errors.New(i18n.T("cancelled"))
+}
+
+// applyBackfill persists a successful lookup onto the entry.
+func (j *Journal) applyBackfill(msg backfillMsg) {
+	if msg.id != j.backfilling {
+		return
+	}
+	j.backfilling = ""
+	j.cancelBackfill = nil
+	if msg.err != nil {
+		j.backfillErr = msg.err
+		return
+	}
+	if j.svc == nil {
+		j.backfillErr = errors.New("journal service unavailable")
+		return
+	}
+	updated, err := j.svc.Update(msg.id, func(e *create.Entry) error {
+		e.WaveSummary = msg.summary
+		e.SetSource(create.WaveSource(msg.summary, msg.fetchedAt))
+		return nil
+	})
+	if err != nil {
+		j.backfillErr = err
+		return
+	}
+	for i := range j.Entries {
+		if j.Entries[i].ID == updated.ID {
+			j.Entries[i] = updated
+		}
+	}
+	if j.ready {
+		idx := j.list.Index()
+		j.refreshListItems()
+		j.list.Select(idx)
+	}
+}
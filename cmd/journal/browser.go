@@ -0,0 +1,37 @@
+package journal
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/htmlview"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+)
+
+// openedMsg reports the result of opening an entry in the browser.
+type openedMsg struct {
+	path string
+	err  error
+}
+
+// openInBrowserCmd renders e as HTML and opens it in the default browser.
+func openInBrowserCmd(e create.Entry) tea.Cmd {
+	return func() tea.Msg {
+		page, err := htmlview.Entry(e)
+		if err != nil {
+			return openedMsg{err: err}
+		}
+		path, err := htmlview.Open(page)
+		return openedMsg{path: path, err: err}
+	}
+}
+
+// applyOpened records the outcome for the detail view and flashes it in the
+// list's status bar.
+func (j *Journal) applyOpened(msg openedMsg) tea.Cmd {
+	if msg.err != nil {
+		j.status = i18n.T("Could not open browser: %v", msg.err)
+	} else {
+		j.status = i18n.T("Opened %s", msg.path)
+	}
+	return j.list.NewStatusMessage(j.status)
+}
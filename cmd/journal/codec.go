@@ -0,0 +1,95 @@
+package journal
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"gopkg.in/yaml.v3"
+)
+
+// codec encodes entries for one on-disk format.
+type codec struct {
+	ext       string
+	marshal   func(create.Entry) ([]byte, error)
+	unmarshal func([]byte, *create.Entry) error
+}
+
+var (
+	jsonCodec = codec{
+		ext:       ".json",
+		marshal:   func(e create.Entry) ([]byte, error) { return json.MarshalIndent(e, "", "  ") },
+		unmarshal: func(b []byte, e *create.Entry) error { return json.Unmarshal(b, e) },
+	}
+	// yamlCodec round-trips through JSON so YAML files use the same field names
+	// and custom encodings (e.g. wave_summary) as the JSON format.
+	yamlCodec = codec{ext: ".yaml", marshal: marshalYAML, unmarshal: unmarshalYAML}
+)
+
+// entryExts lists every readable entry extension; all formats are always read.
+var entryExts = []string{".json", ".yaml", ".yml"}
+
+// writeCodec returns the codec selected by `journal.format` (json or yaml).
+func writeCodec() codec {
+	switch strings.ToLower(strings.TrimSpace(viper.GetString("journal.format"))) {
+	case "yaml", "yml":
+		return yamlCodec
+	default:
+		return jsonCodec
+	}
+}
+
+// codecFor picks the codec for an existing file by extension.
+func codecFor(path string) codec {
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		return yamlCodec
+	default:
+		return jsonCodec
+	}
+}
+
+func isEntryFile(name string) bool {
+	for _, ext := range entryExts {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+func marshalYAML(e create.Entry) ([]byte, error) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	// YAML is a superset of JSON: parse into a node tree to keep field order,
+	// then switch to block style for hand-editing.
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return nil, err
+	}
+	blockStyle(&node)
+	return yaml.Marshal(&node)
+}
+
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
+
+func unmarshalYAML(b []byte, e *create.Entry) error {
+	var v any
+	if err := yaml.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	j, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(j, e)
+}
@@ -0,0 +1,118 @@
+package journal
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/quiver"
+)
+
+// descriptionData is what a `journal.description` template sees, e.g.
+// "{{.Height}} {{.WaveSummary.Short}} {{.Tags}}".
+type descriptionData struct {
+	Spot         string
+	Author       string
+	When         string // localized session date and time
+	Height       string // perceived height label
+	Duration     string // e.g. "1h45m", empty when untimed
+	Waves        int
+	Rating       int    // 1–5; 0 when unrated
+	Stars        string // e.g. "★★★★☆", empty when unrated
+	Board        string // name of the board ridden
+	WavesPerHour string // e.g. "9.5", empty without a count and duration
+	WaveSummary  buoy.WaveSummary
+	Tags         string // "#glassy #dawn"
+	AQI          int
+	Comments     string // first line only
+}
+
+func newDescriptionData(i journalItem, when string) *descriptionData {
+	d := &descriptionData{
+		Spot:        i.Spot,
+		Author:      i.Author,
+		When:        when,
+		Height:      create.HeightLabel(i.WaveHeight),
+		Waves:       i.WaveCount,
+		Rating:      i.Rating,
+		Stars:       create.Stars(i.Rating),
+		Board:       quiver.Name(i.BoardID),
+		WaveSummary: i.WaveSummary,
+		AQI:         i.AQI,
+	}
+	if i.DurationMin > 0 {
+		d.Duration = create.FormatDuration(i.DurationMin)
+	}
+	if rate, ok := create.WavesPerHour(i.WaveCount, i.DurationMin); ok {
+		d.WavesPerHour = fmt.Sprintf("%.1f", rate)
+	}
+	if len(i.Tags) > 0 {
+		d.Tags = "#" + strings.Join(i.Tags, " #")
+	}
+	d.Comments, _, _ = strings.Cut(strings.TrimSpace(i.Comments), "\n")
+	return d
+}
+
+// descTemplate caches the parsed `journal.description` template; the list
+// renders every visible item on each frame.
+var descTemplate struct {
+	sync.Mutex
+	src  string
+	tmpl *template.Template // nil when unset or invalid
+}
+
+// CheckDescription parses `journal.description` and runs it against a sample
+// entry, so a typo or an unknown field is reported when the TUI starts
+// rather than quietly replaced by the default line.
+func CheckDescription() error {
+	src := strings.TrimSpace(viper.GetString("journal.description"))
+	if src == "" {
+		return nil
+	}
+	tmpl, err := template.New("description").Option("missingkey=error").Parse(src)
+	if err != nil {
+		return fmt.Errorf("invalid journal.description: %w", err)
+	}
+	sample := journalItem{create.Entry{
+		Spot: "Ocean Beach", Author: "sample", WaveHeight: "Chest", DurationMin: 90,
+		WaveCount: 12, Rating: 4, AQI: 20, Tags: []string{"glassy"}, Comments: "sample",
+	}}
+	if err := tmpl.Execute(io.Discard, newDescriptionData(sample, "sample")); err != nil {
+		return fmt.Errorf("invalid journal.description: %w", err)
+	}
+	return nil
+}
+
+// descriptionTemplate returns the configured template, or nil to use the
+// built-in description. CheckDescription has reported an invalid one by
+// the time the list renders; it falls back to the default too.
+func descriptionTemplate() *template.Template {
+	src := strings.TrimSpace(viper.GetString("journal.description"))
+	descTemplate.Lock()
+	defer descTemplate.Unlock()
+	if src != descTemplate.src {
+		descTemplate.src = src
+		descTemplate.tmpl = nil
+		if src != "" {
+			descTemplate.tmpl, _ = template.New("description").Option("missingkey=error").Parse(src)
+		}
+	}
+	return descTemplate.tmpl
+}
+
+// renderDescription executes tmpl for an item; ok is false on error so the
+// caller can fall back to the default line.
+func renderDescription(tmpl *template.Template, d *descriptionData) (string, bool) {
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, d); err != nil {
+		return "", false
+	}
+	// collapse gaps left by empty fields
+	return strings.Join(strings.Fields(b.String()), " "), true
+}
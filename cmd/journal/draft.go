@@ -0,0 +1,67 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/sumwatshade/surflog/cmd/create"
+)
+
+// DraftStore persists a single in-progress entry so an interrupted create form
+// can be restored on next launch. Drafts live in a subdirectory so List never
+// mistakes them for saved entries.
+type DraftStore struct {
+	path string
+}
+
+// NewDraftStore creates a draft store under dir/drafts.
+func NewDraftStore(dir string) (*DraftStore, error) {
+	if dir == "" {
+		return nil, errors.New("empty journal dir")
+	}
+	draftDir := filepath.Join(dir, "drafts")
+	if err := os.MkdirAll(draftDir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DraftStore{path: filepath.Join(draftDir, "current.json")}, nil
+}
+
+// Save writes the draft atomically (temp file + rename).
+func (d *DraftStore) Save(e create.Entry) error {
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := d.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, d.path)
+}
+
+// Load returns the saved draft; ok is false when none exists.
+func (d *DraftStore) Load() (create.Entry, bool, error) {
+	b, err := os.ReadFile(d.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return create.Entry{}, false, nil
+	}
+	if err != nil {
+		return create.Entry{}, false, err
+	}
+	var e create.Entry
+	if err := json.Unmarshal(b, &e); err != nil {
+		return create.Entry{}, false, err
+	}
+	return e, true, nil
+}
+
+// Clear removes any saved draft.
+func (d *DraftStore) Clear() error {
+	if err := os.Remove(d.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
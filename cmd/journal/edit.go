@@ -0,0 +1,52 @@
+package journal
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+)
+
+// Editing reports whether the detail view's edit form or the list's search
+// has focus, so global keybindings can step aside while the user types.
+func (j *Journal) Editing() bool {
+	return j != nil && (j.editing != nil || j.ready && j.list.FilterState() == list.Filtering)
+}
+
+// updateEdit drives the edit form: esc abandons it, submitting saves through
+// Service.Update and returns to the refreshed detail view.
+func (j *Journal) updateEdit(msg tea.Msg) tea.Cmd {
+	if k, ok := msg.(tea.KeyMsg); ok && k.String() == "esc" {
+		j.editing = nil
+		return nil
+	}
+	done, cmd := j.editing.Update(msg)
+	if !done {
+		return cmd
+	}
+	form := j.editing
+	j.editing = nil
+	saved, err := j.svc.Update(form.ID(), func(e *create.Entry) error {
+		form.Apply(e)
+		return nil
+	})
+	if err != nil {
+		j.status = i18n.T("Save failed: %v", err)
+		return nil
+	}
+	for i := range j.Entries {
+		if j.Entries[i].ID == saved.ID {
+			j.Entries[i] = saved
+		}
+	}
+	j.refreshListItems()
+	// the edit may have moved the entry; keep it selected
+	for i, it := range j.list.VisibleItems() {
+		if ji, ok := it.(journalItem); ok && ji.ID == saved.ID {
+			j.list.Select(i)
+			break
+		}
+	}
+	j.status = i18n.T("Saved changes")
+	return nil
+}
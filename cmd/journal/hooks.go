@@ -0,0 +1,134 @@
+package journal
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/hooks"
+)
+
+// NewDefaultService opens the file journal under `journal.dir`. Changes made
+// through it run the configured entry hooks (see the hooks package).
+func NewDefaultService() (Service, error) {
+	svc, err := NewFileService(config.JournalDir())
+	if err != nil {
+		return nil, err
+	}
+	return hookedService{Service: svc}, nil
+}
+
+// hookedService runs the configured entry hooks after each successful
+// create, update and delete. Imports copy entries rather than creating them,
+// so they run none. With a background runner (the TUI's) the hooks run off
+// the caller's goroutine; otherwise the change waits for its hook.
+type hookedService struct {
+	Service
+	bg *hookRunner
+}
+
+func (s hookedService) Create(e create.Entry) (create.Entry, error) {
+	saved, err := s.Service.Create(e)
+	if err == nil {
+		s.run(hooks.EntryCreated, saved)
+	}
+	return saved, err
+}
+
+func (s hookedService) Update(id string, mutate func(*create.Entry) error) (create.Entry, error) {
+	updated, err := s.Service.Update(id, mutate)
+	if err == nil {
+		s.run(hooks.EntryUpdated, updated)
+	}
+	return updated, err
+}
+
+func (s hookedService) Delete(id string) error {
+	old, gerr := s.Service.Get(id)
+	if err := s.Service.Delete(id); err != nil {
+		return err
+	}
+	if gerr != nil {
+		old = create.Entry{ID: id}
+	}
+	s.run(hooks.EntryDeleted, old)
+	return nil
+}
+
+func (s hookedService) run(event string, e create.Entry) {
+	if s.bg == nil {
+		_ = runEntryHook(event, e)
+		return
+	}
+	s.bg.start(event, e)
+}
+
+// runEntryHook runs event's hook for e. A failing hook doesn't undo the
+// save; it is recorded in the hook log and returned.
+func runEntryHook(event string, e create.Entry) error {
+	return hooks.Run(context.Background(), event, e, "SURFLOG_ENTRY_ID="+e.ID)
+}
+
+// hookRunner runs entry hooks in the background and hands their failures to
+// the TUI, which shows them as a toast (see HookFailedMsg).
+type hookRunner struct {
+	failures chan error
+	wg       sync.WaitGroup
+}
+
+func newHookRunner() *hookRunner {
+	return &hookRunner{failures: make(chan error, 8)}
+}
+
+func (r *hookRunner) start(event string, e create.Entry) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if err := runEntryHook(event, e); err != nil {
+			select {
+			case r.failures <- err:
+			default: // already showing failures; the hook log has them all
+			}
+		}
+	}()
+}
+
+// wait waits up to d for running hooks to finish.
+func (r *hookRunner) wait(d time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(d):
+	}
+}
+
+// HookFailedMsg reports an entry hook that failed in the background.
+type HookFailedMsg struct{ Err error }
+
+// HookFailuresCmd waits for the next background hook failure. The UI issues
+// it again after each HookFailedMsg.
+func (j *Journal) HookFailuresCmd() tea.Cmd {
+	if j == nil || j.hooks == nil {
+		return nil
+	}
+	failures := j.hooks.failures
+	return func() tea.Msg { return HookFailedMsg{Err: <-failures} }
+}
+
+// hookShutdownWait is how long quitting waits for running entry hooks.
+const hookShutdownWait = 5 * time.Second
+
+// WaitHooks gives entry hooks still running a few seconds to finish before
+// the app exits.
+func (j *Journal) WaitHooks() {
+	if j != nil && j.hooks != nil {
+		j.hooks.wait(hookShutdownWait)
+	}
+}
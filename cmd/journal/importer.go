@@ -0,0 +1,296 @@
+package journal
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/create"
+)
+
+// ImportFormats are the external formats ParseExternal understands.
+var ImportFormats = []string{"csv", "surfline-json"}
+
+// ParseExternal reads sessions logged in another app. Rows that cannot be
+// mapped are reported in skipped (by 1-based row) rather than failing the
+// whole file.
+func ParseExternal(r io.Reader, format string) (entries []create.Entry, skipped map[int]error, err error) {
+	switch strings.ToLower(format) {
+	case "csv":
+		return parseCSV(r)
+	case "surfline-json", "surfline":
+		return parseSurflineJSON(r)
+	default:
+		return nil, nil, fmt.Errorf("unknown format %q (want %s)", format, strings.Join(ImportFormats, ", "))
+	}
+}
+
+// csvAliases maps header names used by other logs (and by `surflog export`)
+// to entry fields. Headers are matched case-insensitively.
+var csvAliases = map[string]string{
+	"spot": "spot", "location": "spot", "break": "spot", "spot_name": "spot",
+	"session_at": "at", "datetime": "at", "start": "at", "started_at": "at",
+	"date": "date", "time": "time",
+	"wave_height": "height", "height": "height", "size": "height", "surf_height": "height",
+	"duration_min": "duration", "duration": "duration", "minutes": "duration",
+	"wave_count": "waves", "waves": "waves",
+	"comments": "comments", "notes": "comments", "description": "comments",
+	"tags": "tags", "author": "author", "surfer": "author",
+}
+
+func parseCSV(r io.Reader) ([]create.Entry, map[int]error, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading header: %w", err)
+	}
+	cols := map[string]int{}
+	for i, h := range header {
+		if f, ok := csvAliases[strings.ToLower(strings.TrimSpace(h))]; ok {
+			if _, dup := cols[f]; !dup {
+				cols[f] = i
+			}
+		}
+	}
+	if _, ok := cols["spot"]; !ok {
+		return nil, nil, errors.New("no spot column (spot, location or break)")
+	}
+	var entries []create.Entry
+	skipped := map[int]error{}
+	for row := 2; ; row++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		get := func(f string) string {
+			if i, ok := cols[f]; ok && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+		e, err := externalEntry(get)
+		if err != nil {
+			skipped[row] = err
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, skipped, nil
+}
+
+// externalEntry maps looked-up fields onto an entry.
+func externalEntry(get func(field string) string) (create.Entry, error) {
+	e := create.Entry{Spot: get("spot"), Comments: get("comments"), Author: get("author")}
+	if e.Spot == "" {
+		return e, errors.New("missing spot")
+	}
+	at := get("at")
+	if at == "" {
+		at = strings.TrimSpace(get("date") + " " + get("time"))
+	}
+	t, err := parseExternalTime(at)
+	if err != nil {
+		return e, err
+	}
+	e.SessionAt = t
+	e.WaveHeight = externalHeight(get("height"))
+	if d := get("duration"); d != "" {
+		if e.DurationMin, err = create.ParseDuration(d); err != nil {
+			return e, err
+		}
+	}
+	if w := get("waves"); w != "" {
+		if e.WaveCount, err = strconv.Atoi(w); err != nil || e.WaveCount < 0 {
+			return e, fmt.Errorf("invalid wave count %q", w)
+		}
+	}
+	e.AddTags(strings.FieldsFunc(get("tags"), func(r rune) bool { return r == ',' || r == ' ' || r == ';' })...)
+	return e, nil
+}
+
+// externalTimeLayouts are tried in order; times without a zone are local.
+var externalTimeLayouts = []string{
+	"2006-01-02 15:04", "2006-01-02 15:04:05", "2006-01-02T15:04", "2006-01-02T15:04:05",
+	"01/02/2006 15:04", "1/2/2006 15:04", "01/02/2006 3:04 PM", "1/2/2006 3:04 PM",
+	"2006-01-02", "01/02/2006", "1/2/2006",
+}
+
+func parseExternalTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, errors.New("missing session time")
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range externalTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised session time %q", s)
+}
+
+// feetHeights buckets a face height in feet (upper bound, exclusive) into
+// the perceived height options.
+var feetHeights = []struct {
+	below  float64
+	height string
+}{{1, "Ankle"}, {2, "Knee"}, {3, "Waist"}, {4, "Chest"}, {5, "Shoulder"}, {6.5, "Head"}}
+
+// externalHeight maps a height option, a configured label or a size in feet
+// ("3", "3-4ft", "2.5 ft") onto HeightOptions. Unknown text is kept as is so
+// nothing is lost.
+func externalHeight(s string) string {
+	if s == "" {
+		return ""
+	}
+	if h, ok := create.NormalizeHeight(s); ok {
+		return h
+	}
+	num := strings.TrimSpace(strings.TrimSuffix(strings.TrimSuffix(strings.ToLower(s), "ft"), "'"))
+	lo, hi, isRange := strings.Cut(num, "-")
+	ft, err := strconv.ParseFloat(strings.TrimSpace(lo), 64)
+	if err != nil {
+		return s
+	}
+	if isRange {
+		if h, err := strconv.ParseFloat(strings.TrimSpace(hi), 64); err == nil {
+			ft = (ft + h) / 2
+		}
+	}
+	for _, b := range feetHeights {
+		if ft < b.below {
+			return b.height
+		}
+	}
+	return "Overhead"
+}
+
+// surflineSession is the subset of a Surfline session export that maps onto
+// an entry. Timestamps are epoch milliseconds or RFC 3339; surf heights are
+// in feet.
+type surflineSession struct {
+	Spot struct {
+		Name string `json:"name"`
+	} `json:"spot"`
+	SpotName       string          `json:"spotName"`
+	StartTimestamp json.RawMessage `json:"startTimestamp"`
+	EndTimestamp   json.RawMessage `json:"endTimestamp"`
+	WaveCount      int             `json:"waveCount"`
+	Notes          string          `json:"notes"`
+	Name           string          `json:"name"`
+	Surf           struct {
+		Min float64 `json:"min"`
+		Max float64 `json:"max"`
+	} `json:"surf"`
+}
+
+func parseSurflineJSON(r io.Reader) ([]create.Entry, map[int]error, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	var sessions []surflineSession
+	if err := json.Unmarshal(raw, &sessions); err != nil {
+		// some exports wrap the list: {"sessions": [...]}
+		var wrapped struct {
+			Sessions []surflineSession `json:"sessions"`
+		}
+		if werr := json.Unmarshal(raw, &wrapped); werr != nil {
+			return nil, nil, fmt.Errorf("not a Surfline session export: %w", err)
+		}
+		sessions = wrapped.Sessions
+	}
+	var entries []create.Entry
+	skipped := map[int]error{}
+	for i, s := range sessions {
+		spot := s.Spot.Name
+		if spot == "" {
+			spot = s.SpotName
+		}
+		start, err := surflineTime(s.StartTimestamp)
+		if spot == "" {
+			err = errors.New("missing spot")
+		}
+		if err != nil {
+			skipped[i+1] = err
+			continue
+		}
+		e := create.Entry{Spot: spot, SessionAt: start, WaveCount: s.WaveCount, Comments: strings.TrimSpace(s.Name + "\n" + s.Notes)}
+		if end, err := surflineTime(s.EndTimestamp); err == nil && end.After(start) {
+			e.DurationMin = int(end.Sub(start).Minutes())
+		}
+		if s.Surf.Max > 0 {
+			e.WaveHeight = externalHeight(strconv.FormatFloat((s.Surf.Min+s.Surf.Max)/2, 'f', 1, 64))
+		}
+		entries = append(entries, e)
+	}
+	return entries, skipped, nil
+}
+
+func surflineTime(raw json.RawMessage) (time.Time, error) {
+	var ms int64
+	if err := json.Unmarshal(raw, &ms); err == nil && ms > 0 {
+		return time.UnixMilli(ms), nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return parseExternalTime(s)
+	}
+	return time.Time{}, errors.New("missing start time")
+}
+
+// ImportReport summarises an external import.
+type ImportReport struct {
+	Created []create.Entry
+	Skipped []create.Entry // same spot and session minute already in the journal
+	Failed  map[int]error  // by 1-based position in the parsed entries
+}
+
+// ImportExternal creates new entries (with fresh IDs) for sessions not
+// already in dst, matching on spot (case-insensitive) and session time to the
+// minute. Duplicates inside the import itself are skipped too. When dryRun is
+// set nothing is written.
+func ImportExternal(dst Service, entries []create.Entry, dryRun bool) (ImportReport, error) {
+	existing, err := dst.List()
+	if err != nil {
+		return ImportReport{}, err
+	}
+	key := func(e create.Entry) string {
+		return strings.ToLower(strings.TrimSpace(e.Spot)) + "|" + sessionTime(e).UTC().Truncate(time.Minute).Format(time.RFC3339)
+	}
+	seen := make(map[string]bool, len(existing))
+	for _, e := range existing {
+		seen[key(e)] = true
+	}
+	rep := ImportReport{Failed: map[int]error{}}
+	for i, e := range entries {
+		k := key(e)
+		if seen[k] {
+			rep.Skipped = append(rep.Skipped, e)
+			continue
+		}
+		if !dryRun {
+			saved, err := dst.Create(e)
+			if err != nil {
+				rep.Failed[i+1] = err
+				continue
+			}
+			e = saved
+		}
+		seen[k] = true
+		rep.Created = append(rep.Created, e)
+	}
+	return rep, nil
+}
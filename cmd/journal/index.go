@@ -0,0 +1,120 @@
+package journal
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/sumwatshade/surflog/cmd/create"
+)
+
+// listIndex caches decoded entries in the state dir so List only reads the
+// files that changed since the last call. Each file is matched by name,
+// size and modification time; the entry files stay the source of truth and
+// a missing or unreadable index is simply rebuilt.
+type listIndex struct {
+	Schema string // entryShape when written; a mismatch discards the index
+	Files  map[string]indexedFile
+}
+
+type indexedFile struct {
+	Size    int64
+	ModTime int64 // UnixNano
+	Entry   create.Entry
+}
+
+func (f indexedFile) matches(fi fs.FileInfo) bool {
+	return f.Size == fi.Size() && f.ModTime == fi.ModTime().UnixNano()
+}
+
+// entryShape fingerprints the exported shape of create.Entry so an index
+// written before a field was added is not served without it.
+var entryShape = func() string {
+	var sb strings.Builder
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct || reflect.PointerTo(t).Implements(reflect.TypeFor[gob.GobDecoder]()) {
+			return
+		}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			fmt.Fprintf(&sb, "%s.%s %s;", t.Name(), f.Name, f.Type)
+			walk(f.Type)
+		}
+	}
+	walk(reflect.TypeFor[create.Entry]())
+	h := fnv.New64a()
+	h.Write([]byte(sb.String()))
+	return fmt.Sprintf("%x", h.Sum64())
+}()
+
+// indexPath returns where the index for the journal in dir lives, or ""
+// when there is no state dir to keep it in.
+func indexPath(stateDir, dir string) string {
+	if stateDir == "" {
+		return ""
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = dir
+	}
+	h := fnv.New64a()
+	h.Write([]byte(abs))
+	return filepath.Join(stateDir, "index", fmt.Sprintf("journal-%x.gob", h.Sum64()))
+}
+
+// loadIndex reads the index at path. Any failure yields an empty index.
+func loadIndex(path string) listIndex {
+	empty := listIndex{Files: map[string]indexedFile{}}
+	if path == "" {
+		return empty
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return empty
+	}
+	var idx listIndex
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&idx); err != nil || idx.Schema != entryShape || idx.Files == nil {
+		return empty
+	}
+	return idx
+}
+
+// saveIndex replaces the index at path atomically. Errors are ignored by
+// callers: a stale or missing index only costs the next List a full read.
+func saveIndex(path string, idx listIndex) error {
+	if path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	idx.Schema = entryShape
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(idx); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
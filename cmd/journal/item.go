@@ -8,7 +8,10 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sumwatshade/surflog/cmd/config"
 	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/layout"
 )
 
 var (
@@ -16,22 +19,40 @@ var (
 	itemDescStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
 	selectedTitleStyle = itemTitleStyle.Copy().Foreground(lipgloss.Color("159"))
 	selectedDescStyle  = itemDescStyle.Copy().Foreground(lipgloss.Color("246"))
+	tagChipStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("159")).Background(lipgloss.Color("24")).Padding(0, 1)
 )
 
 type journalItem struct{ create.Entry }
 
 func (i journalItem) Title() string { return i.Spot }
+
+// Description is the second list line: the `journal.description` template
+// when configured, otherwise conditions, rating, session time and length.
 func (i journalItem) Description() string {
 	// include session date/time (local) if available
 	ts := ""
 	if !i.SessionAt.IsZero() {
-		ts = i.SessionAt.Format("2006-01-02 15:04")
+		ts = i18n.DateTime(i.SessionAt)
 	} else if strings.TrimSpace(i.CreatedAt) != "" { // fallback parse
 		if t, err := time.Parse(time.RFC3339, strings.TrimSpace(i.CreatedAt)); err == nil {
-			ts = t.Local().Format("2006-01-02 15:04")
+			ts = i18n.DateTime(t.Local())
+		}
+	}
+	if tmpl := descriptionTemplate(); tmpl != nil {
+		if s, ok := renderDescription(tmpl, newDescriptionData(i, ts)); ok {
+			return s
 		}
 	}
+	if i.DurationMin > 0 {
+		ts = strings.TrimPrefix(ts+" · "+create.FormatDuration(i.DurationMin), " · ")
+	}
+	if a := strings.TrimSpace(i.Author); a != "" && a != config.User() {
+		ts = strings.TrimSpace(a + " · " + ts)
+	}
 	ws := i.WaveSummary.String()
+	if s := create.Stars(i.Rating); s != "" {
+		ts = strings.TrimSpace(s + " " + ts)
+	}
 	if ws != "" && ts != "" {
 		return ws + " | " + ts
 	}
@@ -41,7 +62,20 @@ func (i journalItem) Description() string {
 	return ws
 }
 func (i journalItem) FilterValue() string {
-	return strings.ToLower(strings.Join([]string{i.Spot, i.WaveSummary.String(), i.Comments}, " "))
+	return strings.ToLower(strings.Join([]string{i.Spot, i.Author, i.WaveSummary.String(), i.Comments, create.FormatTags(i.Tags)}, " "))
+}
+
+// tagChips renders tags as chips after the description, unless a custom
+// description template decides where tags go.
+func (i journalItem) tagChips() string {
+	if len(i.Tags) == 0 || descriptionTemplate() != nil {
+		return ""
+	}
+	chips := make([]string, len(i.Tags))
+	for k, t := range i.Tags {
+		chips[k] = tagChipStyle.Render("#" + t)
+	}
+	return " " + strings.Join(chips, " ")
 }
 
 type itemDelegate struct{}
@@ -61,6 +95,7 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 		title = selectedTitleStyle.Render(it.Title())
 		desc = selectedDescStyle.Render(it.Description())
 	}
+	desc += it.tagChips()
 	// Highlight filter matches (simple contains highlight for now)
 	if f := strings.TrimSpace(m.FilterValue()); f != "" {
 		lower := strings.ToLower(title)
@@ -71,5 +106,8 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 			title = title[:pos] + filterMatchStyle.Render(orig) + title[pos+len(f):]
 		}
 	}
+	// long spot names and summaries are cut rather than wrapped, which would
+	// break the two-line item height
+	title, desc = layout.Truncate(title, m.Width()), layout.Truncate(desc, m.Width())
 	io.WriteString(w, lipgloss.JoinVertical(lipgloss.Left, title, desc))
 }
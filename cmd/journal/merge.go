@@ -0,0 +1,100 @@
+package journal
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/create"
+)
+
+// nearDuplicateWindow is how close two sessions at the same spot must be to be
+// treated as the same session logged on two machines.
+const nearDuplicateWindow = 30 * time.Minute
+
+// MergeReport summarises a merge.
+type MergeReport struct {
+	Imported       []create.Entry
+	SkippedID      []create.Entry // same ID already present
+	SkippedNearDup []create.Entry // same spot within nearDuplicateWindow
+	Failed         map[string]error
+}
+
+// Merge imports entries from src into dst, skipping entries whose ID already
+// exists and near-duplicates (same spot, session within 30 minutes). When
+// dryRun is set nothing is written but the report reflects what would happen.
+func Merge(dst Service, src []create.Entry, dryRun bool) (MergeReport, error) {
+	existing, err := dst.List()
+	if err != nil {
+		return MergeReport{}, err
+	}
+	ids := make(map[string]bool, len(existing))
+	for _, e := range existing {
+		ids[e.ID] = true
+	}
+	rep := MergeReport{Failed: map[string]error{}}
+	for _, e := range src {
+		if ids[e.ID] {
+			rep.SkippedID = append(rep.SkippedID, e)
+			continue
+		}
+		if nearDuplicate(e, existing) {
+			rep.SkippedNearDup = append(rep.SkippedNearDup, e)
+			continue
+		}
+		if !dryRun {
+			if _, err := dst.Import(e); err != nil {
+				rep.Failed[e.ID] = err
+				continue
+			}
+		}
+		ids[e.ID] = true
+		existing = append(existing, e)
+		rep.Imported = append(rep.Imported, e)
+	}
+	return rep, nil
+}
+
+// SortBySession orders entries newest session first, falling back to
+// CreatedAt when SessionAt is zero.
+func SortBySession(entries []create.Entry) {
+	sort.SliceStable(entries, func(i, k int) bool {
+		return sessionTime(entries[i]).After(sessionTime(entries[k]))
+	})
+}
+
+// sessionTime returns SessionAt, falling back to CreatedAt.
+func sessionTime(e create.Entry) time.Time {
+	if !e.SessionAt.IsZero() {
+		return e.SessionAt
+	}
+	if t, err := time.Parse(time.RFC3339, strings.TrimSpace(e.CreatedAt)); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+func nearDuplicate(e create.Entry, existing []create.Entry) bool {
+	spot := strings.ToLower(strings.TrimSpace(e.Spot))
+	at := sessionTime(e)
+	if at.IsZero() {
+		return false
+	}
+	for _, x := range existing {
+		if strings.ToLower(strings.TrimSpace(x.Spot)) != spot {
+			continue
+		}
+		xt := sessionTime(x)
+		if xt.IsZero() {
+			continue
+		}
+		d := at.Sub(xt)
+		if d < 0 {
+			d = -d
+		}
+		if d <= nearDuplicateWindow {
+			return true
+		}
+	}
+	return false
+}
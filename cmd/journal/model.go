@@ -1,19 +1,20 @@
 package journal
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
 	"sort"
 	"strings"
-	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/config"
 	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/quiver"
+	"github.com/sumwatshade/surflog/cmd/units"
 )
 
 // Journal holds underlying entries plus the interactive list model.
@@ -25,9 +26,25 @@ type Journal struct {
 	height  int
 	detail  bool // whether we're showing a single entry
 	svc     Service
+	drafts  *DraftStore
+	ctx     context.Context // bounds lookups; cancelled when the app quits
 	// deletion state
+	onlyMine         bool   // show only entries authored by the current user
+	byRating         bool   // list best-rated sessions first instead of newest
 	confirmingDelete bool   // user pressed delete, awaiting confirmation
 	deleteTargetID   string // id of entry pending deletion
+	// backfill state for entries saved without conditions
+	backfilling      string // id of entry being looked up
+	backfillErr      error
+	backfillProgress string             // latest archive download progress
+	cancelBackfill   context.CancelFunc // aborts the running lookup
+	status           string             // outcome of the last browser open, shown in the detail view
+	editing          *create.EditForm   // non-nil while the detail view's entry is being edited
+	// outbox holds entries whose save failed until a retry succeeds
+	outbox   *Outbox
+	pending  int  // entries waiting in the outbox
+	retrying bool // a retry tick is scheduled
+	hooks    *hookRunner
 }
 
 var (
@@ -43,29 +60,36 @@ var (
 func NewJournal() *Journal {
 	j := &Journal{}
 	// Assume viper always has journal.dir (set via default in initConfig or user override)
-	dir := strings.TrimSpace(viper.GetString("journal.dir"))
+	dir := config.JournalDir()
 	if dir != "" {
-		// expand leading ~ or make relative absolute
-		if strings.HasPrefix(dir, "~") {
-			if home, herr := os.UserHomeDir(); herr == nil {
-				dir = filepath.Join(home, strings.TrimPrefix(dir, "~"))
-			}
-		} else if !filepath.IsAbs(dir) {
-			if wd, werr := os.Getwd(); werr == nil {
-				dir = filepath.Join(wd, dir)
-			}
-		}
 		if svc, serr := NewFileService(dir); serr == nil {
 			if list, lerr := svc.List(); lerr == nil {
 				j.Entries = append(j.Entries, list...)
 				j.sortEntries()
 			}
-			j.svc = svc
+			// hooks run in the background so a slow one can't freeze the UI
+			j.hooks = newHookRunner()
+			j.svc = hookedService{Service: svc, bg: j.hooks}
+		}
+		if ds, derr := NewDraftStore(dir); derr == nil {
+			j.drafts = ds
 		}
 	}
+	j.openOutbox()
 	return j
 }
 
+// SetContext bounds network lookups started from the journal (e.g. backfill)
+// so they are abandoned when ctx is cancelled.
+func (j *Journal) SetContext(ctx context.Context) { j.ctx = ctx }
+
+func (j *Journal) context() context.Context {
+	if j.ctx == nil {
+		return context.Background()
+	}
+	return j.ctx
+}
+
 // AddEntry appends to underlying slice and (if list initialized) inserts item.
 func (j *Journal) AddEntry(entry create.Entry) {
 	j.Entries = append(j.Entries, entry)
@@ -76,16 +100,51 @@ func (j *Journal) AddEntry(entry create.Entry) {
 }
 
 // Persist creates the entry via the underlying service (if available) and adds it to the list.
-func (j *Journal) Persist(entry create.Entry) (create.Entry, error) {
+// When the write fails the entry is queued in the outbox instead and queued is
+// true; OutboxCmd then retries it in the background. Without a journal
+// service (the directory could not be opened) the entry is queued too, and
+// saved on a later launch.
+func (j *Journal) Persist(entry create.Entry) (saved create.Entry, queued bool, err error) {
 	if j.svc == nil {
-		return create.Entry{}, errors.New("journal service unavailable")
+		err = errors.New("journal service unavailable")
+	} else {
+		saved, err = j.svc.Create(entry)
 	}
-	saved, err := j.svc.Create(entry)
 	if err != nil {
-		return create.Entry{}, err
+		if qerr := j.queue(entry, err); qerr != nil {
+			return create.Entry{}, false, qerr
+		}
+		return entry, true, nil
 	}
 	j.AddEntry(saved)
-	return saved, nil
+	return saved, false, nil
+}
+
+// SaveDraft stores an in-progress entry for restoring on next launch.
+func (j *Journal) SaveDraft(entry create.Entry) error {
+	if j.drafts == nil {
+		return errors.New("draft store unavailable")
+	}
+	return j.drafts.Save(entry)
+}
+
+// LoadDraft returns a previously saved draft, if any.
+func (j *Journal) LoadDraft() (create.Entry, bool) {
+	if j.drafts == nil {
+		return create.Entry{}, false
+	}
+	e, ok, err := j.drafts.Load()
+	if err != nil {
+		return create.Entry{}, false
+	}
+	return e, ok
+}
+
+// ClearDraft discards any saved draft.
+func (j *Journal) ClearDraft() {
+	if j.drafts != nil {
+		_ = j.drafts.Clear()
+	}
 }
 
 // ensureList creates or resizes the list model based on dimensions.
@@ -98,15 +157,13 @@ func (j *Journal) ensureList(width, height int) {
 	listHeight := max(5, height-6) // leave space for header/footer around view
 	if !j.ready {
 		j.sortEntries()
-		items := make([]list.Item, 0, len(j.Entries))
-		for i := 0; i < len(j.Entries); i++ { // already sorted desc
-			items = append(items, journalItem{j.Entries[i]})
-		}
+		items := j.visibleItems()
 		l := list.New(items, itemDelegate{}, width-4, listHeight) // -4 for padding
-		l.Title = "Journal"
+		l.Title = i18n.T("Journal")
 		l.SetShowStatusBar(true)
 		l.SetShowPagination(true)
 		l.SetFilteringEnabled(true)
+		l.Filter = searchFilter(items)
 		l.Styles.Title = journalTitleBarStyle
 		l.Styles.StatusBar = statusBarStyle
 		l.Styles.PaginationStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
@@ -125,10 +182,26 @@ func (j *Journal) Update(msg tea.Msg, width, height int) tea.Cmd {
 	if !j.ready {
 		return nil
 	}
+	if j.editing != nil {
+		return j.updateEdit(msg)
+	}
 	switch m := msg.(type) {
+	case backfillMsg:
+		j.applyBackfill(m)
+		return nil
+	case backfillProgressMsg:
+		return j.applyBackfillProgress(m)
+	case openedMsg:
+		return j.applyOpened(m)
+	case exportedMsg:
+		return j.applyExported(m)
 	case tea.KeyMsg:
 		switch m.String() {
 		case "esc":
+			if j.backfilling != "" { // abandon a slow archive lookup
+				j.stopBackfill()
+				return nil
+			}
 			if j.detail { // leave detail view
 				j.detail = false
 				return nil
@@ -142,11 +215,65 @@ func (j *Journal) Update(msg tea.Msg, width, height int) tea.Cmd {
 				j.list.ResetFilter()
 				return nil
 			}
+		case "n", "p":
+			if j.confirmingDelete { // n cancels deletion; p is ignored meanwhile
+				if m.String() == "n" {
+					j.confirmingDelete = false
+					j.deleteTargetID = ""
+				}
+				return nil
+			}
+			if j.detail { // step through entries without leaving the detail view
+				j.stepDetail(m.String() == "n")
+				return nil
+			}
+		case "f": // backfill conditions for a legacy entry from buoy history
+			if j.detail && j.backfilling == "" {
+				if sel, ok := j.list.SelectedItem().(journalItem); ok && sel.WaveSummary.IsZero() {
+					return j.backfillCmd(sel.Entry)
+				}
+				return nil
+			}
+		case "o": // render the selected entry as HTML in the browser
+			if j.list.FilterState() == list.Filtering {
+				break
+			}
+			if sel, ok := j.list.SelectedItem().(journalItem); ok {
+				return openInBrowserCmd(sel.Entry)
+			}
+			return nil
+		case "e": // edit the entry in the detail view; export the visible entries from the list
+			if j.detail {
+				if sel, ok := j.list.SelectedItem().(journalItem); ok && j.svc != nil {
+					j.editing = create.NewEditForm(sel.Entry)
+					j.status = ""
+					return j.editing.Init()
+				}
+				return nil
+			}
+			if j.list.FilterState() == list.Filtering {
+				break
+			}
+			var entries []create.Entry
+			for _, it := range j.list.VisibleItems() {
+				if ji, ok := it.(journalItem); ok {
+					entries = append(entries, ji.Entry)
+				}
+			}
+			return exportCmd(entries)
 		case "enter":
-			// open detail (even if filtering; keep filter applied so selection context remains)
+			if j.list.FilterState() == list.Filtering {
+				break // accept the filter
+			}
+			// open detail, keeping any applied filter so selection context remains
 			j.detail = true
+			j.backfillErr = nil
+			j.status = ""
 			return nil
 		case "x", "delete": // initiate delete (x common; delete key if sent)
+			if j.list.FilterState() == list.Filtering {
+				break
+			}
 			if j.confirmingDelete { // treat as cancel if repeated
 				j.confirmingDelete = false
 				j.deleteTargetID = ""
@@ -157,19 +284,33 @@ func (j *Journal) Update(msg tea.Msg, width, height int) tea.Cmd {
 				j.deleteTargetID = sel.ID
 			}
 			return nil
+		case "u": // toggle between everyone's and only my entries
+			if j.list.FilterState() == list.Filtering {
+				break
+			}
+			j.onlyMine = !j.onlyMine
+			j.list.Title = j.title()
+			j.refreshListItems()
+			return nil
+		case "s": // toggle sorting between session date and rating
+			if j.list.FilterState() == list.Filtering || j.detail {
+				break
+			}
+			j.byRating = !j.byRating
+			j.list.Title = j.title()
+			j.refreshListItems()
+			j.list.Select(0)
+			return nil
 		case "y": // confirm deletion if in confirmation state
+			if j.list.FilterState() == list.Filtering {
+				break
+			}
 			if j.confirmingDelete && j.deleteTargetID != "" {
 				id := j.deleteTargetID
 				j.confirmingDelete = false
 				j.deleteTargetID = ""
 				return j.deleteEntry(id)
 			}
-		case "n": // cancel deletion
-			if j.confirmingDelete {
-				j.confirmingDelete = false
-				j.deleteTargetID = ""
-				return nil
-			}
 		}
 	}
 	var cmd tea.Cmd
@@ -180,10 +321,10 @@ func (j *Journal) Update(msg tea.Msg, width, height int) tea.Cmd {
 // View renders the journal list.
 func (j *Journal) View() string {
 	if !j.ready {
-		return journalTitleBarStyle.Render("Journal") + "\n" + "Loading..."
+		return journalTitleBarStyle.Render(i18n.T("Journal")) + "\n" + i18n.T("Loading...")
 	}
 	if len(j.Entries) == 0 {
-		return journalTitleBarStyle.Render("Journal") + "\n" + lipgloss.NewStyle().Faint(true).Render("No entries yet. Press 'c' to create one.")
+		return journalTitleBarStyle.Render(i18n.T("Journal")) + "\n" + lipgloss.NewStyle().Faint(true).Render(i18n.T("No entries yet. Press 'c' to create one."))
 	}
 	// show delete confirmation banner if active
 	if j.confirmingDelete {
@@ -191,9 +332,17 @@ func (j *Journal) View() string {
 		if sel, ok := j.list.SelectedItem().(journalItem); ok {
 			spot = sel.Spot
 		}
-		banner := lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Bold(true).Render("Delete entry '" + spot + "'? (y/n)")
+		banner := lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Bold(true).Render(i18n.T("Delete entry '%s'? (y/n)", spot))
 		return banner + "\n" + j.list.View()
 	}
+	if j.editing != nil {
+		b := &strings.Builder{}
+		fmt.Fprintln(b, journalTitleBarStyle.Render(i18n.T("Edit Entry")))
+		fmt.Fprintln(b)
+		fmt.Fprintln(b, j.editing.View())
+		fmt.Fprintln(b, faintStyle.Render(i18n.T("(enter to save • esc to cancel)")))
+		return lipgloss.NewStyle().Width(j.width - 4).Render(b.String())
+	}
 	if j.detail {
 		// render selected entry in full page
 		sel, ok := j.list.SelectedItem().(journalItem)
@@ -202,21 +351,95 @@ func (j *Journal) View() string {
 			return j.list.View()
 		}
 		b := &strings.Builder{}
-		fmt.Fprintln(b, journalTitleBarStyle.Render("Journal Entry"))
+		fmt.Fprintln(b, journalTitleBarStyle.Render(i18n.T("Journal Entry")))
 		fmt.Fprintln(b)
 		fmt.Fprintln(b, detailHeaderStyle.Render(sel.Spot))
-		fmt.Fprintln(b, detailMetaStyle.Render(sel.WaveSummary.String()))
+		if !sel.SessionAt.IsZero() {
+			fmt.Fprintln(b, detailMetaStyle.Render(i18n.DateTime(sel.SessionAt)))
+		}
+		if sel.Author != "" {
+			fmt.Fprintln(b, detailMetaStyle.Render(i18n.T("by %s", sel.Author)))
+		}
+		if sel.WaveCount > 0 {
+			line := i18n.T("%d waves", sel.WaveCount)
+			if rate, ok := create.WavesPerHour(sel.WaveCount, sel.DurationMin); ok {
+				line += " " + i18n.T("(%.1f/h over %s)", rate, create.FormatDuration(sel.DurationMin))
+			}
+			fmt.Fprintln(b, detailMetaStyle.Render(line))
+		}
+		if board := quiver.Name(sel.BoardID); board != "" {
+			fmt.Fprintln(b, detailMetaStyle.Render(i18n.T("on %s", board)))
+		}
+		if sel.WaveSummary.IsZero() {
+			fmt.Fprintln(b, faintStyle.Render(i18n.T("No conditions recorded.")))
+			switch {
+			case j.backfilling == sel.ID:
+				fmt.Fprintln(b, faintStyle.Render(i18n.T("Looking up buoy history...")))
+				if j.backfillProgress != "" {
+					fmt.Fprintln(b, faintStyle.Render(j.backfillProgress))
+				}
+				fmt.Fprintln(b, faintStyle.Render(i18n.T("esc to cancel")))
+			case j.backfillErr != nil:
+				fmt.Fprintln(b, lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Render(i18n.T("Backfill failed: %v", j.backfillErr)))
+			default:
+				fmt.Fprintln(b, faintStyle.Render(i18n.T("Press 'f' to backfill from buoy history.")))
+			}
+		} else {
+			fmt.Fprintln(b, detailMetaStyle.Render(sel.WaveSummary.String()))
+		}
+		if sel.Wind != nil {
+			fmt.Fprintln(b, detailMetaStyle.Render(i18n.T("Wind: ")+sel.Wind.String()))
+		}
+		if sel.WaterTempC != nil {
+			fmt.Fprintln(b, detailMetaStyle.Render(i18n.T("water %s", i18n.Temperature(*sel.WaterTempC))))
+		}
+		if sel.TideFt != nil {
+			fmt.Fprintln(b, detailMetaStyle.Render(i18n.T("tide %s", units.Feet(*sel.TideFt))))
+		}
+		if len(sel.Tags) > 0 {
+			fmt.Fprintln(b, detailMetaStyle.Render("#"+strings.Join(sel.Tags, " #")))
+		}
+		if sel.AQI > 0 {
+			fmt.Fprintln(b, detailMetaStyle.Render(i18n.T("AQI %d", sel.AQI)))
+		}
 		if sel.Comments != "" {
 			fmt.Fprintln(b)
 			fmt.Fprintln(b, sel.Comments)
 		}
+		if len(sel.Sources) > 0 {
+			fmt.Fprintln(b)
+			fmt.Fprintln(b, detailMetaStyle.Render(i18n.T("Data sources:")))
+			for _, src := range sel.Sources {
+				fmt.Fprintln(b, faintStyle.Render("  "+src.String()))
+			}
+		}
 		fmt.Fprintln(b)
-		fmt.Fprintln(b, faintStyle.Render("(esc to go back)"))
+		if j.status != "" {
+			fmt.Fprintln(b, faintStyle.Render(j.status))
+		}
+		fmt.Fprintln(b, faintStyle.Render(i18n.T("(%d/%d • n/p next/prev • e edit • o open in browser • esc to go back)", j.list.Index()+1, len(j.list.VisibleItems()))))
 		return lipgloss.NewStyle().Width(j.width - 4).Render(b.String())
 	}
 	return j.list.View()
 }
 
+// stepDetail moves the selection to the next (older) or previous (newer)
+// entry among the visible items, so the active filter and sort are respected.
+func (j *Journal) stepDetail(next bool) {
+	n := len(j.list.VisibleItems())
+	if n == 0 {
+		return
+	}
+	j.backfillErr = nil
+	j.status = ""
+	i := j.list.Index()
+	if next && i < n-1 {
+		j.list.Select(i + 1)
+	} else if !next && i > 0 {
+		j.list.Select(i - 1)
+	}
+}
+
 // helper until Go generics version or shared util
 func max(a, b int) int {
 	if a > b {
@@ -241,29 +464,14 @@ func (j *Journal) deleteEntry(id string) tea.Cmd {
 	}
 	if j.ready {
 		// rebuild list items (simpler vs removing by index due to filtering)
-		items := make([]list.Item, 0, len(j.Entries))
-		for i := len(j.Entries) - 1; i >= 0; i-- { // newest first
-			items = append(items, journalItem{j.Entries[i]})
-		}
-		j.list.SetItems(items)
+		j.refreshListItems()
 	}
 	return nil
 }
 
 // sortEntries orders Entries by SessionAt (newest first). Falls back to CreatedAt when SessionAt zero.
 func (j *Journal) sortEntries() {
-	parse := func(e create.Entry) time.Time {
-		if !e.SessionAt.IsZero() {
-			return e.SessionAt
-		}
-		if t, err := time.Parse(time.RFC3339, strings.TrimSpace(e.CreatedAt)); err == nil {
-			return t
-		}
-		return time.Time{}
-	}
-	sort.SliceStable(j.Entries, func(i, k int) bool {
-		return parse(j.Entries[i]).After(parse(j.Entries[k]))
-	})
+	SortBySession(j.Entries)
 }
 
 // refreshListItems rebuilds list items from sorted Entries.
@@ -272,9 +480,50 @@ func (j *Journal) refreshListItems() {
 		return
 	}
 	j.sortEntries()
+	items := j.visibleItems()
+	j.list.Filter = searchFilter(items)
+	j.list.SetItems(items)
+}
+
+// searchFilter indexes items for the list's "/" search; see Query for the
+// syntax.
+func searchFilter(items []list.Item) list.FilterFunc {
+	entries := make([]create.Entry, len(items))
+	for k, it := range items {
+		entries[k] = it.(journalItem).Entry
+	}
+	return NewIndex(entries).Filter
+}
+
+// visibleItems returns list items for Entries (already sorted newest first),
+// restricted to the current user's entries when onlyMine is set. With
+// byRating the best-rated come first, newest first among equals.
+func (j *Journal) visibleItems() []list.Item {
+	me := config.User()
 	items := make([]list.Item, 0, len(j.Entries))
 	for _, e := range j.Entries {
+		if j.onlyMine && e.Author != "" && e.Author != me {
+			continue
+		}
 		items = append(items, journalItem{e})
 	}
-	j.list.SetItems(items)
+	if j.byRating {
+		sort.SliceStable(items, func(a, b int) bool {
+			return items[a].(journalItem).Rating > items[b].(journalItem).Rating
+		})
+	}
+	return items
+}
+
+// title names the list after its current filter and sort.
+func (j *Journal) title() string {
+	switch {
+	case j.onlyMine && j.byRating:
+		return i18n.T("Journal (mine, by rating)")
+	case j.onlyMine:
+		return i18n.T("Journal (mine)")
+	case j.byRating:
+		return i18n.T("Journal (by rating)")
+	}
+	return i18n.T("Journal")
 }
@@ -0,0 +1,129 @@
+package journal
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/create"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultNotesPattern matches note file names containing an ISO date, e.g.
+// "2024-03-02 Linda Mar.md".
+const DefaultNotesPattern = `(\d{4}-\d{2}-\d{2})`
+
+// NotesOptions configures ParseNotes.
+type NotesOptions struct {
+	// Pattern selects note files by base name; its first group holds the
+	// session date.
+	Pattern *regexp.Regexp
+	// DateLayout parses the captured date (Go layout, e.g. "2006-01-02").
+	DateLayout string
+	// Spot is used for notes that name none.
+	Spot string
+}
+
+// noteFrontMatter is the optional YAML block at the top of a note.
+type noteFrontMatter struct {
+	Spot   string `yaml:"spot"`
+	Time   string `yaml:"time"` // HH:MM, local
+	Height string `yaml:"height"`
+	Tags   any    `yaml:"tags"` // list or comma-separated string
+}
+
+// ParseNotes turns a directory of dated Markdown notes into entries, one per
+// note, with the note text as comments. The spot comes from a `spot:` front
+// matter key, else a leading "# Heading", else opts.Spot. Notes that cannot
+// be read are reported in skipped by path.
+func ParseNotes(dir string, opts NotesOptions) (entries []create.Entry, skipped map[string]error, err error) {
+	if opts.Pattern == nil || opts.Pattern.NumSubexp() < 1 {
+		return nil, nil, fmt.Errorf("note pattern needs a group capturing the date")
+	}
+	skipped = map[string]error{}
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if d.IsDir() || (ext != ".md" && ext != ".markdown") {
+			return nil
+		}
+		m := opts.Pattern.FindStringSubmatch(d.Name())
+		if m == nil {
+			return nil
+		}
+		day, err := time.ParseInLocation(opts.DateLayout, m[1], time.Local)
+		if err != nil {
+			skipped[path] = fmt.Errorf("date %q: %w", m[1], err)
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			skipped[path] = err
+			return nil
+		}
+		e, err := parseNote(data, day, opts.Spot)
+		if err != nil {
+			skipped[path] = err
+			return nil
+		}
+		entries = append(entries, e)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	SortBySession(entries)
+	return entries, skipped, nil
+}
+
+func parseNote(data []byte, day time.Time, defaultSpot string) (create.Entry, error) {
+	var fm noteFrontMatter
+	body := string(bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n")))
+	if rest, ok := strings.CutPrefix(body, "---\n"); ok {
+		if head, tail, ok := strings.Cut(rest, "\n---"); ok {
+			if err := yaml.Unmarshal([]byte(head), &fm); err != nil {
+				return create.Entry{}, fmt.Errorf("front matter: %w", err)
+			}
+			_, body, _ = strings.Cut(tail, "\n")
+		}
+	}
+	body = strings.TrimSpace(body)
+	spot := strings.TrimSpace(fm.Spot)
+	if heading, rest, _ := strings.Cut(body, "\n"); strings.HasPrefix(heading, "# ") {
+		if spot == "" {
+			spot = strings.TrimSpace(strings.TrimPrefix(heading, "# "))
+		}
+		body = strings.TrimSpace(rest)
+	}
+	if spot == "" {
+		spot = defaultSpot
+	}
+	if spot == "" {
+		return create.Entry{}, fmt.Errorf("no spot: add `spot:` front matter, a # heading, or --spot")
+	}
+	at := day
+	if fm.Time != "" {
+		t, err := time.Parse("15:04", strings.TrimSpace(fm.Time))
+		if err != nil {
+			return create.Entry{}, fmt.Errorf("time %q: use HH:MM", fm.Time)
+		}
+		at = time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, time.Local)
+	}
+	e := create.Entry{Spot: spot, SessionAt: at, WaveHeight: externalHeight(fm.Height), Comments: body}
+	switch tags := fm.Tags.(type) {
+	case string:
+		e.AddTags(create.ParseTags(tags)...)
+	case []any:
+		for _, t := range tags {
+			e.AddTags(fmt.Sprint(t))
+		}
+	}
+	return e, nil
+}
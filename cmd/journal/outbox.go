@@ -0,0 +1,215 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/hooks"
+)
+
+// outboxRetryInterval is how often queued entries are retried.
+const outboxRetryInterval = 30 * time.Second
+
+// Outbox holds entries whose save failed (disk full, permissions, a sync
+// conflict) so the session is not lost. It lives in the state directory,
+// away from the journal directory that just failed.
+type Outbox struct {
+	dir string
+}
+
+// QueuedEntry is an entry waiting in the outbox. Its ID is assigned when
+// queued, so a retry that succeeds but fails to dequeue is not saved twice.
+type QueuedEntry struct {
+	Entry    create.Entry `json:"entry"`
+	QueuedAt time.Time    `json:"queued_at"`
+	LastErr  string       `json:"last_error,omitempty"`
+}
+
+// NewOutbox creates an outbox under stateDir/outbox.
+func NewOutbox(stateDir string) (*Outbox, error) {
+	if stateDir == "" {
+		return nil, errors.New("empty state dir")
+	}
+	dir := filepath.Join(stateDir, "outbox")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Outbox{dir: dir}, nil
+}
+
+// Add queues e after a failed save.
+func (o *Outbox) Add(e create.Entry, cause error) error {
+	if e.ID == "" {
+		e.ID = uuid.NewString()
+	}
+	if strings.TrimSpace(e.Author) == "" {
+		e.Author = config.User()
+	}
+	q := QueuedEntry{Entry: e, QueuedAt: time.Now().UTC()}
+	if cause != nil {
+		q.LastErr = cause.Error()
+	}
+	return o.write(q)
+}
+
+func (o *Outbox) write(q QueuedEntry) error {
+	data, err := json.MarshalIndent(q, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(o.dir, q.Entry.ID+".json")
+	if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// List returns queued entries, oldest first. Unreadable files are skipped.
+func (o *Outbox) List() ([]QueuedEntry, error) {
+	files, err := os.ReadDir(o.dir)
+	if err != nil {
+		return nil, err
+	}
+	var out []QueuedEntry
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(o.dir, f.Name()))
+		if err != nil {
+			continue
+		}
+		var q QueuedEntry
+		if json.Unmarshal(b, &q) != nil {
+			continue
+		}
+		out = append(out, q)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].QueuedAt.Before(out[j].QueuedAt) })
+	return out, nil
+}
+
+// Remove drops a queued entry once it has been saved.
+func (o *Outbox) Remove(q QueuedEntry) error {
+	return os.Remove(filepath.Join(o.dir, q.Entry.ID+".json"))
+}
+
+// Retry tries to save every queued entry through svc, returning the saved
+// entries and how many are still pending. Entries that fail again keep their
+// place with the latest error recorded. A queued entry is stored with Import
+// to keep its ID, so the entry-created hook is run here once it is saved.
+func (o *Outbox) Retry(svc Service) (saved []create.Entry, pending int) {
+	queued, err := o.List()
+	if err != nil {
+		return nil, 0
+	}
+	for _, q := range queued {
+		if q.Entry.ID == "" {
+			continue
+		}
+		if _, err := svc.Get(q.Entry.ID); err == nil {
+			// saved by an earlier retry that could not dequeue it
+			if o.Remove(q) != nil {
+				pending++
+			}
+			continue
+		}
+		e, err := svc.Import(q.Entry)
+		if err != nil {
+			q.LastErr = err.Error()
+			_ = o.write(q)
+			pending++
+			continue
+		}
+		_ = runEntryHook(hooks.EntryCreated, e)
+		saved = append(saved, e)
+		if o.Remove(q) != nil {
+			pending++ // dequeued on the next retry
+		}
+	}
+	return saved, pending
+}
+
+// outboxRetryMsg triggers a retry of the queued entries.
+type outboxRetryMsg struct{}
+
+// outboxRetriedMsg carries the outcome of a retry.
+type outboxRetriedMsg struct {
+	saved   []create.Entry
+	pending int
+}
+
+// PendingSaves is the number of entries waiting in the outbox.
+func (j *Journal) PendingSaves() int {
+	if j == nil {
+		return 0
+	}
+	return j.pending
+}
+
+// OutboxCmd schedules the next retry while entries are queued.
+func (j *Journal) OutboxCmd() tea.Cmd {
+	if j == nil || j.pending == 0 || j.retrying {
+		return nil
+	}
+	j.retrying = true
+	return tea.Tick(outboxRetryInterval, func(time.Time) tea.Msg { return outboxRetryMsg{} })
+}
+
+// UpdateOutbox handles the outbox's retry messages. It runs whatever view is
+// active, so queued sessions keep retrying in the background.
+func (j *Journal) UpdateOutbox(msg tea.Msg) tea.Cmd {
+	if j == nil || j.outbox == nil || j.svc == nil {
+		return nil
+	}
+	switch m := msg.(type) {
+	case outboxRetryMsg:
+		outbox, svc := j.outbox, j.svc
+		return func() tea.Msg {
+			saved, pending := outbox.Retry(svc)
+			return outboxRetriedMsg{saved: saved, pending: pending}
+		}
+	case outboxRetriedMsg:
+		j.retrying = false
+		j.pending = m.pending
+		for _, e := range m.saved {
+			j.AddEntry(e)
+		}
+		return j.OutboxCmd()
+	}
+	return nil
+}
+
+// queue moves an entry whose save failed into the outbox.
+func (j *Journal) queue(e create.Entry, cause error) error {
+	if j.outbox == nil {
+		return cause
+	}
+	if err := j.outbox.Add(e, cause); err != nil {
+		return cause
+	}
+	j.pending++
+	return nil
+}
+
+// openOutbox attaches the state directory's outbox and counts entries left
+// from a previous run.
+func (j *Journal) openOutbox() {
+	ob, err := NewOutbox(config.StateDir())
+	if err != nil {
+		return
+	}
+	j.outbox = ob
+	if queued, err := ob.List(); err == nil {
+		j.pending = len(queued)
+	}
+}
@@ -0,0 +1,246 @@
+package journal
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/direction"
+)
+
+// Query selects entries using space-separated terms:
+//
+//	before:2025-03-01  after:2024-12-01  spot:<name>  author:<name>
+//	tag:<tag> (or #tag)  height:<value>  swell:<WNW|285>  rating:<n|>n|>=n|<n|<=n>
+//	<word>  "a phrase"
+//
+// Words and phrases are searched in the spot, comments, wave summary, tags
+// and author; a word matches any word starting with it. Values with spaces
+// can be quoted, e.g. spot:"ocean beach". All terms must match. Dates are
+// local and before: is exclusive.
+type Query struct {
+	before, after time.Time
+	spot, author  string
+	height        string
+	swellDir      *float64 // swell direction within ±22.5°
+	rating        *ratingTerm
+	tags          []string
+	words         []string
+	phrases       [][]string // each a run of tokens that must appear in order
+}
+
+// ratingTerm compares an entry's star rating against n.
+type ratingTerm struct {
+	op string // "=", ">", ">=", "<", "<="
+	n  int
+}
+
+func (r ratingTerm) match(rating int) bool {
+	switch r.op {
+	case ">":
+		return rating > r.n
+	case ">=":
+		return rating >= r.n
+	case "<":
+		return rating < r.n
+	case "<=":
+		return rating <= r.n
+	}
+	return rating == r.n
+}
+
+func parseRating(val string) (*ratingTerm, error) {
+	r := &ratingTerm{op: "="}
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if rest, ok := strings.CutPrefix(val, op); ok {
+			r.op, val = op, rest
+			break
+		}
+	}
+	n, err := strconv.Atoi(val)
+	if err != nil || n < 0 || n > 5 {
+		return nil, fmt.Errorf("invalid rating %q (want e.g. 4, >3 or <=2)", val)
+	}
+	r.n = n
+	return r, nil
+}
+
+// splitQuery splits s on spaces, keeping double-quoted runs together so
+// both "a phrase" and key:"two words" stay one term. phrase reports terms
+// that began with a quote.
+func splitQuery(s string) (terms []string, phrase []bool) {
+	var cur strings.Builder
+	inQuote, any := false, false
+	flush := func() {
+		if any {
+			terms = append(terms, cur.String())
+			phrase = append(phrase, strings.HasPrefix(cur.String(), `"`))
+		}
+		cur.Reset()
+		any = false
+	}
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			cur.WriteRune(r)
+			any = true
+		case unicode.IsSpace(r) && !inQuote:
+			flush()
+		default:
+			cur.WriteRune(r)
+			any = true
+		}
+	}
+	flush()
+	return terms, phrase
+}
+
+// ParseQuery parses a filter expression; an empty one matches everything.
+func ParseQuery(s string) (Query, error) {
+	var q Query
+	terms, phrase := splitQuery(s)
+	for i, term := range terms {
+		if phrase[i] {
+			if toks := tokenize(term); len(toks) > 1 {
+				q.phrases = append(q.phrases, toks)
+			} else {
+				q.words = append(q.words, toks...)
+			}
+			continue
+		}
+		if t, ok := strings.CutPrefix(term, "#"); ok && t != "" {
+			q.tags = append(q.tags, strings.ToLower(t))
+			continue
+		}
+		key, val, ok := strings.Cut(term, ":")
+		val = strings.Trim(val, `"`)
+		if !ok || val == "" {
+			q.words = append(q.words, tokenize(term)...)
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "before", "after":
+			t, err := time.ParseInLocation("2006-01-02", val, time.Local)
+			if err != nil {
+				return Query{}, fmt.Errorf("invalid %s date %q (want YYYY-MM-DD)", key, val)
+			}
+			if strings.EqualFold(key, "before") {
+				q.before = t
+			} else {
+				q.after = t
+			}
+		case "spot":
+			q.spot = strings.ToLower(val)
+		case "author":
+			q.author = strings.ToLower(val)
+		case "tag":
+			q.tags = append(q.tags, strings.ToLower(val))
+		case "swell":
+			d, ok := direction.Degrees(val)
+			if !ok {
+				return Query{}, fmt.Errorf("invalid swell direction %q (want e.g. WNW or 285)", val)
+			}
+			q.swellDir = &d
+		case "height":
+			h, ok := create.NormalizeHeight(val)
+			if !ok {
+				return Query{}, fmt.Errorf("invalid height %q (want one of %s)", val, strings.Join(create.HeightLabels(), ", "))
+			}
+			q.height = h
+		case "rating":
+			r, err := parseRating(val)
+			if err != nil {
+				return Query{}, err
+			}
+			q.rating = r
+		default:
+			return Query{}, fmt.Errorf("unknown filter %q", key)
+		}
+	}
+	return q, nil
+}
+
+// Match reports whether the entry satisfies every term.
+func (q Query) Match(e create.Entry) bool {
+	at := sessionTime(e)
+	if !q.before.IsZero() && !at.Before(q.before) {
+		return false
+	}
+	if !q.after.IsZero() && at.Before(q.after) {
+		return false
+	}
+	if q.spot != "" && !strings.Contains(strings.ToLower(e.Spot), q.spot) {
+		return false
+	}
+	if q.author != "" && !strings.EqualFold(e.Author, q.author) {
+		return false
+	}
+	if q.height != "" && !strings.EqualFold(e.WaveHeight, q.height) {
+		return false
+	}
+	if q.swellDir != nil {
+		d, ok := e.WaveSummary.SwellDirectionDeg()
+		if !ok || direction.Diff(d, *q.swellDir) > 22.5 {
+			return false
+		}
+	}
+	if q.rating != nil && !q.rating.match(e.Rating) {
+		return false
+	}
+	for _, t := range q.tags {
+		if !e.HasTag(t) {
+			return false
+		}
+	}
+	if len(q.words) == 0 && len(q.phrases) == 0 {
+		return true
+	}
+	toks := searchTokens(e)
+	for _, w := range q.words {
+		if !slices.ContainsFunc(toks, func(t string) bool { return strings.HasPrefix(t, w) }) {
+			return false
+		}
+	}
+	for _, p := range q.phrases {
+		if !containsRun(toks, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsRun reports whether run appears in toks in order, its last word
+// matching as a prefix like a bare word does.
+func containsRun(toks, run []string) bool {
+	for i := 0; i+len(run) <= len(toks); i++ {
+		ok := true
+		for k, w := range run {
+			if t := toks[i+k]; t != w && (k < len(run)-1 || !strings.HasPrefix(t, w)) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// WithSpot narrows q to entries whose spot contains name (case-insensitive).
+// Unlike the spot: term, name may contain spaces.
+func (q Query) WithSpot(name string) Query {
+	q.spot = strings.ToLower(strings.TrimSpace(name))
+	return q
+}
+
+// WithSince narrows q to sessions on or after t, like after:.
+func (q Query) WithSince(t time.Time) Query {
+	q.after = t
+	return q
+}
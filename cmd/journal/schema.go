@@ -0,0 +1,211 @@
+package journal
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EntrySchema is the published JSON Schema for stored entries. Third-party
+// tools can validate against it; `surflog journal schema` prints it.
+//
+//go:embed entry.schema.json
+var EntrySchema []byte
+
+// schemaNode is the subset of JSON Schema keywords the entry schema uses.
+type schemaNode struct {
+	Type                 any                    `json:"type"` // string or []string
+	Required             []string               `json:"required"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Items                *schemaNode            `json:"items"`
+	MinLength            *int                   `json:"minLength"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	Format               string                 `json:"format"`
+}
+
+var entrySchema = func() *schemaNode {
+	var n schemaNode
+	if err := json.Unmarshal(EntrySchema, &n); err != nil {
+		panic("journal: invalid embedded entry schema: " + err.Error())
+	}
+	return &n
+}()
+
+// ValidationError describes one stored file that does not match the schema.
+type ValidationError struct {
+	Path     string
+	ID       string // entry id when it could be read
+	Problems []string
+}
+
+func (v ValidationError) Error() string {
+	return filepath.Base(v.Path) + ": " + strings.Join(v.Problems, "; ")
+}
+
+// ValidateEntryData checks one encoded entry (JSON, or YAML when yaml is
+// set) against the schema and returns its id and any problems.
+func ValidateEntryData(data []byte, isYAML bool) (string, []string) {
+	var doc any
+	if isYAML {
+		var y any
+		if err := yaml.Unmarshal(data, &y); err != nil {
+			return "", []string{"invalid YAML: " + err.Error()}
+		}
+		// normalise YAML scalars (e.g. timestamps) to their JSON forms
+		b, err := json.Marshal(y)
+		if err != nil {
+			return "", []string{"unsupported YAML: " + err.Error()}
+		}
+		data = b
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return "", []string{"invalid JSON: " + err.Error()}
+	}
+	var problems []string
+	entrySchema.validate("", doc, &problems)
+	id := ""
+	if m, ok := doc.(map[string]any); ok {
+		id, _ = m["id"].(string)
+	}
+	return id, problems
+}
+
+// ValidateDir checks every entry file in dir, returning one error per
+// invalid file (sorted by path).
+func ValidateDir(dir string) ([]ValidationError, error) {
+	des, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var out []ValidationError
+	for _, de := range des {
+		if de.IsDir() || !isEntryFile(de.Name()) {
+			continue
+		}
+		path := filepath.Join(dir, de.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			out = append(out, ValidationError{Path: path, Problems: []string{err.Error()}})
+			continue
+		}
+		id, problems := ValidateEntryData(data, codecFor(path).ext == yamlCodec.ext)
+		if len(problems) > 0 {
+			out = append(out, ValidationError{Path: path, ID: id, Problems: problems})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, nil
+}
+
+func (n *schemaNode) types() []string {
+	switch t := n.Type.(type) {
+	case string:
+		return []string{t}
+	case []any:
+		out := make([]string, 0, len(t))
+		for _, v := range t {
+			if s, ok := v.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func jsonType(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if x == math.Trunc(x) {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return "unknown"
+}
+
+func (n *schemaNode) validate(path string, v any, problems *[]string) {
+	at := path
+	if at == "" {
+		at = "entry"
+	}
+	if types := n.types(); len(types) > 0 {
+		got := jsonType(v)
+		ok := false
+		for _, t := range types {
+			if t == got || (t == "number" && got == "integer") {
+				ok = true
+			}
+		}
+		if !ok {
+			*problems = append(*problems, fmt.Sprintf("%s: expected %s, got %s", at, strings.Join(types, " or "), got))
+			return
+		}
+	}
+	switch x := v.(type) {
+	case string:
+		if n.MinLength != nil && len([]rune(x)) < *n.MinLength {
+			*problems = append(*problems, fmt.Sprintf("%s: must not be empty", at))
+		}
+		if n.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, x); err != nil {
+				*problems = append(*problems, fmt.Sprintf("%s: %q is not an RFC 3339 date-time", at, x))
+			}
+		}
+	case float64:
+		if n.Minimum != nil && x < *n.Minimum {
+			*problems = append(*problems, fmt.Sprintf("%s: %v is below minimum %v", at, x, *n.Minimum))
+		}
+		if n.Maximum != nil && x > *n.Maximum {
+			*problems = append(*problems, fmt.Sprintf("%s: %v is above maximum %v", at, x, *n.Maximum))
+		}
+	case []any:
+		if n.Items != nil {
+			for i, item := range x {
+				n.Items.validate(fmt.Sprintf("%s[%d]", at, i), item, problems)
+			}
+		}
+	case map[string]any:
+		for _, r := range n.Required {
+			if _, ok := x[r]; !ok {
+				*problems = append(*problems, fmt.Sprintf("%s: missing required %q", at, r))
+			}
+		}
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			child := k
+			if path != "" {
+				child = path + "." + k
+			}
+			if p, ok := n.Properties[k]; ok {
+				p.validate(child, x[k], problems)
+			} else if n.AdditionalProperties != nil && !*n.AdditionalProperties {
+				*problems = append(*problems, fmt.Sprintf("%s: unknown field %q", at, k))
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package journal
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/sumwatshade/surflog/cmd/create"
+)
+
+// tokenize lowercases s and splits it into words, keeping decimal points
+// inside numbers so "1.8m" stays one token.
+func tokenize(s string) []string {
+	toks := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.'
+	})
+	out := toks[:0]
+	for _, t := range toks {
+		if t = strings.Trim(t, "."); t != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// searchTokens is the searchable text of an entry, in order: spot, author,
+// wave summary, comments and tags.
+func searchTokens(e create.Entry) []string {
+	return tokenize(strings.Join([]string{e.Spot, e.Author, e.WaveSummary.String(), e.Comments, strings.Join(e.Tags, " ")}, " "))
+}
+
+// Index is an inverted index over entries' searchable text. It narrows a
+// Query's words to candidate entries before Match checks the rest.
+type Index struct {
+	entries  []create.Entry
+	vocab    []string         // sorted distinct tokens
+	postings map[string][]int // token -> ascending entry positions
+}
+
+// NewIndex indexes entries; Search results are positions in this slice.
+func NewIndex(entries []create.Entry) *Index {
+	idx := &Index{entries: entries, postings: map[string][]int{}}
+	for i, e := range entries {
+		for _, t := range searchTokens(e) {
+			p := idx.postings[t]
+			if len(p) > 0 && p[len(p)-1] == i {
+				continue
+			}
+			if len(p) == 0 {
+				idx.vocab = append(idx.vocab, t)
+			}
+			idx.postings[t] = append(p, i)
+		}
+	}
+	sort.Strings(idx.vocab)
+	return idx
+}
+
+// Search returns the positions of entries matching q, in index order.
+func (idx *Index) Search(q Query) []int {
+	var cand []int
+	if len(q.words) == 0 {
+		cand = make([]int, len(idx.entries))
+		for i := range cand {
+			cand[i] = i
+		}
+	} else {
+		for k, w := range q.words {
+			hits := idx.prefixPostings(w)
+			if k == 0 {
+				cand = hits
+			} else {
+				cand = intersect(cand, hits)
+			}
+			if len(cand) == 0 {
+				return nil
+			}
+		}
+	}
+	out := cand[:0:0]
+	for _, i := range cand {
+		if q.Match(idx.entries[i]) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// prefixPostings unions the postings of every token starting with w.
+func (idx *Index) prefixPostings(w string) []int {
+	var hits []int
+	for k := sort.SearchStrings(idx.vocab, w); k < len(idx.vocab) && strings.HasPrefix(idx.vocab[k], w); k++ {
+		hits = append(hits, idx.postings[idx.vocab[k]]...)
+	}
+	slices.Sort(hits)
+	return slices.Compact(hits)
+}
+
+func intersect(a, b []int) []int {
+	var out []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i, j = i+1, j+1
+		}
+	}
+	return out
+}
+
+// Filter is a list.FilterFunc over the indexed entries, which must be the
+// list's items in order. Terms that don't parse as a query, or a list that
+// has drifted from the index, fall back to the list's fuzzy match.
+func (idx *Index) Filter(term string, targets []string) []list.Rank {
+	q, err := ParseQuery(term)
+	if err != nil || len(targets) != len(idx.entries) {
+		return list.DefaultFilter(term, targets)
+	}
+	hits := idx.Search(q)
+	ranks := make([]list.Rank, len(hits))
+	for k, i := range hits {
+		ranks[k] = list.Rank{Index: i}
+	}
+	return ranks
+}
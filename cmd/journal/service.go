@@ -1,17 +1,21 @@
 package journal
 
 import (
-	"encoding/json"
+	"bytes"
 	"errors"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/config"
 	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/spots"
 )
 
 // Service defines persistence operations for journal entries.
@@ -19,6 +23,8 @@ type Service interface {
 	List() ([]create.Entry, error)
 	Get(id string) (create.Entry, error)
 	Create(e create.Entry) (create.Entry, error)
+	// Import stores an entry under its existing ID (e.g. when merging journals).
+	Import(e create.Entry) (create.Entry, error)
 	Update(id string, mutate func(*create.Entry) error) (create.Entry, error)
 	Delete(id string) error
 }
@@ -27,7 +33,8 @@ var _ Service = (*fileService)(nil)
 
 // fileService stores each entry as a JSON file under baseDir.
 type fileService struct {
-	baseDir string
+	baseDir   string
+	indexPath string // list cache in the state dir; "" disables it
 }
 
 // NewFileService creates a journal service rooted at dir (created if missing).
@@ -38,14 +45,71 @@ func NewFileService(dir string) (Service, error) {
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return nil, err
 	}
-	return &fileService{baseDir: dir}, nil
+	return &fileService{baseDir: dir, indexPath: indexPath(config.StateDir(), dir)}, nil
 }
 
-func (s *fileService) entryPath(id string) string { return filepath.Join(s.baseDir, id+".json") }
+// entryPath returns the existing file for id: a bare <id>.<ext> name, or a
+// dated name ending in _<shortid>.<ext> whose content carries the same ID.
+// When no file exists the bare name in the configured format is returned.
+func (s *fileService) entryPath(id string) string {
+	for _, ext := range entryExts {
+		plain := filepath.Join(s.baseDir, id+ext)
+		if _, err := os.Stat(plain); err == nil {
+			return plain
+		}
+	}
+	if len(id) >= shortIDLen {
+		for _, ext := range entryExts {
+			matches, _ := filepath.Glob(filepath.Join(s.baseDir, "*_"+id[:shortIDLen]+ext))
+			for _, m := range matches {
+				b, err := os.ReadFile(m)
+				if err != nil {
+					continue
+				}
+				var probe create.Entry
+				if codecFor(m).unmarshal(b, &probe) == nil && probe.ID == id {
+					return m
+				}
+			}
+		}
+	}
+	return filepath.Join(s.baseDir, id+writeCodec().ext)
+}
+
+// shortIDLen is how much of the UUID is kept in dated filenames.
+const shortIDLen = 8
+
+// newEntryPath chooses the filename for a new entry. With `journal.filenames:
+// dated` entries are named like 2025-05-04T0700_ocean-beach_1a2b3c4d.json so
+// the directory sorts chronologically; the default is <id>.json. The extension
+// follows the configured format.
+func (s *fileService) newEntryPath(e create.Entry) string {
+	ext := writeCodec().ext
+	if viper.GetString("journal.filenames") != "dated" || len(e.ID) < shortIDLen {
+		return filepath.Join(s.baseDir, e.ID+ext)
+	}
+	return s.datedPath(e, ext)
+}
+
+// datedPath is the dated filename for e with extension ext.
+func (s *fileService) datedPath(e create.Entry, ext string) string {
+	at := sessionTime(e)
+	if at.IsZero() {
+		at = time.Now()
+	}
+	name := at.Format("2006-01-02T1504")
+	if slug := spots.Slug(e.Spot); slug != "" {
+		name += "_" + slug
+	}
+	return filepath.Join(s.baseDir, name+"_"+e.ID[:shortIDLen]+ext)
+}
+
+// listWorkers bounds concurrent file reads during List.
+const listWorkers = 8
 
 // List loads all entry JSON files (best-effort; skips corrupt ones) sorted by mtime desc.
+// Files are read and decoded by a small worker pool, each reusing its own buffer.
 func (s *fileService) List() ([]create.Entry, error) {
-	var entries []create.Entry
 	// gather files
 	var files []fs.FileInfo
 	dir, err := os.ReadDir(s.baseDir)
@@ -53,7 +117,7 @@ func (s *fileService) List() ([]create.Entry, error) {
 		return nil, err
 	}
 	for _, de := range dir {
-		if de.IsDir() || !strings.HasSuffix(de.Name(), ".json") {
+		if de.IsDir() || !isEntryFile(de.Name()) {
 			continue
 		}
 		info, err := de.Info()
@@ -63,33 +127,117 @@ func (s *fileService) List() ([]create.Entry, error) {
 		files = append(files, info)
 	}
 	sort.Slice(files, func(i, j int) bool { return files[i].ModTime().After(files[j].ModTime()) })
-	for _, fi := range files {
-		b, err := os.ReadFile(filepath.Join(s.baseDir, fi.Name()))
-		if err != nil {
+
+	// Unchanged files come from the index; only the rest are read.
+	idx := loadIndex(s.indexPath)
+	results := make([]create.Entry, len(files))
+	ok := make([]bool, len(files))
+	var stale []int
+	for i, fi := range files {
+		if f, hit := idx.Files[fi.Name()]; hit && f.matches(fi) {
+			results[i], ok[i] = f.Entry, true
 			continue
 		}
-		var e create.Entry
-		if err := json.Unmarshal(b, &e); err != nil || e.ID == "" {
+		stale = append(stale, i)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(listWorkers, len(stale)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var buf bytes.Buffer
+			for i := range jobs {
+				e, err := s.readEntry(&buf, files[i])
+				if err != nil {
+					continue
+				}
+				results[i] = e
+				ok[i] = true
+			}
+		}()
+	}
+	for _, i := range stale {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	s.refreshIndex(idx, files, results, ok)
+
+	entries := make([]create.Entry, 0, len(files))
+	for i := range results {
+		if ok[i] {
+			entries = append(entries, results[i])
+		}
+	}
+	return entries, nil
+}
+
+// indexRacyWindow keeps files modified this recently out of the index: on
+// filesystems with coarse timestamps a second same-size write could
+// otherwise go unnoticed.
+const indexRacyWindow = 2 * time.Second
+
+// refreshIndex rewrites the index when files were added, changed or removed
+// since it was written.
+func (s *fileService) refreshIndex(idx listIndex, files []fs.FileInfo, results []create.Entry, ok []bool) {
+	if s.indexPath == "" {
+		return
+	}
+	cutoff := time.Now().Add(-indexRacyWindow)
+	next := listIndex{Files: make(map[string]indexedFile, len(files))}
+	changed := false
+	for i, fi := range files {
+		if !ok[i] || fi.ModTime().After(cutoff) {
 			continue
 		}
-		if strings.TrimSpace(e.CreatedAt) == "" { // backfill from file mtime
-			e.CreatedAt = fi.ModTime().UTC().Format(time.RFC3339)
+		if f, hit := idx.Files[fi.Name()]; !hit || !f.matches(fi) {
+			changed = true
 		}
-		entries = append(entries, e)
+		next.Files[fi.Name()] = indexedFile{Size: fi.Size(), ModTime: fi.ModTime().UnixNano(), Entry: results[i]}
+	}
+	if changed || len(next.Files) != len(idx.Files) {
+		_ = saveIndex(s.indexPath, next)
 	}
-	return entries, nil
+}
+
+// readEntry decodes a single entry file using buf as scratch space.
+func (s *fileService) readEntry(buf *bytes.Buffer, fi fs.FileInfo) (create.Entry, error) {
+	f, err := os.Open(filepath.Join(s.baseDir, fi.Name()))
+	if err != nil {
+		return create.Entry{}, err
+	}
+	defer f.Close()
+	buf.Reset()
+	if _, err := buf.ReadFrom(f); err != nil {
+		return create.Entry{}, err
+	}
+	var e create.Entry
+	if err := codecFor(fi.Name()).unmarshal(buf.Bytes(), &e); err != nil {
+		return create.Entry{}, err
+	}
+	if e.ID == "" {
+		return create.Entry{}, errors.New("entry missing id")
+	}
+	if strings.TrimSpace(e.CreatedAt) == "" { // backfill from file mtime
+		e.CreatedAt = fi.ModTime().UTC().Format(time.RFC3339)
+	}
+	return e, nil
 }
 
 func (s *fileService) Get(id string) (create.Entry, error) {
 	if id == "" {
 		return create.Entry{}, errors.New("empty id")
 	}
-	b, err := os.ReadFile(s.entryPath(id))
+	path := s.entryPath(id)
+	b, err := os.ReadFile(path)
 	if err != nil {
 		return create.Entry{}, err
 	}
 	var e create.Entry
-	if err := json.Unmarshal(b, &e); err != nil {
+	if err := codecFor(path).unmarshal(b, &e); err != nil {
 		return create.Entry{}, err
 	}
 	if e.ID == "" {
@@ -106,11 +254,37 @@ func (s *fileService) Create(e create.Entry) (create.Entry, error) {
 	if strings.TrimSpace(e.CreatedAt) == "" {
 		e.CreatedAt = time.Now().UTC().Format(time.RFC3339)
 	}
-	data, err := json.MarshalIndent(e, "", "  ")
+	if strings.TrimSpace(e.Author) == "" {
+		e.Author = config.User()
+	}
+	data, err := writeCodec().marshal(e)
 	if err != nil {
 		return create.Entry{}, err
 	}
-	if err := os.WriteFile(s.entryPath(e.ID), data, 0o644); err != nil {
+	if err := os.WriteFile(s.newEntryPath(e), data, 0o644); err != nil {
+		return create.Entry{}, err
+	}
+	return e, nil
+}
+
+func (s *fileService) Import(e create.Entry) (create.Entry, error) {
+	if strings.TrimSpace(e.ID) == "" {
+		return create.Entry{}, errors.New("empty id")
+	}
+	if strings.TrimSpace(e.Spot) == "" {
+		return create.Entry{}, errors.New("spot required")
+	}
+	if _, err := os.Stat(s.entryPath(e.ID)); err == nil {
+		return create.Entry{}, errors.New("entry " + e.ID + " already exists")
+	}
+	if strings.TrimSpace(e.CreatedAt) == "" {
+		e.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	data, err := writeCodec().marshal(e)
+	if err != nil {
+		return create.Entry{}, err
+	}
+	if err := os.WriteFile(s.newEntryPath(e), data, 0o644); err != nil {
 		return create.Entry{}, err
 	}
 	return e, nil
@@ -130,17 +304,31 @@ func (s *fileService) Update(id string, mutate func(*create.Entry) error) (creat
 	if strings.TrimSpace(cur.CreatedAt) == "" { // ensure not lost
 		cur.CreatedAt = time.Now().UTC().Format(time.RFC3339)
 	}
-	data, err := json.MarshalIndent(cur, "", "  ")
+	path := s.entryPath(id)
+	codec := codecFor(path) // keep the file's existing format
+	data, err := codec.marshal(cur)
 	if err != nil {
 		return create.Entry{}, err
 	}
-	tmp := s.entryPath(id) + ".tmp"
+	// A dated name follows the spot and session time: the new name appears
+	// complete via the rename, then the old one is removed.
+	dest := path
+	if filepath.Base(path) != id+codec.ext && len(id) >= shortIDLen && !sessionTime(cur).IsZero() {
+		dest = s.datedPath(cur, codec.ext)
+	}
+	tmp := path + ".tmp"
 	if err := os.WriteFile(tmp, data, 0o644); err != nil {
 		return create.Entry{}, err
 	}
-	if err := os.Rename(tmp, s.entryPath(id)); err != nil {
+	if err := os.Rename(tmp, dest); err != nil {
+		os.Remove(tmp)
 		return create.Entry{}, err
 	}
+	if dest != path {
+		if err := os.Remove(path); err != nil {
+			return create.Entry{}, err
+		}
+	}
 	return cur, nil
 }
 
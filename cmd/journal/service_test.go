@@ -0,0 +1,261 @@
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/create"
+)
+
+// savedEntry is an entry file as an older version wrote it, with the
+// imperial summary text it used to store.
+const savedEntry = `{
+  "id": "3f2b8c4e-1d7a-4b9e-8c21-5a6f0e9d7b13",
+  "spot": "Ocean Beach",
+  "wave_height": "Chest",
+  "wave_summary": {
+    "station_id": "46026",
+    "time": "2024-05-01T14:00:00Z",
+    "significant_height_m": 1.4,
+    "swell_height_m": 1.2,
+    "swell_period_s": 13,
+    "wind_wave_height_m": 0.5,
+    "wind_wave_period_s": 5,
+    "swell_direction": "WNW",
+    "wind_wave_direction": "NW",
+    "steepness": "AVERAGE",
+    "average_period_s": 8.2,
+    "mean_wave_direction_deg": 290,
+    "swell_direction_deg": 292.5,
+    "wind_wave_direction_deg": 315,
+    "mean_wave_direction": "WNW",
+    "summary": "4.6ft @ 13s WNW"
+  },
+  "wind": {
+    "station_id": "46026",
+    "time": "2024-05-01T14:00:00Z",
+    "speed_ms": 4.1,
+    "direction_deg": 300,
+    "direction": "WNW"
+  },
+  "water_temp_c": 12.3,
+  "session_at": "2024-05-01T14:30:00Z",
+  "comments": "",
+  "created_at": "2024-05-01T16:00:00Z"
+}`
+
+// TestUpdateKeepsSnapshot re-saves an entry under other display and
+// smoothing settings and checks the conditions snapshot's readings are
+// written back as they were.
+func TestUpdateKeepsSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	const id = "3f2b8c4e-1d7a-4b9e-8c21-5a6f0e9d7b13"
+	path := filepath.Join(dir, id+".json")
+	if err := os.WriteFile(path, []byte(savedEntry), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	svc, err := NewFileService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := func(b []byte) map[string]any {
+		t.Helper()
+		var m map[string]any
+		if err := json.Unmarshal(b, &m); err != nil {
+			t.Fatal(err)
+		}
+		ws := m["wave_summary"].(map[string]any)
+		delete(ws, "summary") // display text, no longer stored
+		return map[string]any{"wave_summary": ws, "wind": m["wind"], "water_temp_c": m["water_temp_c"]}
+	}
+	want := snapshot([]byte(savedEntry))
+	t.Cleanup(func() {
+		for _, k := range []string{"units", "display.temperature", "buoy.smoothing"} {
+			viper.Set(k, nil)
+		}
+	})
+
+	for _, settings := range []map[string]any{
+		{"units": "metric", "display.temperature": "C", "buoy.smoothing": "median"},
+		{"units": "imperial", "display.temperature": "F", "buoy.smoothing": "latest"},
+	} {
+		for k, v := range settings {
+			viper.Set(k, v)
+		}
+		if _, err := svc.Update(id, func(e *create.Entry) error {
+			e.Comments += "re-saved; "
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := snapshot(b); !reflect.DeepEqual(got, want) {
+			t.Errorf("with %v the snapshot became\n%v\nwant\n%v", settings, got, want)
+		}
+		var m map[string]any
+		_ = json.Unmarshal(b, &m)
+		if _, ok := m["wave_summary"].(map[string]any)["summary"]; ok {
+			t.Errorf("with %v the unit-dependent summary text was saved", settings)
+		}
+	}
+}
+
+// TestUpdateRenamesDatedFile moves a dated entry file when its spot or
+// session time changes, leaving no copy under the old name.
+func TestUpdateRenamesDatedFile(t *testing.T) {
+	viper.Set("journal.filenames", "dated")
+	t.Cleanup(func() { viper.Set("journal.filenames", nil) })
+	dir := t.TempDir()
+	svc, err := NewFileService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2025, 5, 4, 7, 0, 0, 0, time.UTC)
+	e, err := svc.Create(create.Entry{Spot: "Ocean Beach", SessionAt: at})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Update(e.ID, func(e *create.Entry) error {
+		e.Spot = "Linda Mar"
+		e.SessionAt = at.Add(90 * time.Minute)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	des, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "2025-05-04T0830_linda-mar_" + e.ID[:shortIDLen] + ".json"
+	if len(des) != 1 || des[0].Name() != want {
+		var names []string
+		for _, de := range des {
+			names = append(names, de.Name())
+		}
+		t.Fatalf("journal dir holds %v, want [%s]", names, want)
+	}
+	if got, err := svc.Get(e.ID); err != nil || got.Spot != "Linda Mar" {
+		t.Fatalf("Get after rename = %+v, %v", got, err)
+	}
+}
+
+// withStateDir points `state.dir` at a fresh temporary directory.
+func withStateDir(tb testing.TB) {
+	viper.Set("state.dir", tb.TempDir())
+	tb.Cleanup(func() { viper.Set("state.dir", nil) })
+}
+
+// age backdates every file in dir past the index's racy window.
+func age(tb testing.TB, dir string) {
+	old := time.Now().Add(-time.Hour)
+	des, err := os.ReadDir(dir)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	for _, de := range des {
+		if err := os.Chtimes(filepath.Join(dir, de.Name()), old, old); err != nil {
+			tb.Fatal(err)
+		}
+	}
+}
+
+func TestListIndex(t *testing.T) {
+	withStateDir(t)
+	dir := t.TempDir()
+	svc, err := NewFileService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := svc.Create(create.Entry{Spot: "Ocean Beach", Comments: "glassy"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := svc.Create(create.Entry{Spot: "Linda Mar", Comments: "crowded"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "3f2b8c4e-1d7a-4b9e-8c21-5a6f0e9d7b13.json"), []byte(savedEntry), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var saved create.Entry
+	if err := json.Unmarshal([]byte(savedEntry), &saved); err != nil {
+		t.Fatal(err)
+	}
+	age(t, dir)
+	if entries, err := svc.List(); err != nil || len(entries) != 3 {
+		t.Fatalf("first List = %d entries, %v", len(entries), err)
+	}
+	if _, err := os.Stat(svc.(*fileService).indexPath); err != nil {
+		t.Fatalf("index not written: %v", err)
+	}
+
+	// An edit changes the file's stamp, so the index copy is not served.
+	if _, err := svc.Update(a.ID, func(e *create.Entry) error {
+		e.Comments = "glassy, then onshore by 9"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.Delete(b.ID); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := svc.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Comments != "glassy, then onshore by 9" {
+		t.Fatalf("List after edit and delete = %+v", entries)
+	}
+	// The untouched entry came from the index with its snapshot intact.
+	if got := entries[1]; !reflect.DeepEqual(got, saved) {
+		t.Errorf("indexed entry = %+v\nwant %+v", got, saved)
+	}
+}
+
+// BenchmarkList lists a journal of 10k entries.
+// Listing after the first call is served from the index.
+func BenchmarkList(b *testing.B) {
+	withStateDir(b)
+	dir := b.TempDir()
+	svc, err := NewFileService(dir)
+	if err != nil {
+		b.Fatal(err)
+	}
+	start := time.Date(2020, 1, 1, 7, 0, 0, 0, time.UTC)
+	for i := range 10_000 {
+		_, err := svc.Create(create.Entry{
+			Spot:       fmt.Sprintf("Spot %d", i%40),
+			WaveHeight: create.HeightOptions[i%len(create.HeightOptions)],
+			SessionAt:  start.Add(time.Duration(i) * 6 * time.Hour),
+			Rating:     i%5 + 1,
+			Comments:   "peaky and a bit wind-affected by the end",
+			Tags:       []string{"dawn", "longboard"},
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	age(b, dir)
+	if _, err := svc.List(); err != nil { // build the index
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for range b.N {
+		entries, err := svc.List()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(entries) != 10_000 {
+			b.Fatalf("List returned %d entries, want 10000", len(entries))
+		}
+	}
+}
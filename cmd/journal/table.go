@@ -0,0 +1,194 @@
+package journal
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/units"
+)
+
+// TableFormats are the formats accepted by WriteTable.
+var TableFormats = []string{"csv", "markdown", "json"}
+
+// CheckTableFormat reports an error for formats WriteTable does not know.
+func CheckTableFormat(format string) error {
+	switch strings.ToLower(format) {
+	case "csv", "markdown", "md", "json":
+		return nil
+	}
+	return fmt.Errorf("unknown format %q (want %s)", format, strings.Join(TableFormats, ", "))
+}
+
+// tableColumns returns the flattened columns written for csv and markdown, in
+// order. Heights and water temperature are in the `units` system, named by
+// their suffix; everything else follows the entry JSON (seconds, m/s).
+func tableColumns() []string {
+	h, t := "_"+units.HeightUnit(), "_f"
+	if units.Celsius() {
+		t = "_c"
+	}
+	return []string{
+		"id", "session_at", "spot", "author", "wave_height", "duration_min", "wave_count",
+		"buoy_station", "significant_height" + h, "swell_height" + h, "swell_period_s", "swell_direction",
+		"wind_wave_height" + h, "wind_wave_period_s", "wind_wave_direction", "average_period_s",
+		"mean_wave_direction_deg", "steepness", "wind_speed_ms", "wind_gust_ms", "wind_direction_deg",
+		"pressure_hpa", "water_temp" + t, "aqi", "tags", "comments",
+	}
+}
+
+// tableRow flattens e into tableColumns. Missing values are empty.
+func tableRow(e create.Entry) []string {
+	num := func(v float64, ok bool) string {
+		if !ok {
+			return ""
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	// converted values are rounded so feet don't carry float noise
+	height := func(m float64, ok bool) string {
+		if !ok || units.Metric() {
+			return num(m, ok)
+		}
+		return strconv.FormatFloat(units.HeightValue(m), 'f', 2, 64)
+	}
+	count := func(n int) string {
+		if n == 0 {
+			return ""
+		}
+		return strconv.Itoa(n)
+	}
+	ws := e.WaveSummary
+	hasWaves := !ws.IsZero()
+	row := []string{
+		e.ID, "", e.Spot, e.Author, e.WaveHeight, count(e.DurationMin), count(e.WaveCount),
+		ws.StationID(), height(ws.SignificantHeight(), hasWaves), height(ws.SwellHeight(), hasWaves), num(ws.SwellPeriod(), hasWaves), ws.SwellDirection(),
+		height(ws.WindWaveHeight(), hasWaves), num(ws.WindWavePeriod(), hasWaves), ws.WindWaveDirection(), num(ws.AveragePeriod(), hasWaves),
+		"", ws.Steepness(), "", "", "",
+		"", "", count(e.AQI), strings.Join(e.Tags, " "), e.Comments,
+	}
+	if at := sessionTime(e); !at.IsZero() {
+		row[1] = at.Format(time.RFC3339)
+	}
+	if hasWaves {
+		row[16] = strconv.Itoa(ws.MeanWaveDirection())
+	}
+	if w := e.Wind; w != nil && !w.IsZero() {
+		row[18] = num(w.Speed(), true)
+		row[19] = num(w.Gust())
+		row[20] = num(w.Direction())
+		row[21] = num(w.Pressure())
+	}
+	if c := e.WaterTempC; c != nil && units.Celsius() {
+		row[22] = num(*c, true)
+	} else if c != nil {
+		row[22] = strconv.FormatFloat(i18n.TemperatureValue(*c), 'f', 1, 64)
+	}
+	return row
+}
+
+// WriteTable writes entries as csv or markdown with wave and wind fields
+// flattened into columns, or as a JSON array of entries.
+func WriteTable(w io.Writer, format string, entries []create.Entry) error {
+	if err := CheckTableFormat(format); err != nil {
+		return err
+	}
+	switch strings.ToLower(format) {
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write(tableColumns()); err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if err := cw.Write(tableRow(e)); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case "markdown", "md":
+		cell := strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+		columns := tableColumns()
+		fmt.Fprintf(w, "| %s |\n", strings.Join(columns, " | "))
+		fmt.Fprintf(w, "|%s\n", strings.Repeat(" --- |", len(columns)))
+		for _, e := range entries {
+			row := tableRow(e)
+			for i := range row {
+				row[i] = cell.Replace(row[i])
+			}
+			if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(row, " | ")); err != nil {
+				return err
+			}
+		}
+		return nil
+	default: // json
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+}
+
+// tableExt returns the file extension for a WriteTable format.
+func tableExt(format string) string {
+	if f := strings.ToLower(format); f == "markdown" || f == "md" {
+		return ".md"
+	}
+	return "." + strings.ToLower(format)
+}
+
+// exportedMsg reports the result of the journal view's export key.
+type exportedMsg struct {
+	path string
+	n    int
+	err  error
+}
+
+// exportCmd writes entries to <export.dir>/surflog-<date>.<ext> in the
+// `export.format` format (csv by default). export.dir defaults to the home
+// directory.
+func exportCmd(entries []create.Entry) tea.Cmd {
+	return func() tea.Msg {
+		format := viper.GetString("export.format")
+		if format == "" {
+			format = "csv"
+		}
+		dir := viper.GetString("export.dir")
+		if dir == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return exportedMsg{err: err}
+			}
+			dir = home
+		}
+		path := filepath.Join(dir, "surflog-"+time.Now().Format("2006-01-02")+tableExt(format))
+		f, err := os.Create(path)
+		if err != nil {
+			return exportedMsg{err: err}
+		}
+		err = WriteTable(f, format, entries)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		return exportedMsg{path: path, n: len(entries), err: err}
+	}
+}
+
+// applyExported flashes the export result in the list's status bar.
+func (j *Journal) applyExported(msg exportedMsg) tea.Cmd {
+	if msg.err != nil {
+		j.status = i18n.T("Export failed: %v", msg.err)
+	} else {
+		j.status = i18n.T("Exported %d entries to %s", msg.n, msg.path)
+	}
+	return j.list.NewStatusMessage(j.status)
+}
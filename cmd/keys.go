@@ -1,35 +1,119 @@
 package cmd
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/recommend"
+	"github.com/sumwatshade/surflog/cmd/stationpicker"
+	"github.com/sumwatshade/surflog/cmd/week"
+)
 
 // keyMap defines all key bindings for the application. It satisfies key.Map so
 // it can be passed directly to bubbles/help.Model for automatic rendering.
 type keyMap struct {
-	Journal key.Binding
-	Create  key.Binding
-	Help    key.Binding
-	Quit    key.Binding
+	Journal  key.Binding
+	Create   key.Binding
+	Bets     key.Binding
+	Report   key.Binding
+	Quiver   key.Binding
+	Stats    key.Binding
+	Week     key.Binding
+	Stations key.Binding
+	Timer    key.Binding
+	Tide     key.Binding
+	TideDay  key.Binding
+	Outlook  key.Binding
+	Spot     key.Binding
+	Refresh  key.Binding
+	// Retry* re-run a single fetch after it failed.
+	RetryWaves    key.Binding
+	RetryWind     key.Binding
+	RetryTide     key.Binding
+	RetryForecast key.Binding
+	Help          key.Binding
+	Quit          key.Binding
 }
 
 // ShortHelp returns keybindings shown in the mini help view.
 func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Journal, k.Create, k.Help, k.Quit}
+	return []key.Binding{k.Journal, k.Create, k.Bets, k.Report, k.Quiver, k.Stats, k.Week, k.Stations, k.Timer, k.Tide, k.TideDay, k.Spot, k.Refresh, k.Help, k.Quit}
 }
 
 // FullHelp returns keybindings for the expanded help view (columns).
 func (k keyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{{k.Journal, k.Create}, {k.Help, k.Quit}}
+	return [][]key.Binding{{k.Journal, k.Create, k.Bets, k.Report, k.Quiver, k.Stats, k.Week, k.Stations}, {k.Timer, k.Tide, k.TideDay, k.Outlook, k.Spot, k.Refresh, k.Help, k.Quit},
+		{k.RetryWaves, k.RetryWind, k.RetryTide, k.RetryForecast}}
 }
 
-// keys is the exported set of key bindings used across the app.
-var keys = keyMap{
-	Journal: key.NewBinding(
-		key.WithKeys("j"),
-		key.WithHelp("j", "journal view"),
-	),
-	Create: key.NewBinding(
-		key.WithKeys("c"),
-		key.WithHelp("c", "create entry"),
-	),
-	Quit: key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+// newKeyMap builds the set of key bindings used across the app. It is built at
+// startup (after config is loaded) so help text uses the configured locale.
+func newKeyMap() keyMap {
+	return keyMap{
+		Journal: key.NewBinding(
+			key.WithKeys("j"),
+			key.WithHelp("j", i18n.T("journal view")),
+		),
+		Create: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", i18n.T("create entry")),
+		),
+		Bets: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", i18n.T("best bets view")),
+		),
+		Report: key.NewBinding(
+			key.WithKeys("s", "S"), // S from the journal, where s sorts
+			key.WithHelp("s", i18n.T("spot report view")),
+		),
+		Quiver: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", i18n.T("quiver view")),
+		),
+		Stats: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", i18n.T("stats view")),
+		),
+		Week: key.NewBinding(
+			key.WithKeys(week.Key),
+			key.WithHelp(week.Key, i18n.T("week planner")),
+		),
+		Stations: key.NewBinding(
+			key.WithKeys(stationpicker.Key),
+			key.WithHelp(stationpicker.Key, i18n.T("station browser")),
+		),
+		Timer: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", i18n.T("start/stop timer")),
+		),
+		Tide: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", i18n.T("tide chart/table")),
+		),
+		TideDay: key.NewBinding(
+			key.WithKeys("left", "right"),
+			key.WithHelp("←/→", i18n.T("tide day")),
+		),
+		Outlook: key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", i18n.T("swell forecast daily/hourly")),
+		),
+		Spot: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", i18n.T("next spot")),
+		),
+		Refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", i18n.T("refresh conditions")),
+		),
+		RetryWaves:    retryBinding(buoy.RetryKey(buoy.SectionWaves), i18n.T("retry waves")),
+		RetryWind:     retryBinding(buoy.RetryKey(buoy.SectionWind), i18n.T("retry wind")),
+		RetryTide:     retryBinding(buoy.RetryKey(buoy.SectionTide), i18n.T("retry tide")),
+		RetryForecast: retryBinding(recommend.RetryKey, i18n.T("retry forecast")),
+		Quit:          key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", i18n.T("quit"))),
+	}
+}
+
+func retryBinding(k, help string) key.Binding {
+	return key.NewBinding(key.WithKeys(k), key.WithHelp(k, help))
 }
@@ -0,0 +1,44 @@
+// Package layout fits terminal text into a number of display columns. Widths
+// are measured the way the terminal draws them: ANSI styling takes no room
+// and wide runes (CJK, most emoji) take two columns.
+package layout
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Ellipsis marks text cut short by Truncate.
+const Ellipsis = "…"
+
+// Width returns the display width of the widest line in s.
+func Width(s string) int {
+	w := 0
+	for _, line := range strings.Split(s, "\n") {
+		w = max(w, ansi.StringWidth(line))
+	}
+	return w
+}
+
+// Truncate shortens a single line to at most width columns, ending it with an
+// ellipsis when anything was cut. Styling is kept intact.
+func Truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if ansi.StringWidth(s) <= width {
+		return s
+	}
+	return ansi.Truncate(s, width, Ellipsis)
+}
+
+// TruncateLines applies Truncate to every line of s, so a block never wraps
+// when placed in a column width wide.
+func TruncateLines(s string, width int) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = Truncate(line, width)
+	}
+	return strings.Join(lines, "\n")
+}
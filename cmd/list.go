@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/journal"
+	"github.com/sumwatshade/surflog/cmd/quiver"
+	"github.com/sumwatshade/surflog/cmd/units"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List journal entries (for scripts)",
+	Long: `Prints journal entries newest first, one per line, or with --json one JSON
+object per line (newline-delimited) in the journal's entry format.
+
+--spot matches part of the spot name and may contain spaces; --filter takes
+the same expression as the journal "/" search
+(e.g. 'spot:mavericks rating:>3 "paddle out"').`,
+	Example: `  surflog list --spot "Linda Mar" --since 2025-01-01 --json`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, _ := cmd.Flags().GetString("filter")
+		q, err := journal.ParseQuery(filter)
+		if err != nil {
+			return err
+		}
+		if spot, _ := cmd.Flags().GetString("spot"); strings.TrimSpace(spot) != "" {
+			q = q.WithSpot(spot)
+		}
+		if since, _ := cmd.Flags().GetString("since"); since != "" {
+			t, err := time.ParseInLocation("2006-01-02", since, time.Local)
+			if err != nil {
+				return fmt.Errorf("invalid --since %q (want YYYY-MM-DD)", since)
+			}
+			q = q.WithSince(t)
+		}
+		entries, err := loadEntries()
+		if err != nil {
+			return err
+		}
+		journal.SortBySession(entries)
+		limit, _ := cmd.Flags().GetInt("limit")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		out := cmd.OutOrStdout()
+		enc := json.NewEncoder(out)
+		n := 0
+		for _, e := range entries {
+			if !q.Match(e) {
+				continue
+			}
+			if limit > 0 && n == limit {
+				break
+			}
+			n++
+			if asJSON {
+				if err := enc.Encode(e); err != nil {
+					return err
+				}
+				continue
+			}
+			fmt.Fprintf(out, "%s  %s  %-20s %-9s %s\n", shortID(e.ID), e.SessionAt.Local().Format("2006-01-02 15:04"), e.Spot, create.HeightLabel(e.WaveHeight), e.WaveSummary.Short())
+		}
+		return nil
+	},
+}
+
+var showCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Print one journal entry",
+	Long:  "Prints the entry with the given ID (or unique ID prefix, as shown by `surflog list`).",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := loadEntries()
+		if err != nil {
+			return err
+		}
+		e, err := findEntry(entries, args[0])
+		if err != nil {
+			return err
+		}
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			data, err := json.MarshalIndent(e, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return nil
+		}
+		printEntry(cmd.OutOrStdout(), e)
+		return nil
+	},
+}
+
+// printEntry writes the plain-text equivalent of the journal detail view.
+func printEntry(w io.Writer, e create.Entry) {
+	fmt.Fprintln(w, e.Spot)
+	fmt.Fprintf(w, "id       %s\n", e.ID)
+	if !e.SessionAt.IsZero() {
+		fmt.Fprintf(w, "session  %s\n", i18n.DateTime(e.SessionAt.Local()))
+	}
+	if e.DurationMin > 0 {
+		fmt.Fprintf(w, "duration %s\n", create.FormatDuration(e.DurationMin))
+	}
+	if e.Author != "" {
+		fmt.Fprintf(w, "author   %s\n", e.Author)
+	}
+	fmt.Fprintf(w, "height   %s\n", create.HeightLabel(e.WaveHeight))
+	if e.WaveCount > 0 {
+		line := fmt.Sprintf("%d", e.WaveCount)
+		if rate, ok := create.WavesPerHour(e.WaveCount, e.DurationMin); ok {
+			line += fmt.Sprintf(" (%.1f/h)", rate)
+		}
+		fmt.Fprintf(w, "waves    %s\n", line)
+	}
+	if board := quiver.Name(e.BoardID); board != "" {
+		fmt.Fprintf(w, "board    %s\n", board)
+	}
+	if s := create.Stars(e.Rating); s != "" {
+		fmt.Fprintf(w, "rating   %s\n", s)
+	}
+	if !e.WaveSummary.IsZero() {
+		fmt.Fprintf(w, "buoy     %s\n", e.WaveSummary.String())
+	}
+	if e.Wind != nil {
+		fmt.Fprintf(w, "wind     %s\n", e.Wind.String())
+	}
+	if e.WaterTempC != nil {
+		fmt.Fprintf(w, "water    %s\n", i18n.Temperature(*e.WaterTempC))
+	}
+	if e.TideFt != nil {
+		fmt.Fprintf(w, "tide     %s\n", units.Feet(*e.TideFt))
+	}
+	if e.AQI > 0 {
+		fmt.Fprintf(w, "aqi      %d\n", e.AQI)
+	}
+	if len(e.Tags) > 0 {
+		fmt.Fprintf(w, "tags     #%s\n", strings.Join(e.Tags, " #"))
+	}
+	for _, a := range e.Attachments {
+		fmt.Fprintf(w, "attached %s\n", a.Name)
+	}
+	if e.Comments != "" {
+		fmt.Fprintf(w, "\n%s\n", e.Comments)
+	}
+}
+
+// shortID abbreviates an entry ID for listings; `show` accepts the prefix.
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
+
+func init() {
+	listCmd.Flags().String("spot", "", "only entries whose spot contains this")
+	listCmd.Flags().String("since", "", "only sessions on or after this date, YYYY-MM-DD")
+	listCmd.Flags().String("filter", "", "journal filter expression")
+	listCmd.Flags().Int("limit", 0, "print at most this many entries (0 = all)")
+	listCmd.Flags().Bool("json", false, "print entries as newline-delimited JSON")
+	showCmd.Flags().Bool("json", false, "print the entry as JSON")
+	rootCmd.AddCommand(listCmd, showCmd)
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/journal"
+	"github.com/sumwatshade/surflog/cmd/spots"
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log <spot>",
+	Short: "Log a session without opening the TUI",
+	Long: `Creates a journal entry for a session at <spot>, snapshots the buoy
+conditions the same way the create form does, and prints the saved entry ID.
+
+--at takes "HH:MM" (today) or "YYYY-MM-DD HH:MM" and defaults to now.
+Sessions more than an hour ago get the wave reading nearest their start.
+The buoy is --station when given, else the spot's mapped station, else the
+configured one. --hindcast also records archived wind, water temperature and
+the predicted tide; see ` + "`surflog journal add`" + `.`,
+	Example: `  surflog log "Ocean Beach" --height waist --comments "fun lefts" --at "07:15"`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runLog,
+}
+
+var journalAddCmd = &cobra.Command{
+	Use:   "add <spot>",
+	Short: "Add a session, optionally with archived conditions",
+	Long: `Creates a journal entry like ` + "`surflog log`" + `. With --hindcast and a past --at,
+conditions come from NDBC's monthly and yearly archives rather than the
+realtime files, which only reach back about 45 days: waves, wind and water
+temperature nearest the session start, plus the tide level predicted at the
+spot's tide station. Run it once per session to log a whole old trip.`,
+	Example: `  surflog journal add "Playa Hermosa" --at "2023-06-14 07:30" --hindcast --height head --duration 120
+  surflog journal add "Playa Hermosa" --at "2023-06-15 16:00" --hindcast --height shoulder`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLog,
+}
+
+// runLog creates an entry for args[0] from the flags shared by log and
+// journal add.
+func runLog(cmd *cobra.Command, args []string) error {
+	entry := create.Entry{Spot: strings.TrimSpace(args[0]), SessionAt: time.Now().Truncate(time.Minute)}
+	if entry.Spot == "" {
+		return errors.New("spot required")
+	}
+	if at, _ := cmd.Flags().GetString("at"); at != "" {
+		t, ok := create.ParseSessionTime(at)
+		if !ok {
+			return fmt.Errorf("invalid --at %q (want HH:MM or YYYY-MM-DD HH:MM)", at)
+		}
+		entry.SessionAt = t
+	}
+	height, _ := cmd.Flags().GetString("height")
+	var ok bool
+	if entry.WaveHeight, ok = create.NormalizeHeight(height); !ok {
+		return fmt.Errorf("unknown --height %q (want one of %s)", height, strings.Join(create.HeightLabels(), ", "))
+	}
+	entry.Comments, _ = cmd.Flags().GetString("comments")
+	if entry.DurationMin, _ = cmd.Flags().GetInt("duration"); entry.DurationMin < 0 {
+		return errors.New("--duration must not be negative")
+	}
+	if entry.WaveCount, _ = cmd.Flags().GetInt("waves"); entry.WaveCount < 0 {
+		return errors.New("--waves must not be negative")
+	}
+	tags, _ := cmd.Flags().GetStringSlice("tags")
+	entry.AddTags(tags...)
+
+	hindcast, _ := cmd.Flags().GetBool("hindcast")
+	if hindcast && !create.Backdated(entry.SessionAt) {
+		return errors.New("--hindcast needs a past --at")
+	}
+
+	station, tideStation := "", ""
+	if cmd.Flags().Changed("station") {
+		station = buoy.ConfiguredBuoyStation()
+	} else if svc, err := spots.NewDefaultService(); err == nil {
+		if sp, err := svc.Get(entry.Spot); err == nil {
+			entry.Spot = sp.Name // keep the spot's canonical spelling
+			station = strings.TrimSpace(sp.Station)
+			tideStation = strings.TrimSpace(sp.TideStation)
+		}
+	}
+	snapshot := func() error { return snapshotConditions(cmd, &entry, station) }
+	if hindcast {
+		snapshot = func() error { return hindcastConditions(cmd, &entry, station, tideStation) }
+	}
+	ctx, stop := withProgress(cmd.Context(), cmd.ErrOrStderr())
+	cmd.SetContext(ctx)
+	err := snapshot()
+	interrupted := ctx.Err() != nil
+	stop()
+	if interrupted {
+		return errors.New("cancelled; nothing saved")
+	}
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "saving without buoy data: %v\n", err)
+	}
+
+	svc, err := journal.NewDefaultService()
+	if err != nil {
+		return err
+	}
+	saved, err := svc.Create(entry)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), saved.ID)
+	return nil
+}
+
+// snapshotConditions fills the entry's wave summary (and, for sessions that
+// are not backdated, wind and water temperature) from station, or the
+// configured buoy when station is empty. Only the wave error is returned;
+// wind and water are best-effort as in the create form.
+func snapshotConditions(cmd *cobra.Command, e *create.Entry, station string) error {
+	svc := buoy.NewService()
+	if station != "" {
+		if err := buoy.ValidateBuoyStation(station); err != nil {
+			return err
+		}
+		svc = buoy.NewServiceForStations(station, "")
+	}
+	ctx := cmd.Context()
+	if create.Backdated(e.SessionAt) {
+		ws, err := svc.GetWaveSummaryAt(ctx, e.SessionAt)
+		if err != nil {
+			return err
+		}
+		e.WaveSummary = ws
+		e.SetSource(create.WaveSource(ws, time.Now()))
+		return nil
+	}
+	ws, err := svc.GetWaveSummary(ctx)
+	if err != nil {
+		return err
+	}
+	e.WaveSummary = ws
+	e.SetSource(create.WaveSource(ws, time.Now()))
+	if w, err := svc.GetWindSummary(ctx); err == nil {
+		e.Wind = &w
+		e.SetSource(create.WindSource(w, time.Now()))
+	}
+	if t, err := svc.GetTemperatures(ctx); err == nil {
+		if c, ok := t.Water(); ok {
+			e.WaterTempC = &c
+			e.SetSource(create.WaterSource(ws.StationID(), time.Now()))
+		}
+	}
+	return nil
+}
+
+// hindcastConditions fills the entry's waves, wind, water temperature and
+// tide from the archives for its (past) session time. Only a missing wave
+// observation is an error.
+func hindcastConditions(cmd *cobra.Command, e *create.Entry, station, tideStation string) error {
+	if station != "" {
+		if err := buoy.ValidateBuoyStation(station); err != nil {
+			return err
+		}
+	}
+	svc := buoy.NewServiceForStations(station, tideStation)
+	h, err := svc.GetHindcast(cmd.Context(), e.SessionAt)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	e.WaveSummary = h.Wave
+	e.SetSource(create.WaveSource(h.Wave, now))
+	if h.Wind != nil {
+		e.Wind = h.Wind
+		e.SetSource(create.WindSource(*h.Wind, now))
+	}
+	if h.WaterC != nil {
+		e.WaterTempC = h.WaterC
+		e.SetSource(create.WaterSource(h.Wave.StationID(), now))
+	}
+	if h.Tide != nil {
+		ft := math.Round(h.Tide.Height*100) / 100
+		e.TideFt = &ft
+		if tideStation == "" {
+			tideStation = buoy.ConfiguredTideStation()
+		}
+		e.SetSource(create.TideSource(tideStation, now))
+	}
+	return nil
+}
+
+func init() {
+	for _, c := range []*cobra.Command{logCmd, journalAddCmd} {
+		c.Flags().String("height", create.HeightOptions[0], "perceived wave height (value or configured label)")
+		c.Flags().String("comments", "", "session comments")
+		c.Flags().String("at", "", `session start, "HH:MM" or "YYYY-MM-DD HH:MM" (default now)`)
+		c.Flags().Int("duration", 0, "session length in minutes")
+		c.Flags().Int("waves", 0, "waves caught")
+		c.Flags().StringSlice("tags", nil, "tags (comma-separated or repeated)")
+		c.Flags().Bool("hindcast", false, "record archived wind, water and tide as well as waves (needs a past --at)")
+	}
+	rootCmd.AddCommand(logCmd)
+	journalCmd.AddCommand(journalAddCmd)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/config"
+)
+
+// maintenanceCmd groups housekeeping tasks for long-running installs.
+var maintenanceCmd = &cobra.Command{
+	Use:   "maintenance",
+	Short: "Housekeeping for local archives",
+}
+
+var maintenanceCompactCmd = &cobra.Command{
+	Use:   "compact",
+	Short: "Downsample old observations to 3-hourly and compress them",
+	Long: `Compact moves archived observations older than --older-than-months
+(default: archive.compact_after_months, 3) into a gzip-compressed file at
+3-hour resolution. Recording new observations does this automatically; run
+it by hand after lowering the threshold.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		after := buoy.CompactAfter()
+		if months, _ := cmd.Flags().GetInt("older-than-months"); months > 0 {
+			after = time.Duration(months) * 30 * 24 * time.Hour
+		}
+		archive, err := buoy.NewWaterTempArchive(config.StateDir())
+		if err != nil {
+			return err
+		}
+		stations, err := archive.Stations()
+		if err != nil {
+			return err
+		}
+		before := time.Now().Add(-after)
+		total := 0
+		for _, station := range stations {
+			n, err := archive.Compact(station, before)
+			if err != nil {
+				return fmt.Errorf("compact %s: %w", station, err)
+			}
+			if n > 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s: compacted %d readings\n", station, n)
+			}
+			total += n
+		}
+		if total == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "nothing to compact")
+		}
+		return nil
+	},
+}
+
+func init() {
+	maintenanceCompactCmd.Flags().Int("older-than-months", 0, "compact observations older than this many months")
+	maintenanceCmd.AddCommand(maintenanceCompactCmd)
+	rootCmd.AddCommand(maintenanceCmd)
+}
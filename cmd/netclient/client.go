@@ -0,0 +1,135 @@
+package netclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// defaultTimeout applies when http.timeout is unset or invalid.
+const defaultTimeout = 10 * time.Second
+
+var (
+	sharedClientOnce sync.Once
+	sharedClient     *http.Client
+	sharedClientErr  error
+)
+
+// ConfigError reports http.* settings the shared client could not be built
+// from, such as an unparsable http.proxy or a missing http.tls.ca_file.
+type ConfigError struct{ Err error }
+
+func (e *ConfigError) Error() string { return "http config: " + e.Err.Error() }
+func (e *ConfigError) Unwrap() error { return e.Err }
+
+// failingTransport fails every request with the config error, so a bad
+// setting shows up on each fetch instead of being quietly ignored.
+type failingTransport struct{ err error }
+
+func (t failingTransport) RoundTrip(*http.Request) (*http.Response, error) { return nil, t.err }
+
+// Shared lazily builds the client reused by every data source (NOAA, forecasts,
+// air quality) so repeated fetches keep connections alive instead of dialing
+// from scratch. It is built on first use so that config has been loaded by then.
+// When the http.* settings are invalid every request fails with a
+// *ConfigError; call Check first to report that up front.
+func Shared() *http.Client {
+	sharedClientOnce.Do(func() {
+		c, err := newHTTPClient()
+		if err != nil {
+			sharedClientErr = &ConfigError{Err: err}
+			c = &http.Client{Transport: failingTransport{err: sharedClientErr}}
+		}
+		sharedClient = c
+	})
+	return sharedClient
+}
+
+// Check builds the shared client and returns a *ConfigError when the http.*
+// settings are invalid.
+func Check() error {
+	Shared()
+	return sharedClientErr
+}
+
+// newHTTPClient builds the tuned client used for all NOAA requests from config:
+//
+//	http.timeout               overall request timeout (e.g. "30s")
+//	http.proxy                 explicit proxy URL; otherwise HTTP(S)_PROXY/NO_PROXY apply
+//	http.tls.ca_file           extra PEM bundle trusted in addition to system roots
+//	http.tls.insecure_skip_verify  disable certificate verification (debugging only)
+//
+// Responses are transparently gzip-decoded by the transport.
+func newHTTPClient() (*http.Client, error) {
+	timeout := viper.GetDuration("http.timeout")
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	proxy := http.ProxyFromEnvironment
+	if raw := strings.TrimSpace(viper.GetString("http.proxy")); raw != "" {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		proxy = http.ProxyURL(u)
+	}
+
+	tlsCfg, err := tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Timeout: timeout, Transport: newTransport(proxy, tlsCfg)}, nil
+}
+
+func newTransport(proxy func(*http.Request) (*url.URL, error), tlsCfg *tls.Config) *http.Transport {
+	return &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   5 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:       tlsCfg,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          10,
+		MaxIdleConnsPerHost:   4,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		DisableCompression:    false,
+	}
+}
+
+// tlsConfig returns nil when no TLS customisation is configured.
+func tlsConfig() (*tls.Config, error) {
+	caFile := strings.TrimSpace(viper.GetString("http.tls.ca_file"))
+	insecure := viper.GetBool("http.tls.insecure_skip_verify")
+	if caFile == "" && !insecure {
+		return nil, nil
+	}
+	cfg := &tls.Config{InsecureSkipVerify: insecure}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates found in " + caFile)
+		}
+		cfg.RootCAs = pool
+	}
+	return cfg, nil
+}
@@ -0,0 +1,39 @@
+package netclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/sumwatshade/surflog/cmd/i18n"
+)
+
+// Hint suggests what to do about a fetch error, for showing under it in the
+// TUI, or returns "" when there is nothing useful to add.
+func Hint(err error) string {
+	if err == nil {
+		return ""
+	}
+	var cfgErr *ConfigError
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	msg := err.Error()
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ""
+	case errors.As(err, &cfgErr):
+		return i18n.T("fix http.proxy or http.tls.ca_file in your config")
+	case errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()):
+		return i18n.T("timed out: the connection may be slow; try a longer --timeout")
+	case errors.As(err, &dnsErr):
+		return i18n.T("can't reach the server: check your connection or proxy")
+	case errors.As(err, &netErr):
+		return i18n.T("network error: check your connection or proxy")
+	case strings.Contains(msg, "status code: 429"):
+		return i18n.T("rate limited: wait a few minutes before retrying")
+	case strings.Contains(msg, "status code: 5"):
+		return i18n.T("the data server is having trouble; try again shortly")
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+package netclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Progress describes how far a download has got.
+type Progress struct {
+	Label string // what is being fetched, e.g. "46274 Jun 2023"
+	Done  int64  // bytes read so far
+	Total int64  // expected bytes; -1 when the server did not say
+}
+
+// Percent returns how much of the download is done; ok is false when the
+// total is unknown.
+func (p Progress) Percent() (pct int, ok bool) {
+	if p.Total <= 0 {
+		return 0, false
+	}
+	return int(p.Done * 100 / p.Total), true
+}
+
+// String renders e.g. "46274 Jun 2023: 42% of 2.9 MB" or, without a known
+// total, "46274 2023: 1.2 MB".
+func (p Progress) String() string {
+	if pct, ok := p.Percent(); ok {
+		return fmt.Sprintf("%s: %d%% of %s", p.Label, pct, formatBytes(p.Total))
+	}
+	return fmt.Sprintf("%s: %s", p.Label, formatBytes(p.Done))
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.0f kB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+type progressKey struct{}
+
+// WithProgress returns a context whose slow downloads (see TrackBody) report
+// to fn as they advance. fn is called from the downloading goroutine.
+func WithProgress(ctx context.Context, fn func(Progress)) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// progressStep is how many bytes pass between reports when the total is
+// unknown.
+const progressStep = 64 << 10
+
+// TrackBody wraps resp.Body so reads are reported to the context's progress
+// callback, if any, under label. Reports are throttled to whole percents (or
+// every 64 kB without a known total), plus one when the body is exhausted.
+func TrackBody(ctx context.Context, label string, resp *http.Response) {
+	fn, ok := ctx.Value(progressKey{}).(func(Progress))
+	if !ok || fn == nil {
+		return
+	}
+	fn(Progress{Label: label, Total: resp.ContentLength})
+	resp.Body = &progressBody{ReadCloser: resp.Body, fn: fn, p: Progress{Label: label, Total: resp.ContentLength}, last: -1}
+}
+
+type progressBody struct {
+	io.ReadCloser
+	fn   func(Progress)
+	p    Progress
+	last int64 // last reported percent, or byte count without a total
+	done bool  // the final report has been sent
+}
+
+func (b *progressBody) Read(buf []byte) (int, error) {
+	n, err := b.ReadCloser.Read(buf)
+	b.p.Done += int64(n)
+	mark := b.p.Done / progressStep
+	if pct, ok := b.p.Percent(); ok {
+		mark = int64(pct)
+	}
+	if b.done {
+		return n, err
+	}
+	if mark != b.last || err == io.EOF {
+		b.last, b.done = mark, err == io.EOF
+		b.fn(b.p)
+	}
+	return n, err
+}
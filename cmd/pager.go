@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// pagerAnnotation marks commands whose output can run longer than a screen.
+// Their stdout goes through $PAGER when it is a terminal.
+const pagerAnnotation = "surflog/pager"
+
+// pager is the running pager process, if any; closePager waits for it.
+var pager struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+}
+
+// usePager marks commands for paging.
+func usePager(cmds ...*cobra.Command) {
+	for _, c := range cmds {
+		if c.Annotations == nil {
+			c.Annotations = map[string]string{}
+		}
+		c.Annotations[pagerAnnotation] = "true"
+	}
+}
+
+// startPager redirects cmd's output to $PAGER (less by default) when the
+// command is marked for paging, stdout is a terminal and paging is not
+// disabled with --no-pager or `pager.disabled`. If the pager cannot start,
+// output goes to stdout as usual.
+func startPager(cmd *cobra.Command) {
+	if cmd.Annotations[pagerAnnotation] == "" || viper.GetBool("pager.disabled") || !stdoutIsTerminal() {
+		return
+	}
+	args := strings.Fields(os.Getenv("PAGER"))
+	if len(args) == 0 {
+		args = []string{"less"}
+	}
+	if args[0] == "cat" {
+		return
+	}
+	p := exec.Command(args[0], args[1:]...)
+	p.Stdout, p.Stderr = os.Stdout, os.Stderr
+	if os.Getenv("LESS") == "" {
+		// quit when the output fits one screen and keep colors
+		p.Env = append(os.Environ(), "LESS=FRX")
+	}
+	stdin, err := p.StdinPipe()
+	if err != nil {
+		return
+	}
+	if err := p.Start(); err != nil {
+		return
+	}
+	pager.cmd, pager.stdin = p, stdin
+	cmd.SetOut(stdin)
+}
+
+// closePager flushes output to the pager and waits for the user to quit it.
+func closePager() {
+	if pager.cmd == nil {
+		return
+	}
+	_ = pager.stdin.Close()
+	_ = pager.cmd.Wait()
+	pager.cmd = nil
+}
+
+func stdoutIsTerminal() bool { return isTerminal(os.Stdout) }
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+func init() {
+	rootCmd.PersistentFlags().Bool("no-pager", false, "do not pipe long output through $PAGER")
+	cobra.CheckErr(viper.BindPFlag("pager.disabled", rootCmd.PersistentFlags().Lookup("no-pager")))
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) { startPager(cmd) }
+	usePager(listCmd, journalAuthorsCmd, journalFriendsCmd, recapCmd, journalLeaderboardCmd, forecastAccuracyCmd, spotListCmd)
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/sumwatshade/surflog/cmd/netclient"
+)
+
+// progressLine prints download progress on one rewritten stderr line, so
+// slow archive fetches don't look frozen. It stays silent when stderr is not
+// a terminal.
+type progressLine struct {
+	w     io.Writer
+	width int // of the last line printed, to blank it out
+}
+
+func (p *progressLine) report(pr netclient.Progress) {
+	line := "fetching " + pr.String()
+	fmt.Fprintf(p.w, "\r%-*s", p.width, line)
+	p.width = len(line)
+}
+
+// done clears the progress line.
+func (p *progressLine) done() {
+	if p.width > 0 {
+		fmt.Fprintf(p.w, "\r%-*s\r", p.width, "")
+		p.width = 0
+	}
+}
+
+// withProgress returns a context that is cancelled on Ctrl+C and reports
+// download progress to stderr; call stop when the work is done.
+func withProgress(ctx context.Context, stderr io.Writer) (_ context.Context, stop func()) {
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
+	if f, ok := stderr.(*os.File); !ok || !isTerminal(f) {
+		return ctx, cancel
+	}
+	line := &progressLine{w: stderr}
+	return netclient.WithProgress(ctx, line.report), func() {
+		line.done()
+		cancel()
+	}
+}
@@ -0,0 +1,236 @@
+package quiver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+)
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("44"))
+	boardStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
+	selectedStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("159"))
+	faintStyle    = lipgloss.NewStyle().Faint(true).Foreground(lipgloss.Color("245"))
+	warnStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Bold(true)
+)
+
+// Model is the quiver management right-pane view: a list of boards with how
+// often each was ridden, plus add/edit/delete.
+type Model struct {
+	svc      Service
+	boards   []Board
+	rides    map[string]int // sessions per board ID
+	idx      int
+	status   string
+	deleting bool // awaiting y/n for the selected board
+
+	// add/edit form state; form is nil when not editing
+	form    *huh.Form
+	editID  string // "" when adding
+	nameStr string
+	dimStr  string
+	volStr  string
+}
+
+// NewModel loads the default quiver.
+func NewModel() *Model {
+	m := &Model{}
+	if svc, err := NewDefaultService(); err == nil {
+		m.svc = svc
+		m.reload()
+	} else {
+		m.status = i18n.T("Quiver unavailable: %v", err)
+	}
+	return m
+}
+
+func (m *Model) reload() {
+	boards, err := m.svc.List()
+	if err != nil {
+		m.status = i18n.T("Quiver unavailable: %v", err)
+		return
+	}
+	m.boards = boards
+	m.idx = min(m.idx, max(0, len(boards)-1))
+}
+
+// SetRides records how many sessions were ridden on each board ID.
+func (m *Model) SetRides(rides map[string]int) {
+	if m != nil {
+		m.rides = rides
+	}
+}
+
+// Editing reports whether the add/edit form has focus, so global keybindings
+// can step aside while the user types.
+func (m *Model) Editing() bool { return m != nil && m.form != nil }
+
+// Update moves through the boards with ↑/↓ and adds (n), edits (e) or
+// deletes (x, then y) them.
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	if m == nil || m.svc == nil {
+		return nil
+	}
+	if m.form != nil {
+		return m.updateForm(msg)
+	}
+	k, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+	if m.deleting {
+		m.deleting = false
+		if k.String() == "y" && m.idx < len(m.boards) {
+			if err := m.svc.Delete(m.boards[m.idx].ID); err != nil {
+				m.status = i18n.T("Delete failed: %v", err)
+			} else {
+				m.status = i18n.T("Deleted %s", m.boards[m.idx].Name)
+				m.reload()
+			}
+		}
+		return nil
+	}
+	switch k.String() {
+	case "up":
+		if m.idx > 0 {
+			m.idx--
+		}
+	case "down":
+		if m.idx < len(m.boards)-1 {
+			m.idx++
+		}
+	case "n":
+		return m.openForm(Board{})
+	case "e", "enter":
+		if m.idx < len(m.boards) {
+			return m.openForm(m.boards[m.idx])
+		}
+	case "x", "delete":
+		if m.idx < len(m.boards) {
+			m.deleting = true
+		}
+	}
+	return nil
+}
+
+func (m *Model) openForm(b Board) tea.Cmd {
+	m.editID, m.nameStr, m.dimStr, m.volStr = b.ID, b.Name, b.Dimensions, ""
+	if b.VolumeL > 0 {
+		m.volStr = strconv.FormatFloat(b.VolumeL, 'f', -1, 64)
+	}
+	m.status = ""
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().Title(i18n.T("Board name")).Placeholder("Fish").Value(&m.nameStr).Validate(validateName),
+			huh.NewInput().Title(i18n.T("Dimensions (optional)")).Placeholder(`5'8" x 21 x 2 1/2`).Value(&m.dimStr),
+			huh.NewInput().Title(i18n.T("Volume in litres (optional)")).Placeholder("33.5").Value(&m.volStr).Validate(validateVolume),
+		),
+	).WithShowHelp(false)
+	return m.form.Init()
+}
+
+// updateForm drives the add/edit form: esc abandons it, submitting saves.
+func (m *Model) updateForm(msg tea.Msg) tea.Cmd {
+	if k, ok := msg.(tea.KeyMsg); ok && k.String() == "esc" {
+		m.form = nil
+		return nil
+	}
+	updated, cmd := m.form.Update(msg)
+	if f, ok := updated.(*huh.Form); ok {
+		m.form = f
+	}
+	if m.form.State != huh.StateCompleted {
+		return cmd
+	}
+	m.form = nil
+	vol, _ := parseVolume(m.volStr)
+	saved, err := m.svc.Save(Board{ID: m.editID, Name: m.nameStr, Dimensions: m.dimStr, VolumeL: vol})
+	if err != nil {
+		m.status = i18n.T("Save failed: %v", err)
+		return nil
+	}
+	m.reload()
+	for i, b := range m.boards {
+		if b.ID == saved.ID {
+			m.idx = i
+		}
+	}
+	m.status = i18n.T("Saved %s", saved.Name)
+	return nil
+}
+
+func validateName(v string) error {
+	if strings.TrimSpace(v) == "" {
+		return errors.New(i18n.T("name required"))
+	}
+	return nil
+}
+
+// parseVolume reads an optional volume in litres; blank means unknown.
+func parseVolume(v string) (float64, error) {
+	v = strings.TrimSuffix(strings.TrimSpace(strings.ToLower(v)), "l")
+	if v == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil || f < 0 {
+		return 0, errors.New(i18n.T("enter litres, e.g. 33.5"))
+	}
+	return f, nil
+}
+
+func validateVolume(v string) error {
+	_, err := parseVolume(v)
+	return err
+}
+
+// View renders the board list or the open form.
+func (m *Model) View() string {
+	b := &strings.Builder{}
+	if m != nil && m.form != nil {
+		title := i18n.T("New board")
+		if m.editID != "" {
+			title = i18n.T("Edit board")
+		}
+		fmt.Fprintln(b, titleStyle.Render(title))
+		fmt.Fprintln(b)
+		fmt.Fprintln(b, m.form.View())
+		fmt.Fprintln(b, faintStyle.Render(i18n.T("enter to save • esc to cancel")))
+		return b.String()
+	}
+	fmt.Fprintln(b, titleStyle.Render(i18n.T("Quiver")))
+	fmt.Fprintln(b)
+	if m == nil || len(m.boards) == 0 {
+		fmt.Fprintln(b, faintStyle.Render(i18n.T("No boards yet. Press 'n' to add one.")))
+	}
+	if m != nil {
+		for i, board := range m.boards {
+			line := board.String()
+			if n := m.rides[board.ID]; n > 0 {
+				line += " " + faintStyle.Render(i18n.T("· %d sessions", n))
+			}
+			if i == m.idx {
+				fmt.Fprintln(b, selectedStyle.Render("> ")+selectedStyle.Render(line))
+				continue
+			}
+			fmt.Fprintln(b, "  "+boardStyle.Render(line))
+		}
+		if m.deleting && m.idx < len(m.boards) {
+			fmt.Fprintln(b)
+			fmt.Fprintln(b, warnStyle.Render(i18n.T("Delete board '%s'? (y/n)", m.boards[m.idx].Name)))
+		}
+		if m.status != "" {
+			fmt.Fprintln(b)
+			fmt.Fprintln(b, faintStyle.Render(m.status))
+		}
+	}
+	fmt.Fprintln(b)
+	fmt.Fprint(b, faintStyle.Render(i18n.T("n new • e edit • x delete")))
+	return b.String()
+}
@@ -0,0 +1,191 @@
+// Package quiver keeps the user's surfboards so sessions can record which
+// board was ridden.
+package quiver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/sumwatshade/surflog/cmd/config"
+)
+
+// Board is one surfboard in the quiver. Entries refer to it by ID, so it can
+// be renamed without losing its sessions.
+type Board struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Dimensions string  `json:"dimensions,omitempty"` // free-form, e.g. 6'2" x 19 1/4 x 2 1/2
+	VolumeL    float64 `json:"volume_l,omitempty"`   // litres; 0 = unknown
+}
+
+// String renders the board for lists, e.g. `Fish (5'8" x 21, 33.5L)`.
+func (b Board) String() string {
+	var details []string
+	if d := strings.TrimSpace(b.Dimensions); d != "" {
+		details = append(details, d)
+	}
+	if b.VolumeL > 0 {
+		details = append(details, fmt.Sprintf("%.1fL", b.VolumeL))
+	}
+	if len(details) == 0 {
+		return b.Name
+	}
+	return b.Name + " (" + strings.Join(details, ", ") + ")"
+}
+
+// Service defines persistence operations for boards.
+type Service interface {
+	List() ([]Board, error)
+	Get(id string) (Board, error)
+	// Save writes b, assigning an ID to new boards, and returns it.
+	Save(b Board) (Board, error)
+	Delete(id string) error
+}
+
+var _ Service = (*fileService)(nil)
+
+// fileService stores each board as <id>.json under baseDir.
+type fileService struct {
+	baseDir string
+}
+
+// NewFileService creates a board service rooted at dir (created if missing).
+func NewFileService(dir string) (Service, error) {
+	if dir == "" {
+		return nil, errors.New("empty quiver dir")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &fileService{baseDir: dir}, nil
+}
+
+// NewDefaultService returns the board service rooted at `quiver.dir`
+// (~/.surflog/quiver by default).
+func NewDefaultService() (Service, error) {
+	return NewFileService(config.QuiverDir())
+}
+
+func (s *fileService) path(id string) string {
+	return filepath.Join(s.baseDir, filepath.Base(id)+".json")
+}
+
+// List loads all boards sorted by name (best-effort; skips corrupt files).
+func (s *fileService) List() ([]Board, error) {
+	dir, err := os.ReadDir(s.baseDir)
+	if err != nil {
+		return nil, err
+	}
+	var out []Board
+	for _, de := range dir {
+		if de.IsDir() || !strings.HasSuffix(de.Name(), ".json") {
+			continue
+		}
+		b, err := s.Get(strings.TrimSuffix(de.Name(), ".json"))
+		if err != nil {
+			continue
+		}
+		out = append(out, b)
+	}
+	sort.Slice(out, func(i, j int) bool { return strings.ToLower(out[i].Name) < strings.ToLower(out[j].Name) })
+	return out, nil
+}
+
+func (s *fileService) Get(id string) (Board, error) {
+	if strings.TrimSpace(id) == "" {
+		return Board{}, errors.New("empty board id")
+	}
+	data, err := os.ReadFile(s.path(id))
+	if err != nil {
+		return Board{}, err
+	}
+	var b Board
+	if err := json.Unmarshal(data, &b); err != nil {
+		return Board{}, err
+	}
+	if b.ID == "" || b.Name == "" {
+		return Board{}, fmt.Errorf("board %s: missing id or name", id)
+	}
+	return b, nil
+}
+
+func (s *fileService) Save(b Board) (Board, error) {
+	b.Name = strings.TrimSpace(b.Name)
+	b.Dimensions = strings.TrimSpace(b.Dimensions)
+	if b.Name == "" {
+		return Board{}, errors.New("board name required")
+	}
+	if b.VolumeL < 0 {
+		return Board{}, errors.New("volume must not be negative")
+	}
+	if b.ID == "" {
+		b.ID = uuid.NewString()
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return Board{}, err
+	}
+	tmp := s.path(b.ID) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return Board{}, err
+	}
+	if err := os.Rename(tmp, s.path(b.ID)); err != nil {
+		return Board{}, err
+	}
+	forget()
+	return b, nil
+}
+
+func (s *fileService) Delete(id string) error {
+	if strings.TrimSpace(id) == "" {
+		return errors.New("empty board id")
+	}
+	if err := os.Remove(s.path(id)); err != nil {
+		return err
+	}
+	forget()
+	return nil
+}
+
+// names caches board names by ID since views ask on every render.
+var names struct {
+	sync.Mutex
+	loaded bool
+	byID   map[string]string
+}
+
+// forget drops the cached names after the quiver changes.
+func forget() {
+	names.Lock()
+	names.loaded = false
+	names.Unlock()
+}
+
+// Name returns the name of the board with id from the default quiver, or ""
+// when there is none (e.g. it was deleted).
+func Name(id string) string {
+	if id == "" {
+		return ""
+	}
+	names.Lock()
+	defer names.Unlock()
+	if !names.loaded {
+		names.loaded = true
+		names.byID = map[string]string{}
+		if svc, err := NewDefaultService(); err == nil {
+			if boards, err := svc.List(); err == nil {
+				for _, b := range boards {
+					names.byID[b.ID] = b.Name
+				}
+			}
+		}
+	}
+	return names.byID[id]
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/recap"
+	"github.com/sumwatshade/surflog/cmd/spots"
+)
+
+var recapCmd = &cobra.Command{
+	Use:   "recap",
+	Short: "Print a shareable monthly recap (defaults to last month)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		month := recap.PreviousMonth(time.Now())
+		if s, _ := cmd.Flags().GetString("month"); s != "" {
+			t, err := time.ParseInLocation("2006-01", s, time.Local)
+			if err != nil {
+				return fmt.Errorf("invalid --month %q (want YYYY-MM)", s)
+			}
+			month = t
+		}
+		entries, err := loadEntries()
+		if err != nil {
+			return err
+		}
+		r := recap.Build(entries, month)
+		snippet := r.Snippet(spots.DefaultRedactor())
+		if export, _ := cmd.Flags().GetBool("export"); export {
+			path, err := recap.Export(filepath.Join(config.JournalDir(), "recaps"), r, snippet)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.ErrOrStderr(), "saved", path)
+		}
+		fmt.Fprint(cmd.OutOrStdout(), snippet)
+		return nil
+	},
+}
+
+var journalLeaderboardCmd = &cobra.Command{
+	Use:   "leaderboard",
+	Short: "Rank your most-surfed spots",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := loadEntries()
+		if err != nil {
+			return err
+		}
+		limit, _ := cmd.Flags().GetInt("limit")
+		var red *spots.Redactor
+		if share, _ := cmd.Flags().GetBool("share"); share {
+			red = spots.DefaultRedactor()
+		}
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 2, 2, ' ', 0)
+		fmt.Fprintln(w, "#\tSPOT\tSESSIONS\tTIME")
+		for i, sc := range recap.Leaderboard(entries) {
+			if limit > 0 && i >= limit {
+				break
+			}
+			fmt.Fprintf(w, "%d\t%s\t%d\t%s\n", i+1, red.Name(sc.Spot), sc.Sessions, create.FormatDuration(sc.Minutes))
+		}
+		return w.Flush()
+	},
+}
+
+func init() {
+	recapCmd.Flags().String("month", "", "month to recap as YYYY-MM (default last month)")
+	recapCmd.Flags().Bool("export", false, "also save the snippet under <journal.dir>/recaps")
+	journalLeaderboardCmd.Flags().Int("limit", 10, "number of spots to show (0 for all)")
+	journalLeaderboardCmd.Flags().Bool("share", false, "replace private spot names with their aliases")
+	journalCmd.AddCommand(journalLeaderboardCmd)
+	rootCmd.AddCommand(recapCmd)
+}
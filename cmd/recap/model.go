@@ -0,0 +1,86 @@
+package recap
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/spots"
+)
+
+var (
+	cardStyle  = lipgloss.NewStyle().Padding(0, 1).Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("44"))
+	titleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("51"))
+	infoStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
+	goodStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("159")).Bold(true)
+	faintStyle = lipgloss.NewStyle().Faint(true).Foreground(lipgloss.Color("245"))
+	errStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("203"))
+)
+
+// Model is the monthly recap card shown on the first launch of a month.
+type Model struct {
+	recap     Recap
+	exportDir string
+	status    string
+	err       error
+}
+
+// NewModel wraps a recap; snippets are exported under exportDir.
+func NewModel(r Recap, exportDir string) *Model {
+	return &Model{recap: r, exportDir: exportDir}
+}
+
+// Update handles the export key. It reports true when the card is dismissed.
+func (m *Model) Update(msg tea.Msg) bool {
+	k, ok := msg.(tea.KeyMsg)
+	if m == nil || !ok {
+		return false
+	}
+	switch k.String() {
+	case "e":
+		path, err := Export(m.exportDir, m.recap, m.recap.Snippet(spots.DefaultRedactor()))
+		m.err = err
+		if err == nil {
+			m.status = path
+		}
+	case "enter", "esc":
+		return true
+	}
+	return false
+}
+
+// View renders the recap card.
+func (m *Model) View() string {
+	if m == nil {
+		return ""
+	}
+	r := m.recap
+	b := &strings.Builder{}
+	fmt.Fprintln(b, titleStyle.Render(i18n.T("Your recap for %s", r.Month.Format("2006-01"))))
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, infoStyle.Render(i18n.T("Sessions: %d", r.Sessions)))
+	if r.Minutes > 0 {
+		fmt.Fprintln(b, infoStyle.Render(i18n.T("Time in the water: %s", create.FormatDuration(r.Minutes))))
+	}
+	if rate, ok := r.WavesPerHour(); ok {
+		fmt.Fprintln(b, infoStyle.Render(i18n.T("Waves caught: %d (%.1f/hour)", r.Waves, rate)))
+	}
+	if r.TopSpot != "" {
+		fmt.Fprintln(b, goodStyle.Render(i18n.T("Top spot: %s", r.TopSpot)))
+	}
+	if !r.BestDay.IsZero() {
+		fmt.Fprintln(b, infoStyle.Render(i18n.T("Best day: %s, %s at %s", i18n.Date(r.BestDay), create.HeightLabel(r.BestWave), r.BestSpot)))
+	}
+	fmt.Fprintln(b)
+	switch {
+	case m.err != nil:
+		fmt.Fprintln(b, errStyle.Render(i18n.T("Export failed: %v", m.err)))
+	case m.status != "":
+		fmt.Fprintln(b, goodStyle.Render(i18n.T("Saved snippet to %s", m.status)))
+	}
+	fmt.Fprint(b, faintStyle.Render(i18n.T("e export snippet • enter/esc dismiss")))
+	return cardStyle.Render(b.String())
+}
@@ -0,0 +1,155 @@
+package recap
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/spots"
+)
+
+// SpotCount is one leaderboard row.
+type SpotCount struct {
+	Spot     string
+	Sessions int
+	Minutes  int
+}
+
+// Recap summarises one calendar month of sessions.
+type Recap struct {
+	Month    time.Time // first day of the month (local time)
+	Sessions int
+	Minutes  int
+	TopSpot  string
+	BestDay  time.Time // zero when there were no sessions
+	BestSpot string    // spot surfed on BestDay
+	BestWave string    // perceived height on BestDay
+	// Waves and WaveMinutes cover only sessions logged with both a wave
+	// count and a duration, so the rate is not diluted by untimed sessions.
+	Waves       int
+	WaveMinutes int
+}
+
+// WavesPerHour returns the month's catch rate; ok is false without any
+// counted, timed sessions.
+func (r Recap) WavesPerHour() (float64, bool) {
+	return create.WavesPerHour(r.Waves, r.WaveMinutes)
+}
+
+// Empty reports whether the month had no sessions.
+func (r Recap) Empty() bool { return r.Sessions == 0 }
+
+// MonthStart returns the first instant of t's month in t's location.
+func MonthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// sessionTime falls back to CreatedAt for entries without a session time.
+func sessionTime(e create.Entry) time.Time {
+	if !e.SessionAt.IsZero() {
+		return e.SessionAt
+	}
+	t, _ := time.Parse(time.RFC3339, strings.TrimSpace(e.CreatedAt))
+	return t
+}
+
+// heightRank orders perceived heights; unknown values rank lowest.
+func heightRank(h string) int {
+	for i, opt := range create.HeightOptions {
+		if strings.EqualFold(opt, h) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Leaderboard ranks spots by session count (then time in the water) over the
+// given entries.
+func Leaderboard(entries []create.Entry) []SpotCount {
+	by := map[string]*SpotCount{}
+	for _, e := range entries {
+		name := strings.TrimSpace(e.Spot)
+		if name == "" {
+			continue
+		}
+		k := strings.ToLower(name)
+		sc := by[k]
+		if sc == nil {
+			sc = &SpotCount{Spot: name}
+			by[k] = sc
+		}
+		sc.Sessions++
+		sc.Minutes += e.DurationMin
+	}
+	out := make([]SpotCount, 0, len(by))
+	for _, sc := range by {
+		out = append(out, *sc)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Sessions != out[j].Sessions {
+			return out[i].Sessions > out[j].Sessions
+		}
+		if out[i].Minutes != out[j].Minutes {
+			return out[i].Minutes > out[j].Minutes
+		}
+		return out[i].Spot < out[j].Spot
+	})
+	return out
+}
+
+// Build summarises the month containing month. The best day is the one with
+// the biggest perceived waves, then the most time in the water.
+func Build(entries []create.Entry, month time.Time) Recap {
+	start := MonthStart(month)
+	end := start.AddDate(0, 1, 0)
+	r := Recap{Month: start}
+	var inMonth []create.Entry
+	bestRank, bestMin := -2, -1
+	for _, e := range entries {
+		at := sessionTime(e).In(start.Location())
+		if at.Before(start) || !at.Before(end) {
+			continue
+		}
+		inMonth = append(inMonth, e)
+		r.Sessions++
+		r.Minutes += e.DurationMin
+		if e.WaveCount > 0 && e.DurationMin > 0 {
+			r.Waves += e.WaveCount
+			r.WaveMinutes += e.DurationMin
+		}
+		rank := heightRank(e.WaveHeight)
+		if rank > bestRank || (rank == bestRank && e.DurationMin > bestMin) {
+			bestRank, bestMin = rank, e.DurationMin
+			r.BestDay = time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+			r.BestSpot = e.Spot
+			r.BestWave = e.WaveHeight
+		}
+	}
+	if lb := Leaderboard(inMonth); len(lb) > 0 {
+		r.TopSpot = lb[0].Spot
+	}
+	return r
+}
+
+// Snippet renders the recap as shareable Markdown, with private spots
+// replaced by their aliases.
+func (r Recap) Snippet(red *spots.Redactor) string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "## Surf recap: %s\n\n", r.Month.Format("January 2006"))
+	fmt.Fprintf(b, "- Sessions: %d\n", r.Sessions)
+	if r.Minutes > 0 {
+		fmt.Fprintf(b, "- Time in the water: %s\n", create.FormatDuration(r.Minutes))
+	}
+	if rate, ok := r.WavesPerHour(); ok {
+		fmt.Fprintf(b, "- Waves caught: %d (%.1f/hour)\n", r.Waves, rate)
+	}
+	if r.TopSpot != "" {
+		fmt.Fprintf(b, "- Top spot: %s\n", red.Name(r.TopSpot))
+	}
+	if !r.BestDay.IsZero() {
+		fmt.Fprintf(b, "- Best day: %s, %s at %s\n", r.BestDay.Format("Jan 2"), create.HeightLabel(r.BestWave), red.Name(r.BestSpot))
+	}
+	return b.String()
+}
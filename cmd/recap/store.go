@@ -0,0 +1,75 @@
+package recap
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Store remembers which month's recap was last shown so the card appears only
+// on the first launch of a new month.
+type Store struct {
+	path string
+}
+
+type storeState struct {
+	LastShown string `json:"last_shown"` // YYYY-MM of the recapped month
+}
+
+// NewStore creates a recap store under dir (created if missing).
+func NewStore(dir string) (*Store, error) {
+	if dir == "" {
+		return nil, errors.New("empty state dir")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Store{path: filepath.Join(dir, "recap.json")}, nil
+}
+
+func (s *Store) load() (storeState, error) {
+	var st storeState
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return st, err
+	}
+	return st, json.Unmarshal(b, &st)
+}
+
+// Due reports whether last month's recap has not been shown yet.
+func (s *Store) Due(now time.Time) bool {
+	st, err := s.load()
+	if err != nil {
+		return false
+	}
+	return st.LastShown != PreviousMonth(now).Format("2006-01")
+}
+
+// MarkShown records that last month's recap has been shown.
+func (s *Store) MarkShown(now time.Time) error {
+	data, err := json.MarshalIndent(storeState{LastShown: PreviousMonth(now).Format("2006-01")}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o644)
+}
+
+// PreviousMonth returns the first day of the month before now.
+func PreviousMonth(now time.Time) time.Time {
+	return MonthStart(now).AddDate(0, -1, 0)
+}
+
+// Export writes the snippet to dir/YYYY-MM.md and returns the path.
+func Export(dir string, r Recap, snippet string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, r.Month.Format("2006-01")+".md")
+	return path, os.WriteFile(path, []byte(snippet), 0o644)
+}
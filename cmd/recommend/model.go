@@ -0,0 +1,272 @@
+package recommend
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/forecast"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/netclient"
+	"github.com/sumwatshade/surflog/cmd/spots"
+	"github.com/sumwatshade/surflog/cmd/units"
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("44"))
+	faintStyle = lipgloss.NewStyle().Faint(true).Foreground(lipgloss.Color("245"))
+	errStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("203"))
+	goodStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("159")).Bold(true)
+	infoStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
+)
+
+// maxBets caps how many recommendations are listed.
+const maxBets = 10
+
+// defaultForecastRefresh is how often loaded forecasts are re-fetched
+// (`refresh.forecasts`); models only update a few times a day.
+const defaultForecastRefresh = 6 * time.Hour
+
+// Model is the "best bets" right-pane view.
+type Model struct {
+	svc     forecast.Service
+	spotSvc spots.Service
+	prefs   Preferences
+	bets    []Bet
+	err     error
+	loading bool
+	loaded  bool
+	ctx     context.Context // from the last Load, reused for background refreshes
+	gen     int             // load generation; stale refresh ticks are dropped
+}
+
+// refreshMsg fires when loaded forecasts are due to be re-fetched.
+type refreshMsg struct{ gen int }
+
+// forecastsMsg carries fetched forecasts keyed by spot name.
+type forecastsMsg struct {
+	forecasts map[string]forecast.Forecast
+	err       error
+}
+
+// NewModel builds the view, learning preferences from existing entries.
+func NewModel(entries []create.Entry) *Model {
+	m := &Model{svc: forecast.NewService(), prefs: Learn(entries)}
+	if svc, err := spots.NewDefaultService(); err == nil {
+		m.spotSvc = svc
+	}
+	return m
+}
+
+// SetEntries re-learns preferences (e.g. after a new entry is saved).
+func (m *Model) SetEntries(entries []create.Entry) {
+	if m == nil {
+		return
+	}
+	m.prefs = Learn(entries)
+	m.loaded = false
+}
+
+// Load fetches forecasts for all spots with coordinates (or the home location
+// when none are set up) unless already loaded.
+func (m *Model) Load(ctx context.Context) tea.Cmd {
+	if m == nil || m.loading || m.loaded {
+		return nil
+	}
+	m.loading = true
+	m.ctx = ctx
+	m.gen++
+	targets := m.targets()
+	svc := m.svc
+	return func() tea.Msg {
+		out, err := fetchAll(ctx, svc, targets, 3)
+		return forecastsMsg{forecasts: out, err: err}
+	}
+}
+
+// RetryKey is the key the TUI binds to Retry.
+const RetryKey = "F"
+
+// Retry re-fetches forecasts now, e.g. after an error.
+func (m *Model) Retry(ctx context.Context) tea.Cmd {
+	if m == nil || m.loading {
+		return nil
+	}
+	m.loaded = false
+	return m.Load(ctx)
+}
+
+// Record fetches forecasts for every target and archives them for accuracy
+// tracking, returning how many were fetched. Used by `surflog forecast snapshot`.
+func (m *Model) Record(ctx context.Context) (int, error) {
+	out, err := fetchAll(ctx, m.svc, m.targets(), 3)
+	return len(out), err
+}
+
+// Fetch returns days of forecasts for the spots the best bets cover, keyed by
+// spot name, for other views that plan further ahead (archiving them too).
+func (m *Model) Fetch(ctx context.Context, days int) (map[string]forecast.Forecast, error) {
+	return fetchAll(ctx, m.svc, m.targets(), days)
+}
+
+// fetchAll fetches days of forecast per target, archiving a snapshot of each
+// one that has a buoy station to score against. The error is only set when
+// every fetch failed.
+func fetchAll(ctx context.Context, svc forecast.Service, targets map[string]target, days int) (map[string]forecast.Forecast, error) {
+	out := map[string]forecast.Forecast{}
+	var lastErr error
+	archive, _ := forecast.NewArchive(config.StateDir())
+	for name, t := range targets {
+		fc, err := svc.Get(ctx, t.lat, t.lon, days)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		out[name] = fc
+		// archiving is best effort
+		if archive != nil && t.station != "" {
+			_, _ = archive.Save(forecast.Snapshot{Provider: svc.Name(), Station: t.station, Label: name, IssuedAt: time.Now(), Points: fc.Points})
+		}
+	}
+	if len(out) == 0 {
+		return nil, lastErr
+	}
+	return out, nil
+}
+
+// target is a forecast location plus the buoy station its forecasts are
+// scored against (empty when the spot has no station of its own).
+type target struct {
+	lat, lon float64
+	station  string
+}
+
+func (m *Model) targets() map[string]target {
+	t := map[string]target{}
+	if m.spotSvc != nil {
+		if list, err := m.spotSvc.List(); err == nil {
+			for _, sp := range list {
+				if sp.HasCoords() {
+					t[sp.Name] = target{sp.Lat, sp.Lon, sp.Station}
+				}
+			}
+		}
+	}
+	if len(t) == 0 {
+		lat, lon := config.Location()
+		t[i18n.T("Home")] = target{lat, lon, buoy.ConfiguredBuoyStation()}
+	}
+	return t
+}
+
+// Travel returns the estimated minutes from home to each spot that has an
+// estimate, or nil without saved spots.
+func (m *Model) Travel() map[string]int {
+	if m == nil || m.spotSvc == nil {
+		return nil
+	}
+	list, err := m.spotSvc.List()
+	if err != nil {
+		return nil
+	}
+	travel := map[string]int{}
+	for _, sp := range list {
+		if mins, ok := spots.TravelMinutes(sp); ok {
+			travel[sp.Name] = mins
+		}
+	}
+	return travel
+}
+
+// Update handles forecast results.
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	if m == nil {
+		return nil
+	}
+	switch msg := msg.(type) {
+	case forecastsMsg:
+		m.loading = false
+		m.loaded = true
+		next := m.scheduleRefresh()
+		if msg.err != nil && len(m.bets) > 0 {
+			return next // keep showing the last good forecast
+		}
+		m.err = msg.err
+		m.bets = Rank(msg.forecasts, m.prefs, m.Travel(), time.Local)
+		return next
+	case refreshMsg:
+		if msg.gen != m.gen || m.loading {
+			return nil
+		}
+		if config.Metered() {
+			return m.scheduleRefresh()
+		}
+		m.loaded = false
+		return m.Load(m.ctx)
+	}
+	return nil
+}
+
+func (m *Model) scheduleRefresh() tea.Cmd {
+	gen := m.gen
+	return tea.Tick(config.RefreshInterval("forecasts", defaultForecastRefresh), func(time.Time) tea.Msg {
+		return refreshMsg{gen: gen}
+	})
+}
+
+// View renders the ranked list.
+func (m *Model) View() string {
+	b := &strings.Builder{}
+	fmt.Fprintln(b, titleStyle.Render(i18n.T("Best Bets")))
+	if m == nil {
+		return b.String()
+	}
+	switch {
+	case m.err != nil && m.loading:
+		fmt.Fprintln(b, faintStyle.Render(i18n.T("retrying...")))
+		return b.String()
+	case m.err != nil:
+		fmt.Fprintln(b, errStyle.Render(i18n.T("Forecast error: %s", m.err.Error())))
+		if hint := netclient.Hint(m.err); hint != "" {
+			fmt.Fprintln(b, faintStyle.Render(hint))
+		}
+		fmt.Fprintln(b, faintStyle.Render(i18n.T("press %s to retry", RetryKey)))
+		return b.String()
+	case (m.loading || !m.loaded) && len(m.bets) == 0:
+		fmt.Fprintln(b, faintStyle.Render(i18n.T("Loading...")))
+		return b.String()
+	case len(m.bets) == 0:
+		fmt.Fprintln(b, faintStyle.Render(i18n.T("No forecast windows available.")))
+		return b.String()
+	}
+	fmt.Fprintln(b)
+	travel := false
+	for i, bet := range m.bets {
+		if i == maxBets {
+			break
+		}
+		score := fmt.Sprintf("%d/10", bet.Score)
+		if bet.Score >= 7 {
+			score = goodStyle.Render(score)
+		}
+		line := fmt.Sprintf("%s %s: %s %s", bet.Day.Format("Mon"), bet.Window.Name, bet.Spot, score)
+		info := i18n.T("%s @ %.0fs", units.Height(bet.SwellHeight), bet.SwellPeriod)
+		if bet.TravelMin > 0 {
+			info += " · " + TravelLabel(bet.TravelMin)
+			travel = true
+		}
+		fmt.Fprintln(b, line+"  "+infoStyle.Render(info))
+	}
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, faintStyle.Render(i18n.T("Scored against the conditions you usually log.")))
+	if travel {
+		fmt.Fprintln(b, faintStyle.Render(i18n.T("Ranked a point lower per %d min of travel.", MinutesPerPoint())))
+	}
+	return b.String()
+}
@@ -0,0 +1,96 @@
+package recommend
+
+import (
+	"strings"
+
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/direction"
+)
+
+// Preference captures the conditions a surfer actually goes out in, learned
+// from the buoy snapshots attached to their journal entries.
+type Preference struct {
+	SwellHeight float64 // mean primary swell height (m)
+	SwellPeriod float64 // mean primary swell period (s)
+	// SwellDirection is the circular mean swell direction (degrees true);
+	// only meaningful when HasDirection.
+	SwellDirection float64
+	HasDirection   bool
+	Sessions       int
+}
+
+// Preferences holds per-spot preferences (keyed by lower-cased spot name) plus
+// an overall fallback for spots without history.
+type Preferences struct {
+	Overall Preference
+	BySpot  map[string]Preference
+}
+
+// defaultPreference is used before any sessions with conditions are logged.
+var defaultPreference = Preference{SwellHeight: 1.5, SwellPeriod: 12}
+
+// Learn derives preferences from journal entries with recorded conditions.
+func Learn(entries []create.Entry) Preferences {
+	type acc struct {
+		h, p float64
+		dirs []float64
+		n    int
+	}
+	overall := acc{}
+	per := map[string]*acc{}
+	for _, e := range entries {
+		ws := e.WaveSummary
+		if ws.IsZero() || ws.SwellPeriod() <= 0 {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(e.Spot))
+		a := per[key]
+		if a == nil {
+			a = &acc{}
+			per[key] = a
+		}
+		for _, x := range []*acc{a, &overall} {
+			x.h += ws.SwellHeight()
+			x.p += ws.SwellPeriod()
+			if d, ok := ws.SwellDirectionDeg(); ok {
+				x.dirs = append(x.dirs, d)
+			}
+			x.n++
+		}
+	}
+	prefs := Preferences{Overall: defaultPreference, BySpot: map[string]Preference{}}
+	mean := func(a acc) Preference {
+		p := Preference{SwellHeight: a.h / float64(a.n), SwellPeriod: a.p / float64(a.n), Sessions: a.n}
+		p.SwellDirection, p.HasDirection = direction.Mean(a.dirs)
+		return p
+	}
+	if overall.n > 0 {
+		prefs.Overall = mean(overall)
+		prefs.Overall.HasDirection = false // a cross-spot mean direction means nothing
+	}
+	for k, a := range per {
+		prefs.BySpot[k] = mean(*a)
+	}
+	return prefs
+}
+
+// For returns the preference for a spot, falling back to the overall one.
+// Spots with only a couple of sessions are blended with the overall preference.
+func (p Preferences) For(spot string) Preference {
+	sp, ok := p.BySpot[strings.ToLower(strings.TrimSpace(spot))]
+	if !ok {
+		return p.Overall
+	}
+	if sp.Sessions >= 3 {
+		return sp
+	}
+	w := float64(sp.Sessions) / 3
+	// direction is spot-specific, so it is never blended with other spots
+	return Preference{
+		SwellHeight:    w*sp.SwellHeight + (1-w)*p.Overall.SwellHeight,
+		SwellPeriod:    w*sp.SwellPeriod + (1-w)*p.Overall.SwellPeriod,
+		SwellDirection: sp.SwellDirection,
+		HasDirection:   sp.HasDirection,
+		Sessions:       sp.Sessions,
+	}
+}
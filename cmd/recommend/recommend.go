@@ -0,0 +1,133 @@
+package recommend
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/direction"
+	"github.com/sumwatshade/surflog/cmd/forecast"
+)
+
+// Window is a named part of the day considered for a session.
+type Window struct {
+	Name      string // "AM" or "PM"
+	StartHour int
+	EndHour   int // exclusive
+}
+
+// Windows are the session slots scored each day (local time).
+var Windows = []Window{{"AM", 6, 10}, {"PM", 15, 19}}
+
+// Bet is a scored session opportunity for a spot.
+type Bet struct {
+	Spot        string
+	Day         time.Time // local midnight
+	Window      Window
+	Score       int // 0-10
+	SwellHeight float64
+	SwellPeriod float64
+	TravelMin   int     // estimated trip from home; 0 when unknown
+	Net         float64 // Score less the travel penalty; bets rank by it
+}
+
+// Score rates forecast conditions against a preference on a 0-10 scale. Height
+// is weighted more than period; both fall off linearly from the preferred value.
+// When the spot has a learned swell direction it takes a share of the weight,
+// falling off to zero 90° away.
+func Score(p forecast.Point, pref Preference) int {
+	hTol := math.Max(pref.SwellHeight, 0.5)
+	hScore := math.Max(0, 1-math.Abs(p.SwellHeight-pref.SwellHeight)/hTol)
+	pScore := math.Max(0, 1-math.Abs(p.SwellPeriod-pref.SwellPeriod)/6)
+	if !pref.HasDirection {
+		return int(math.Round(10 * (0.6*hScore + 0.4*pScore)))
+	}
+	dScore := math.Max(0, 1-direction.Diff(p.SwellDirection, pref.SwellDirection)/90)
+	return int(math.Round(10 * (0.5*hScore + 0.3*pScore + 0.2*dScore)))
+}
+
+// Rank scores every spot/day/window combination and returns them best first,
+// with the travel penalty for each spot's minutes in travel (nil when
+// unknown) taken off so a good close spot beats a great distant one.
+func Rank(forecasts map[string]forecast.Forecast, prefs Preferences, travel map[string]int, loc *time.Location) []Bet {
+	var bets []Bet
+	for spot, fc := range forecasts {
+		pref := prefs.For(spot)
+		type key struct {
+			day time.Time
+			w   int
+		}
+		sums := map[key][3]float64{} // height, period, count
+		dirs := map[key][]float64{}
+		for _, pt := range fc.Points {
+			lt := pt.Time.In(loc)
+			for wi, w := range Windows {
+				if lt.Hour() < w.StartHour || lt.Hour() >= w.EndHour {
+					continue
+				}
+				k := key{time.Date(lt.Year(), lt.Month(), lt.Day(), 0, 0, 0, 0, loc), wi}
+				s := sums[k]
+				s[0] += pt.SwellHeight
+				s[1] += pt.SwellPeriod
+				s[2]++
+				sums[k] = s
+				dirs[k] = append(dirs[k], pt.SwellDirection)
+			}
+		}
+		for k, s := range sums {
+			avg := forecast.Point{SwellHeight: s[0] / s[2], SwellPeriod: s[1] / s[2]}
+			avg.SwellDirection, _ = direction.Mean(dirs[k])
+			score := Score(avg, pref)
+			bets = append(bets, Bet{
+				Spot:        spot,
+				Day:         k.day,
+				Window:      Windows[k.w],
+				Score:       score,
+				SwellHeight: avg.SwellHeight,
+				SwellPeriod: avg.SwellPeriod,
+				TravelMin:   travel[spot],
+				Net:         float64(score) - TravelPenalty(travel[spot]),
+			})
+		}
+	}
+	sort.SliceStable(bets, func(i, j int) bool {
+		if bets[i].Net != bets[j].Net {
+			return bets[i].Net > bets[j].Net
+		}
+		if !bets[i].Day.Equal(bets[j].Day) {
+			return bets[i].Day.Before(bets[j].Day)
+		}
+		if bets[i].Window.StartHour != bets[j].Window.StartHour {
+			return bets[i].Window.StartHour < bets[j].Window.StartHour
+		}
+		return bets[i].Spot < bets[j].Spot // map order would shuffle equal bets
+	})
+	return bets
+}
+
+// defaultMinutesPerPoint is how much travel costs a point of score
+// (`travel.minutes_per_point`).
+const defaultMinutesPerPoint = 30
+
+// MinutesPerPoint returns the configured travel cost of one score point.
+func MinutesPerPoint() int {
+	if n := viper.GetInt("travel.minutes_per_point"); n > 0 {
+		return n
+	}
+	return defaultMinutesPerPoint
+}
+
+// TravelPenalty is the score a trip of minutes costs.
+func TravelPenalty(minutes int) float64 {
+	return float64(minutes) / float64(MinutesPerPoint())
+}
+
+// TravelLabel renders a travel time, e.g. "25min" or "1h40".
+func TravelLabel(minutes int) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%dmin", minutes)
+	}
+	return fmt.Sprintf("%dh%02d", minutes/60, minutes%60)
+}
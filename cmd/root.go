@@ -4,13 +4,21 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/journal"
+	"github.com/sumwatshade/surflog/cmd/netclient"
 )
 
 var cfgFile string
@@ -24,10 +32,31 @@ and log entries about your favorite surf spots/days.`,
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	RunE: func(cmd *cobra.Command, args []string) error {
-		p := tea.NewProgram(initialModel())
-
-		_, err := p.Run()
-
+		if err := netclient.Check(); err != nil {
+			return err
+		}
+		if err := journal.CheckDescription(); err != nil {
+			return err
+		}
+		if err := buoy.ValidateBuoyStation(buoy.ConfiguredBuoyStation()); err != nil {
+			return err
+		}
+		if err := buoy.ValidateTideStation(buoy.ConfiguredTideStation()); err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		p := tea.NewProgram(initialModel(ctx), tea.WithContext(ctx))
+
+		final, err := p.Run()
+		if m, ok := final.(model); ok {
+			m.shutdown()
+		}
+		if errors.Is(err, tea.ErrProgramKilled) && ctx.Err() != nil {
+			// interrupted by a signal; state has been flushed above
+			return nil
+		}
 		return err
 	},
 }
@@ -36,6 +65,7 @@ and log entries about your favorite surf spots/days.`,
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	err := rootCmd.Execute()
+	closePager()
 	if err != nil {
 		os.Exit(1)
 	}
@@ -49,6 +79,18 @@ func init() {
 	// will be global for your application.
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.surflog.yaml)")
+	rootCmd.PersistentFlags().Duration("timeout", 10*time.Second, "network request timeout (e.g. 30s)")
+	rootCmd.PersistentFlags().String("proxy", "", "proxy URL for network requests (default from HTTP(S)_PROXY)")
+	cobra.CheckErr(viper.BindPFlag("http.timeout", rootCmd.PersistentFlags().Lookup("timeout")))
+	rootCmd.PersistentFlags().String("station", "", "NDBC buoy station ID (default 46274)")
+	rootCmd.PersistentFlags().String("tide-station", "", "NOAA tide station ID (default 9410170)")
+	cobra.CheckErr(viper.BindPFlag("http.proxy", rootCmd.PersistentFlags().Lookup("proxy")))
+	cobra.CheckErr(viper.BindPFlag("buoy.station", rootCmd.PersistentFlags().Lookup("station")))
+	cobra.CheckErr(viper.BindPFlag("tide.station", rootCmd.PersistentFlags().Lookup("tide-station")))
+	rootCmd.PersistentFlags().Bool("metered", false, "metered connection: skip background refreshes")
+	cobra.CheckErr(viper.BindPFlag("network.metered", rootCmd.PersistentFlags().Lookup("metered")))
+	rootCmd.PersistentFlags().Bool("low-bandwidth", false, "fetch only the latest buoy rows and tide highs/lows")
+	cobra.CheckErr(viper.BindPFlag("network.low_bandwidth", rootCmd.PersistentFlags().Lookup("low-bandwidth")))
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -57,23 +99,31 @@ func init() {
 
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
+	// Find home directory.
+	home, err := os.UserHomeDir()
+	cobra.CheckErr(err)
+
 	if cfgFile != "" {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
 	} else {
-		// Find home directory.
-		home, err := os.UserHomeDir()
-		cobra.CheckErr(err)
-
 		// Search config in home directory with name ".surflog" (without extension).
 		viper.AddConfigPath(home)
 		viper.SetConfigType("yaml")
 		viper.SetConfigName(".surflog")
-
-		// Provide default journal directory (~/.surflog/journal)
-		viper.SetDefault("journal.dir", filepath.Join(home, ".surflog", "journal"))
 	}
 
+	// Provide default data directories (~/.surflog/journal, ~/.surflog/state,
+	// ~/.surflog/cache, ~/.surflog/quiver)
+	viper.SetDefault("journal.dir", filepath.Join(home, ".surflog", "journal"))
+	viper.SetDefault("state.dir", filepath.Join(home, ".surflog", "state"))
+	viper.SetDefault("cache.dir", filepath.Join(home, ".surflog", "cache"))
+	viper.SetDefault("quiver.dir", filepath.Join(home, ".surflog", "quiver"))
+
+	// Environment overrides use the SURFLOG_ prefix with dots mapped to
+	// underscores, e.g. SURFLOG_HTTP_TIMEOUT=30s.
+	viper.SetEnvPrefix("surflog")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AutomaticEnv() // read in environment variables that match
 
 	// If a config file is found, read it in.
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/sumwatshade/surflog/cmd/bundle"
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/journal"
+	"github.com/sumwatshade/surflog/cmd/spots"
+)
+
+// passphraseEnv supplies the bundle passphrase when --passphrase is not given,
+// keeping it out of shell history.
+const passphraseEnv = "SURFLOG_BUNDLE_PASSPHRASE"
+
+var shareCmd = &cobra.Command{
+	Use:   "share",
+	Short: "Share sessions with friends",
+}
+
+var shareBundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Seal recent entries into an encrypted bundle for a friend",
+	Long: `Writes the selected entries to a passphrase-encrypted bundle a friend can
+load with ` + "`surflog import bundle`" + `. Private spot names are replaced with
+their aliases and attachments are left out.
+
+--entries selects what to share: last:N (most recent sessions) or all. The
+passphrase comes from --passphrase or $` + passphraseEnv + `; send it to your
+friend separately from the file.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pass, err := bundlePassphrase(cmd)
+		if err != nil {
+			return err
+		}
+		entries, err := loadEntries()
+		if err != nil {
+			return err
+		}
+		sel, _ := cmd.Flags().GetString("entries")
+		entries, err = selectEntries(entries, sel)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return errors.New("no entries to share")
+		}
+		red := spots.DefaultRedactor()
+		for i := range entries {
+			e := &entries[i]
+			e.Spot, e.Comments = red.Name(e.Spot), red.Text(e.Comments)
+			e.Attachments = nil
+			if e.Author == "" {
+				e.Author = config.User()
+			}
+		}
+		data, err := bundle.Seal(bundle.Bundle{From: config.User(), CreatedAt: time.Now().UTC().Truncate(time.Second), Entries: entries}, pass)
+		if err != nil {
+			return err
+		}
+		out, _ := cmd.Flags().GetString("output")
+		if out == "" {
+			out = fmt.Sprintf("surflog-%s.bundle", time.Now().Format("2006-01-02"))
+		}
+		if err := os.WriteFile(config.ExpandPath(out), data, 0o600); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "sealed %d entries into %s\n", len(entries), out)
+		return nil
+	},
+}
+
+var importBundleCmd = &cobra.Command{
+	Use:   "bundle <file>",
+	Short: "Import a friend's bundle into the friends journal",
+	Long: `Decrypts a bundle made with ` + "`surflog share bundle`" + ` and stores its entries
+in the friends journal (friends/ inside the journal directory), separate from
+your own sessions. Each entry records who shared it and when. Entries already
+imported are skipped, so re-importing a bundle is harmless.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pass, err := bundlePassphrase(cmd)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(config.ExpandPath(args[0]))
+		if err != nil {
+			return err
+		}
+		b, err := bundle.Open(data, pass)
+		if err != nil {
+			return err
+		}
+		dst, err := journal.NewFileService(config.FriendsDir())
+		if err != nil {
+			return err
+		}
+		now := time.Now().UTC().Truncate(time.Second)
+		var imported, skipped int
+		for _, e := range b.Entries {
+			// the id names the entry file, so anything but a canonical UUID
+			// could write outside the friends journal
+			if id, err := uuid.Parse(e.ID); err != nil || id.String() != e.ID {
+				fmt.Fprintf(cmd.ErrOrStderr(), "! skipping entry with invalid id %q\n", e.ID)
+				continue
+			}
+			if _, err := dst.Get(e.ID); err == nil {
+				skipped++
+				continue
+			}
+			e.SharedBy = &create.Share{From: b.From, SharedAt: b.CreatedAt, ImportedAt: now}
+			e.Attachments = nil
+			if _, err := dst.Import(e); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "! %s: %v\n", e.ID, err)
+				continue
+			}
+			imported++
+		}
+		from := b.From
+		if from == "" {
+			from = "unknown"
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "imported %d entries from %s, skipped %d already imported\n", imported, from, skipped)
+		return nil
+	},
+}
+
+var journalFriendsCmd = &cobra.Command{
+	Use:   "friends",
+	Short: "List sessions imported from friends' bundles",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := journal.NewFileService(config.FriendsDir())
+		if err != nil {
+			return err
+		}
+		entries, err := svc.List()
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "no friends' sessions yet; see `surflog import bundle`")
+			return nil
+		}
+		journal.SortBySession(entries)
+		for _, e := range entries {
+			from := e.Author
+			if e.SharedBy != nil && e.SharedBy.From != "" {
+				from = e.SharedBy.From
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s  %-20s %-10s %s\n", e.SessionAt.Local().Format("2006-01-02 15:04"), e.Spot, e.WaveHeight, from)
+		}
+		return nil
+	},
+}
+
+// selectEntries applies an --entries selector: "all" or "last:N" by session
+// time.
+func selectEntries(entries []create.Entry, sel string) ([]create.Entry, error) {
+	sel = strings.ToLower(strings.TrimSpace(sel))
+	if sel == "all" {
+		return entries, nil
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(sel, "last:"))
+	if !strings.HasPrefix(sel, "last:") || err != nil || n < 1 {
+		return nil, fmt.Errorf("invalid --entries %q (want last:N or all)", sel)
+	}
+	journal.SortBySession(entries)
+	return entries[:min(n, len(entries))], nil
+}
+
+// bundlePassphrase reads --passphrase, falling back to $SURFLOG_BUNDLE_PASSPHRASE.
+func bundlePassphrase(cmd *cobra.Command) (string, error) {
+	pass, _ := cmd.Flags().GetString("passphrase")
+	if pass == "" {
+		pass = os.Getenv(passphraseEnv)
+	}
+	if pass == "" {
+		return "", fmt.Errorf("a passphrase is required (--passphrase or $%s)", passphraseEnv)
+	}
+	return pass, nil
+}
+
+func init() {
+	shareBundleCmd.Flags().String("entries", "last:5", "entries to share: last:N or all")
+	shareBundleCmd.Flags().StringP("output", "o", "", "bundle file to write (default surflog-<date>.bundle)")
+	shareBundleCmd.Flags().String("passphrase", "", "passphrase to encrypt with (default $"+passphraseEnv+")")
+	importBundleCmd.Flags().String("passphrase", "", "passphrase the bundle was sealed with (default $"+passphraseEnv+")")
+	shareCmd.AddCommand(shareBundleCmd)
+	importCmd.AddCommand(importBundleCmd)
+	journalCmd.AddCommand(journalFriendsCmd)
+	rootCmd.AddCommand(shareCmd)
+}
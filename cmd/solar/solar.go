@@ -0,0 +1,116 @@
+package solar
+
+import (
+	"math"
+	"time"
+)
+
+// Standard solar elevation angles (degrees) for rise/set style events.
+const (
+	// Sunrise/sunset: upper limb on the horizon, corrected for refraction.
+	Horizon = -0.833
+	// Civil twilight: first/last light, enough to surf by.
+	Civil = -6.0
+)
+
+const (
+	j2000   = 2451545.0
+	unixJD  = 2440587.5
+	degrees = math.Pi / 180
+)
+
+// Event returns the times on the given local date when the sun crosses the
+// given elevation (degrees) rising and setting, for a location at lat/lon
+// (degrees, east positive). ok is false during polar day/night when the sun
+// never crosses that elevation. Results are in date's location.
+func Event(date time.Time, lat, lon, elevation float64) (rise, set time.Time, ok bool) {
+	rise, set, cosH := crossing(date, lat, lon, elevation)
+	return rise, set, cosH >= -1 && cosH <= 1
+}
+
+// crossing is Event, also returning the cosine of the hour angle: below -1
+// the sun stays above elevation all day, above 1 it stays below.
+func crossing(date time.Time, lat, lon, elevation float64) (rise, set time.Time, cosH float64) {
+	loc := date.Location()
+	noon := time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, loc)
+	jd := float64(noon.Unix())/86400 + unixJD
+	n := math.Round(jd - j2000 + 0.0008)
+
+	// mean solar time and solar anomaly
+	jStar := n - lon/360
+	m := math.Mod(357.5291+0.98560028*jStar, 360)
+	c := 1.9148*math.Sin(m*degrees) + 0.02*math.Sin(2*m*degrees) + 0.0003*math.Sin(3*m*degrees)
+	lambda := math.Mod(m+c+180+102.9372, 360)
+	transit := j2000 + jStar + 0.0053*math.Sin(m*degrees) - 0.0069*math.Sin(2*lambda*degrees)
+
+	sinDec := math.Sin(lambda*degrees) * math.Sin(23.4397*degrees)
+	cosDec := math.Cos(math.Asin(sinDec))
+	cosH = (math.Sin(elevation*degrees) - math.Sin(lat*degrees)*sinDec) / (math.Cos(lat*degrees) * cosDec)
+	if cosH < -1 || cosH > 1 {
+		return time.Time{}, time.Time{}, cosH
+	}
+	h := math.Acos(cosH) / degrees
+	return fromJulian(transit-h/360, loc), fromJulian(transit+h/360, loc), cosH
+}
+
+// Sunrise and Sunset return the standard sunrise/sunset for the date.
+func Sunrise(date time.Time, lat, lon float64) (time.Time, bool) {
+	rise, _, ok := Event(date, lat, lon, Horizon)
+	return rise, ok
+}
+
+func Sunset(date time.Time, lat, lon float64) (time.Time, bool) {
+	_, set, ok := Event(date, lat, lon, Horizon)
+	return set, ok
+}
+
+// Phase is how light it is at a moment.
+type Phase int
+
+const (
+	Night    Phase = iota
+	Twilight       // between first light and sunrise, or sunset and last light
+	Day
+)
+
+// Light is a date's first light, sunrise, sunset and last light (civil
+// twilight). A pair is zero when the sun doesn't cross that elevation that
+// day, near the poles; Phase still classifies times then.
+type Light struct {
+	FirstLight, Sunrise, Sunset, LastLight time.Time
+	sunAllDay, lightAllDay                 bool // above the elevation all day when its pair is zero
+}
+
+// LightOn returns the light times on date's local day at lat/lon.
+func LightOn(date time.Time, lat, lon float64) Light {
+	var l Light
+	var cosH float64
+	l.Sunrise, l.Sunset, cosH = crossing(date, lat, lon, Horizon)
+	l.sunAllDay = cosH < -1
+	l.FirstLight, l.LastLight, cosH = crossing(date, lat, lon, Civil)
+	l.lightAllDay = cosH < -1
+	return l
+}
+
+// Phase classifies t, which should fall on the day l was computed for.
+func (l Light) Phase(t time.Time) Phase {
+	switch {
+	case between(l.Sunrise, l.Sunset, l.sunAllDay, t):
+		return Day
+	case between(l.FirstLight, l.LastLight, l.lightAllDay, t):
+		return Twilight
+	}
+	return Night
+}
+
+func between(rise, set time.Time, allDay bool, t time.Time) bool {
+	if rise.IsZero() {
+		return allDay
+	}
+	return !t.Before(rise) && t.Before(set)
+}
+
+func fromJulian(jd float64, loc *time.Location) time.Time {
+	secs := (jd - unixJD) * 86400
+	return time.Unix(int64(math.Round(secs)), 0).In(loc)
+}
@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/recommend"
+	"github.com/sumwatshade/surflog/cmd/spots"
+)
+
+// spotCmd groups spot management subcommands.
+var spotCmd = &cobra.Command{
+	Use:   "spot",
+	Short: "Manage surf spots and their standing notes",
+}
+
+var spotListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List known spots",
+	Long: `Lists known spots, quickest to reach first when a home location
+(location.lat/location.lon) is configured, with the estimated travel time and
+distance. Travel time is the straight-line distance at travel.speed_kmh (40 by
+default) unless the spot sets its own with surflog spot add --travel.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := spots.NewDefaultService()
+		if err != nil {
+			return err
+		}
+		list, err := svc.List()
+		if err != nil {
+			return err
+		}
+		redactor := spots.NewRedactor(list)
+		spots.ByTravel(list)
+		for _, sp := range list {
+			line := sp.Name
+			if sp.Private {
+				line += fmt.Sprintf(" (private as %q)", redactor.Name(sp.Name))
+			}
+			if mins, ok := spots.TravelMinutes(sp); ok {
+				line += "  ~" + recommend.TravelLabel(mins)
+				if km, ok := spots.DistanceKm(sp); ok {
+					line += fmt.Sprintf(", %.0f km", km)
+				}
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), line)
+		}
+		return nil
+	},
+}
+
+var spotAddCmd = &cobra.Command{
+	Use:   "add <spot>",
+	Short: "Add or update a spot's location and stations",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := spots.NewDefaultService()
+		if err != nil {
+			return err
+		}
+		sp, err := svc.Get(args[0])
+		if err != nil {
+			sp = spots.Spot{Name: args[0]}
+		}
+		if cmd.Flags().Changed("lat") {
+			sp.Lat, _ = cmd.Flags().GetFloat64("lat")
+		}
+		if cmd.Flags().Changed("lon") {
+			sp.Lon, _ = cmd.Flags().GetFloat64("lon")
+		}
+		if cmd.Flags().Changed("station") {
+			sp.Station, _ = cmd.Flags().GetString("station")
+			sp.Station = strings.ToUpper(strings.TrimSpace(sp.Station))
+			if err := buoy.ValidateBuoyStation(sp.Station); sp.Station != "" && err != nil {
+				return err
+			}
+		}
+		if cmd.Flags().Changed("tide-station") {
+			sp.TideStation, _ = cmd.Flags().GetString("tide-station")
+			sp.TideStation = strings.TrimSpace(sp.TideStation)
+			if err := buoy.ValidateTideStation(sp.TideStation); sp.TideStation != "" && err != nil {
+				return err
+			}
+		}
+		if cmd.Flags().Changed("cdip-station") {
+			sp.CDIPStation, _ = cmd.Flags().GetString("cdip-station")
+			sp.CDIPStation = strings.TrimSpace(sp.CDIPStation)
+			if err := buoy.ValidateCDIPStation(sp.CDIPStation); sp.CDIPStation != "" && err != nil {
+				return err
+			}
+		}
+		if cmd.Flags().Changed("travel") {
+			sp.TravelMin, _ = cmd.Flags().GetInt("travel")
+			if sp.TravelMin < 0 {
+				return errors.New("--travel must not be negative")
+			}
+		}
+		if cmd.Flags().Changed("provider") {
+			chosen, _ := cmd.Flags().GetStringToString("provider")
+			for kind, name := range chosen {
+				kind, name = strings.ToLower(strings.TrimSpace(kind)), strings.ToLower(strings.TrimSpace(name))
+				if name == "" || name == "default" {
+					delete(sp.Providers, kind)
+					continue
+				}
+				if err := buoy.ValidateProvider(kind, name); err != nil {
+					return err
+				}
+				if sp.Providers == nil {
+					sp.Providers = map[string]string{}
+				}
+				sp.Providers[kind] = name
+			}
+		}
+		if pick, _ := cmd.Flags().GetBool("pick"); pick {
+			if err := pickStations(cmd, &sp); err != nil {
+				return err
+			}
+		} else if nearest, _ := cmd.Flags().GetBool("nearest"); nearest && (cmd.Flags().Changed("lat") || cmd.Flags().Changed("lon")) {
+			// a convenience: the spot is still saved without stations
+			if err := fillNearestStations(cmd.Context(), cmd.OutOrStdout(), &sp); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "nearest stations: %v\n", err)
+			}
+		}
+		return svc.Save(sp)
+	},
+}
+
+// pickStations lets the user choose the spot's buoy and tide stations on a
+// map around it (or the active location when it has no coordinates). Stations
+// given by flag are left alone, as is either one the user skips.
+func pickStations(cmd *cobra.Command, sp *spots.Spot) error {
+	stations, err := loadStationCatalog(cmd.Context())
+	if err != nil {
+		return err
+	}
+	lat, lon := spots.ActiveLocation()
+	if sp.HasCoords() {
+		lat, lon = sp.Lat, sp.Lon
+	}
+	for _, tide := range []bool{false, true} {
+		if (!tide && cmd.Flags().Changed("station")) || (tide && cmd.Flags().Changed("tide-station")) {
+			continue
+		}
+		current := sp.Station
+		if tide {
+			current = sp.TideStation
+		}
+		picker := buoy.NewStationPicker(stations, tide, lat, lon, current)
+		if _, err := tea.NewProgram(picker, tea.WithAltScreen(), tea.WithContext(cmd.Context())).Run(); err != nil {
+			return err
+		}
+		st, ok := picker.Picked()
+		if !ok {
+			continue
+		}
+		if tide {
+			sp.TideStation = st.ID
+		} else {
+			sp.Station = st.ID
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s: %s %s\n", sp.Name, st.ID, st.Name)
+	}
+	return nil
+}
+
+var spotNotesCmd = &cobra.Command{
+	Use:   "notes <spot> [notes...]",
+	Short: "Show or set standing notes for a spot (hazards, parking, tide quirks)",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := spots.NewDefaultService()
+		if err != nil {
+			return err
+		}
+		sp, err := svc.Get(args[0])
+		if err != nil {
+			sp = spots.Spot{Name: args[0]}
+		}
+		clear, _ := cmd.Flags().GetBool("clear")
+		if len(args) == 1 && !clear {
+			fmt.Fprintln(cmd.OutOrStdout(), sp.Notes)
+			return nil
+		}
+		sp.Notes = strings.Join(args[1:], " ")
+		return svc.Save(sp)
+	},
+}
+
+var spotPrivateCmd = &cobra.Command{
+	Use:   "private <spot>",
+	Short: "Mark a spot private so exports and shares use an alias",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := spots.NewDefaultService()
+		if err != nil {
+			return err
+		}
+		sp, err := svc.Get(args[0])
+		if err != nil {
+			sp = spots.Spot{Name: args[0]}
+		}
+		off, _ := cmd.Flags().GetBool("off")
+		sp.Private = !off
+		if cmd.Flags().Changed("alias") {
+			sp.Alias, _ = cmd.Flags().GetString("alias")
+		}
+		return svc.Save(sp)
+	},
+}
+
+var spotRemoveCmd = &cobra.Command{
+	Use:   "rm <spot>",
+	Short: "Remove a spot",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := spots.NewDefaultService()
+		if err != nil {
+			return err
+		}
+		return svc.Delete(args[0])
+	},
+}
+
+func init() {
+	spotAddCmd.Flags().Float64("lat", 0, "latitude (degrees)")
+	spotAddCmd.Flags().Float64("lon", 0, "longitude (degrees, east positive)")
+	spotAddCmd.Flags().String("station", "", "NDBC buoy station ID for this spot (e.g. 46026)")
+	spotAddCmd.Flags().String("tide-station", "", "NOAA tide station ID for this spot (e.g. 9414290)")
+	spotAddCmd.Flags().String("cdip-station", "", "CDIP buoy station ID for this spot, read with waves=cdip (e.g. 100)")
+	spotAddCmd.Flags().StringToString("provider", nil, `data provider per kind, e.g. waves=cdip (kinds: waves, tides, wind; "default" clears)`)
+	spotAddCmd.Flags().Int("travel", 0, "travel time from home in minutes, instead of the estimate from distance (0 clears)")
+	spotAddCmd.Flags().Bool("pick", false, "choose the buoy and tide stations on a map of nearby stations")
+	spotAddCmd.Flags().Bool("nearest", true, "when setting --lat/--lon, fill in missing stations with the nearest ones")
+	spotNotesCmd.Flags().Bool("clear", false, "remove the spot's notes")
+	spotPrivateCmd.Flags().String("alias", "", "public name to show instead (default \"Secret spot NNNN\", from the name)")
+	spotPrivateCmd.Flags().Bool("off", false, "make the spot public again")
+	spotCmd.AddCommand(spotListCmd, spotAddCmd, spotNotesCmd, spotPrivateCmd, spotRemoveCmd)
+	rootCmd.AddCommand(spotCmd)
+}
@@ -0,0 +1,96 @@
+package spots
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Redactor maps real spot names to their public aliases for output that is
+// shared with others. The local journal always keeps the real name.
+type Redactor struct {
+	aliases map[string]string // lower-cased real name -> alias
+	names   *regexp.Regexp    // any real name, case-insensitively; nil for none
+}
+
+// NewRedactor builds a redactor from the spot list. Private spots without an
+// explicit alias get a generic name derived from the real one (see
+// GenericAlias), so adding or removing spots never renames the others.
+func NewRedactor(list []Spot) *Redactor {
+	r := &Redactor{aliases: map[string]string{}}
+	var reals []string
+	for _, sp := range list {
+		name := strings.TrimSpace(sp.Name)
+		if !sp.Private || name == "" {
+			continue
+		}
+		alias := strings.TrimSpace(sp.Alias)
+		if alias == "" {
+			alias = GenericAlias(name)
+		}
+		r.aliases[strings.ToLower(name)] = alias
+		reals = append(reals, regexp.QuoteMeta(name))
+	}
+	if len(reals) > 0 {
+		// longest first, so "Ocean Beach North" wins over "Ocean Beach"
+		sort.Slice(reals, func(i, j int) bool { return len(reals[i]) > len(reals[j]) })
+		r.names = regexp.MustCompile("(?i)" + strings.Join(reals, "|"))
+	}
+	return r
+}
+
+// GenericAlias is the public name of a private spot without an alias, e.g.
+// "Secret spot 4821". The number comes from the name, so it stays the same
+// however the spot list changes.
+func GenericAlias(name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(strings.TrimSpace(name))))
+	return fmt.Sprintf("Secret spot %04d", h.Sum32()%10000)
+}
+
+// DefaultRedactor loads spots from the configured journal dir. It never fails:
+// with no spot data nothing is redacted.
+func DefaultRedactor() *Redactor {
+	svc, err := NewDefaultService()
+	if err != nil {
+		return NewRedactor(nil)
+	}
+	list, err := svc.List()
+	if err != nil {
+		return NewRedactor(nil)
+	}
+	return NewRedactor(list)
+}
+
+// Name returns the public name for a spot.
+func (r *Redactor) Name(spot string) string {
+	if r == nil {
+		return spot
+	}
+	if alias, ok := r.aliases[strings.ToLower(strings.TrimSpace(spot))]; ok {
+		return alias
+	}
+	return spot
+}
+
+// Text replaces any private spot names appearing in free text (comments). It
+// is a single pass, so an alias that contains a real name ("Reef" shown as
+// "The Reef") is not replaced again.
+func (r *Redactor) Text(s string) string {
+	if r == nil || r.names == nil {
+		return s
+	}
+	return r.names.ReplaceAllStringFunc(s, func(m string) string {
+		if alias, ok := r.aliases[strings.ToLower(m)]; ok {
+			return alias
+		}
+		for real, alias := range r.aliases { // folds ToLower doesn't, e.g. "ſ"
+			if strings.EqualFold(real, m) {
+				return alias
+			}
+		}
+		return m
+	})
+}
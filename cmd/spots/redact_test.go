@@ -0,0 +1,50 @@
+package spots
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRedactorText(t *testing.T) {
+	r := NewRedactor([]Spot{
+		{Name: "Reef", Private: true, Alias: "The Reef"},
+		{Name: "Spot", Private: true},
+		{Name: "Ocean Beach", Private: true, Alias: "Big City Beach"},
+		{Name: "Ocean Beach North", Private: true, Alias: "Up the Coast"},
+		{Name: "Linda Mar"},
+	})
+	tests := []struct{ in, want string }{
+		{"paddled out at reef, REEF was firing", "paddled out at The Reef, The Reef was firing"},
+		{"Spot was empty", GenericAlias("Spot") + " was empty"},
+		{"Ocean Beach North then ocean beach", "Up the Coast then Big City Beach"},
+		{"Linda Mar was flat", "Linda Mar was flat"},
+		{"", ""},
+	}
+	for _, tc := range tests {
+		done := make(chan string, 1)
+		go func() { done <- r.Text(tc.in) }()
+		select {
+		case got := <-done:
+			if got != tc.want {
+				t.Errorf("Text(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Text(%q) did not return", tc.in)
+		}
+	}
+}
+
+func TestGenericAliasStable(t *testing.T) {
+	before := NewRedactor([]Spot{{Name: "Secret Cove", Private: true}})
+	after := NewRedactor([]Spot{{Name: "Another Cove", Private: true}, {Name: "Secret Cove", Private: true}})
+	if a, b := before.Name("Secret Cove"), after.Name("secret cove"); a != b {
+		t.Errorf("alias changed from %q to %q when a spot was added", a, b)
+	}
+	if got := before.Name("Secret Cove"); got == "Secret Cove" {
+		t.Error("private spot was not aliased")
+	}
+	var nilRedactor *Redactor
+	if got := nilRedactor.Text("Secret Cove"); got != "Secret Cove" {
+		t.Errorf("nil redactor changed text to %q", got)
+	}
+}
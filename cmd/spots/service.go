@@ -0,0 +1,149 @@
+package spots
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Spot describes a surf break and any standing notes about it (hazards,
+// parking, tide quirks).
+type Spot struct {
+	Name  string  `json:"name"`
+	Lat   float64 `json:"lat,omitempty"`
+	Lon   float64 `json:"lon,omitempty"`
+	Notes string  `json:"notes,omitempty"`
+	// Private spots are replaced by Alias (or a generic name) in anything that
+	// leaves the machine: exports, dashboards and share snippets.
+	Private bool   `json:"private,omitempty"`
+	Alias   string `json:"alias,omitempty"`
+	// Station and TideStation override the default NDBC buoy and NOAA tide
+	// stations when snapshotting conditions for sessions at this spot.
+	Station     string `json:"station,omitempty"`
+	TideStation string `json:"tide_station,omitempty"`
+	// CDIPStation is the CDIP buoy read when the waves provider is "cdip".
+	CDIPStation string `json:"cdip_station,omitempty"`
+	// Providers overrides the configured data provider per kind ("waves",
+	// "tides", "wind"), e.g. {"waves": "open-meteo"} where no buoy is near.
+	Providers map[string]string `json:"providers,omitempty"`
+	// TravelMin overrides the travel time estimated from the home location,
+	// e.g. for a spot behind a ferry or a long walk in.
+	TravelMin int `json:"travel_min,omitempty"`
+}
+
+// HasCoords reports whether the spot has a location set.
+func (s Spot) HasCoords() bool { return s.Lat != 0 || s.Lon != 0 }
+
+// Service defines persistence operations for spots.
+type Service interface {
+	List() ([]Spot, error)
+	Get(name string) (Spot, error)
+	Save(s Spot) error
+	Delete(name string) error
+}
+
+var _ Service = (*fileService)(nil)
+
+// fileService stores each spot as a JSON file under baseDir, keyed by slug.
+type fileService struct {
+	baseDir string
+}
+
+// NewFileService creates a spot service rooted at dir/spots (created if missing).
+func NewFileService(dir string) (Service, error) {
+	if dir == "" {
+		return nil, errors.New("empty journal dir")
+	}
+	base := filepath.Join(dir, "spots")
+	if err := os.MkdirAll(base, 0o755); err != nil {
+		return nil, err
+	}
+	return &fileService{baseDir: base}, nil
+}
+
+// Slug normalises a spot name for use as a key ("Ocean Beach" -> "ocean-beach").
+func Slug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			dash = false
+		case !dash && b.Len() > 0:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+func (s *fileService) spotPath(name string) string {
+	return filepath.Join(s.baseDir, Slug(name)+".json")
+}
+
+// List loads all spots sorted by name (best-effort; skips corrupt files).
+func (s *fileService) List() ([]Spot, error) {
+	dir, err := os.ReadDir(s.baseDir)
+	if err != nil {
+		return nil, err
+	}
+	var out []Spot
+	for _, de := range dir {
+		if de.IsDir() || !strings.HasSuffix(de.Name(), ".json") {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(s.baseDir, de.Name()))
+		if err != nil {
+			continue
+		}
+		var sp Spot
+		if err := json.Unmarshal(b, &sp); err != nil || sp.Name == "" {
+			continue
+		}
+		out = append(out, sp)
+	}
+	sort.Slice(out, func(i, j int) bool { return strings.ToLower(out[i].Name) < strings.ToLower(out[j].Name) })
+	return out, nil
+}
+
+func (s *fileService) Get(name string) (Spot, error) {
+	if Slug(name) == "" {
+		return Spot{}, errors.New("empty spot name")
+	}
+	b, err := os.ReadFile(s.spotPath(name))
+	if err != nil {
+		return Spot{}, err
+	}
+	var sp Spot
+	if err := json.Unmarshal(b, &sp); err != nil {
+		return Spot{}, err
+	}
+	return sp, nil
+}
+
+func (s *fileService) Save(sp Spot) error {
+	sp.Name = strings.TrimSpace(sp.Name)
+	if Slug(sp.Name) == "" {
+		return errors.New("spot name required")
+	}
+	data, err := json.MarshalIndent(sp, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.spotPath(sp.Name) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.spotPath(sp.Name))
+}
+
+func (s *fileService) Delete(name string) error {
+	if Slug(name) == "" {
+		return errors.New("empty spot name")
+	}
+	return os.Remove(s.spotPath(name))
+}
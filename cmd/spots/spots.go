@@ -0,0 +1,78 @@
+package spots
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/config"
+)
+
+// NewDefaultService returns the spot service rooted in the configured journal dir.
+func NewDefaultService() (Service, error) {
+	return NewFileService(config.JournalDir())
+}
+
+// Active returns the spot named by the `spots.active` config key, if it exists.
+func Active(svc Service) (Spot, bool) {
+	name := strings.TrimSpace(viper.GetString("spots.active"))
+	if svc == nil || name == "" {
+		return Spot{}, false
+	}
+	sp, err := svc.Get(name)
+	if err != nil {
+		return Spot{}, false
+	}
+	return sp, true
+}
+
+// active caches the resolved active spot since views ask on every render.
+var active struct {
+	sync.Mutex
+	resolved bool
+	spot     Spot
+	ok       bool
+}
+
+// resolveActive returns the cached active spot, loading it on first use.
+func resolveActive() (Spot, bool) {
+	active.Lock()
+	defer active.Unlock()
+	if !active.resolved {
+		active.resolved = true
+		if svc, err := NewDefaultService(); err == nil {
+			active.spot, active.ok = Active(svc)
+		}
+	}
+	return active.spot, active.ok
+}
+
+// SetActive switches the active spot for the rest of this run (it does not
+// rewrite the config file). An empty name clears it.
+func SetActive(name string) {
+	viper.Set("spots.active", name)
+	active.Lock()
+	active.resolved = false
+	active.Unlock()
+}
+
+// Current returns the active spot for this run, if any.
+func Current() (Spot, bool) { return resolveActive() }
+
+// ActiveLocation returns the active spot's coordinates when set, otherwise
+// the configured home location.
+func ActiveLocation() (lat, lon float64) {
+	if sp, ok := resolveActive(); ok && sp.HasCoords() {
+		return sp.Lat, sp.Lon
+	}
+	return config.Location()
+}
+
+// ActiveStations returns the active spot's mapped buoy and tide stations;
+// either is empty when the spot has none (meaning the configured default).
+func ActiveStations() (buoyStation, tideStation string) {
+	if sp, ok := resolveActive(); ok {
+		return sp.Station, sp.TideStation
+	}
+	return "", ""
+}
@@ -0,0 +1,55 @@
+package spots
+
+import (
+	"math"
+	"sort"
+
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/swell"
+)
+
+// defaultTravelKmh is the average speed over straight-line distance used to
+// estimate travel time (`travel.speed_kmh`). Roads wind, so it is well under
+// driving speed.
+const defaultTravelKmh = 40
+
+// DistanceKm returns the straight-line distance from the home location to
+// sp; ok is false when either has no coordinates.
+func DistanceKm(sp Spot) (km float64, ok bool) {
+	lat, lon, home := config.Home()
+	if !home || !sp.HasCoords() {
+		return 0, false
+	}
+	return swell.DistanceKm(lat, lon, sp.Lat, sp.Lon), true
+}
+
+// TravelMinutes estimates the trip to sp: its own travel_min when set,
+// otherwise the distance from home at `travel.speed_kmh`.
+func TravelMinutes(sp Spot) (int, bool) {
+	if sp.TravelMin > 0 {
+		return sp.TravelMin, true
+	}
+	km, ok := DistanceKm(sp)
+	if !ok {
+		return 0, false
+	}
+	kmh := viper.GetFloat64("travel.speed_kmh")
+	if kmh <= 0 {
+		kmh = defaultTravelKmh
+	}
+	return int(math.Round(km / kmh * 60)), true
+}
+
+// ByTravel sorts list quickest to reach first. Spots without an estimate
+// follow in their existing order.
+func ByTravel(list []Spot) {
+	sort.SliceStable(list, func(i, j int) bool {
+		a, aok := TravelMinutes(list[i])
+		b, bok := TravelMinutes(list[j])
+		if aok != bok {
+			return aok
+		}
+		return aok && a < b
+	})
+}
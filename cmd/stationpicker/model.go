@@ -0,0 +1,360 @@
+// Package stationpicker is the station browser right-pane view: a searchable
+// list of NDBC buoys and NOAA tide stations with a preview of the selected
+// one's latest reading, from which it can be made the configured station.
+package stationpicker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/layout"
+	"github.com/sumwatshade/surflog/cmd/netclient"
+	"github.com/sumwatshade/surflog/cmd/spots"
+	"github.com/sumwatshade/surflog/cmd/units"
+)
+
+var (
+	titleStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("44"))
+	faintStyle  = lipgloss.NewStyle().Faint(true).Foreground(lipgloss.Color("245"))
+	errStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("203"))
+	infoStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
+	activeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("159")).Bold(true)
+)
+
+// Key is the key the TUI binds to the view. Pressing it again after the
+// station list failed to load retries.
+const Key = "d"
+
+// previewDelay is how long the cursor has to rest on a station before its
+// latest reading is fetched, so scrolling doesn't fire a request per row.
+const previewDelay = 400 * time.Millisecond
+
+// previewLines is the height reserved below the list for the preview.
+const previewLines = 4
+
+// Model is the station browser.
+type Model struct {
+	ctx      context.Context
+	list     list.Model
+	ready    bool
+	loading  bool
+	err      error
+	stations []buoy.StationInfo
+	width    int
+	height   int
+
+	previews   map[string]preview // latest readings by station ID
+	previewFor string             // station the pending preview is for
+	previewSeq int                // drops stale previewDueMsgs
+}
+
+// preview is a station's latest reading, rendered, or why it is missing.
+type preview struct {
+	lines   []string
+	err     error
+	loading bool
+}
+
+// StationSetMsg is sent after a station is saved to the config, so the buoy
+// pane can reload.
+type StationSetMsg struct{ Station buoy.StationInfo }
+
+type catalogMsg struct {
+	stations []buoy.StationInfo
+	err      error
+}
+
+type previewDueMsg struct {
+	id  string
+	seq int
+}
+
+type previewMsg struct {
+	id      string
+	preview preview
+}
+
+// NewModel returns an empty browser; Load fetches the station list.
+func NewModel() *Model {
+	return &Model{ctx: context.Background(), previews: map[string]preview{}}
+}
+
+// Load fetches the station list unless it is loaded or loading. A load that
+// failed is retried.
+func (m *Model) Load(ctx context.Context) tea.Cmd {
+	if m == nil || m.loading || len(m.stations) > 0 {
+		return nil
+	}
+	m.ctx = ctx
+	m.loading = true
+	m.err = nil
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		stations, err := buoy.StationCatalog(ctx)
+		return catalogMsg{stations: stations, err: err}
+	}
+}
+
+// Filtering reports whether the search box has focus, so global keybindings
+// can step aside while the user types.
+func (m *Model) Filtering() bool {
+	return m != nil && m.ready && m.list.FilterState() == list.Filtering
+}
+
+// Update handles the station list, previews and keys: / searches by name or
+// ID and enter makes the selected station the configured buoy or tide
+// station.
+func (m *Model) Update(msg tea.Msg, width, height int) tea.Cmd {
+	if m == nil {
+		return nil
+	}
+	m.ensureList(width, height)
+	switch msg := msg.(type) {
+	case catalogMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err == nil {
+			m.stations = msg.stations
+			if m.ready {
+				return tea.Batch(m.list.SetItems(m.items()), m.schedulePreview())
+			}
+		}
+		return nil
+	case previewDueMsg:
+		if msg.seq != m.previewSeq {
+			return nil
+		}
+		if _, ok := m.previews[msg.id]; ok {
+			return nil
+		}
+		st, ok := m.selected()
+		if !ok || st.ID != msg.id {
+			return nil
+		}
+		m.previews[st.ID] = preview{loading: true}
+		return fetchPreviewCmd(m.ctx, st)
+	case previewMsg:
+		m.previews[msg.id] = msg.preview
+		return nil
+	case tea.KeyMsg:
+		if !m.ready {
+			return nil
+		}
+		if msg.String() == "enter" && m.list.FilterState() != list.Filtering {
+			if st, ok := m.selected(); ok {
+				return m.choose(st)
+			}
+			return nil
+		}
+	}
+	if !m.ready {
+		return nil
+	}
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return tea.Batch(cmd, m.schedulePreview())
+}
+
+// ensureList creates or resizes the list to fit the pane above the preview.
+func (m *Model) ensureList(width, height int) {
+	if width == 0 || height == 0 {
+		return
+	}
+	m.width, m.height = width, height
+	listHeight := max(5, height-6-previewLines-1)
+	if m.ready {
+		m.list.SetSize(width-4, listHeight)
+		return
+	}
+	d := list.NewDefaultDelegate()
+	d.Styles.SelectedTitle = d.Styles.SelectedTitle.Foreground(lipgloss.Color("159")).BorderForeground(lipgloss.Color("44"))
+	d.Styles.SelectedDesc = d.Styles.SelectedDesc.Foreground(lipgloss.Color("246")).BorderForeground(lipgloss.Color("44"))
+	l := list.New(m.items(), d, width-4, listHeight)
+	l.Title = i18n.T("Stations")
+	l.SetShowStatusBar(true)
+	l.SetFilteringEnabled(true)
+	l.Styles.Title = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("159")).Background(lipgloss.Color("24")).Padding(0, 1)
+	l.Styles.PaginationStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	l.Styles.HelpStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("238"))
+	l.SetShowHelp(false) // the app footer has the keys
+	m.list = l
+	m.ready = true
+}
+
+func (m *Model) items() []list.Item {
+	items := make([]list.Item, len(m.stations))
+	for i, st := range m.stations {
+		items[i] = item{st}
+	}
+	return items
+}
+
+func (m *Model) selected() (buoy.StationInfo, bool) {
+	if !m.ready {
+		return buoy.StationInfo{}, false
+	}
+	it, ok := m.list.SelectedItem().(item)
+	return it.StationInfo, ok
+}
+
+// schedulePreview arranges for the selected station's reading to be fetched
+// once the cursor has rested on it.
+func (m *Model) schedulePreview() tea.Cmd {
+	st, ok := m.selected()
+	if !ok || st.ID == m.previewFor {
+		return nil
+	}
+	m.previewFor = st.ID
+	m.previewSeq++
+	msg := previewDueMsg{id: st.ID, seq: m.previewSeq}
+	return tea.Tick(previewDelay, func(time.Time) tea.Msg { return msg })
+}
+
+// choose saves st as the configured station of its kind.
+func (m *Model) choose(st buoy.StationInfo) tea.Cmd {
+	key, kind := "buoy.station", i18n.T("buoy")
+	if st.Tide {
+		key, kind = "tide.station", i18n.T("tide station")
+	}
+	if err := config.Save(key, st.ID); err != nil {
+		return m.list.NewStatusMessage(errStyle.Render(i18n.T("Could not save %s: %v", key, err)))
+	}
+	status := i18n.T("Saved %s %s to %s", kind, st.ID, config.File())
+	if sp, ok := spots.Current(); ok && (st.Tide && sp.TideStation != "" || !st.Tide && sp.Station != "") {
+		status = i18n.T("Saved %s %s; %s keeps its own", kind, st.ID, sp.Name)
+	}
+	return tea.Batch(m.list.NewStatusMessage(activeStyle.Render(status)),
+		func() tea.Msg { return StationSetMsg{Station: st} })
+}
+
+// fetchPreviewCmd reads st's latest observation: waves (or wind, for
+// stations without a wave sensor) for buoys, the level and next turn for
+// tide stations.
+func fetchPreviewCmd(ctx context.Context, st buoy.StationInfo) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+		defer cancel()
+		if st.Tide {
+			return previewMsg{id: st.ID, preview: tidePreview(ctx, st.ID, time.Now())}
+		}
+		return previewMsg{id: st.ID, preview: buoyPreview(ctx, st.ID)}
+	}
+}
+
+func buoyPreview(ctx context.Context, id string) preview {
+	svc := buoy.NewServiceForStations(id, "")
+	var p preview
+	ws, werr := svc.GetWaveSummary(ctx)
+	if werr == nil {
+		p.lines = append(p.lines, i18n.T("Waves %s (%s)", ws.Short(), i18n.DateTime(ws.Time().Local())))
+	}
+	if w, err := svc.GetWindSummary(ctx); err == nil {
+		p.lines = append(p.lines, i18n.T("Wind %s (%s)", w.String(), i18n.DateTime(w.Time().Local())))
+	}
+	if len(p.lines) == 0 {
+		p.err = werr
+	}
+	return p
+}
+
+func tidePreview(ctx context.Context, id string, now time.Time) preview {
+	svc := buoy.NewServiceForStations("", id)
+	td, err := svc.GetTideData(ctx, now.Add(-time.Hour), now.Add(time.Hour))
+	if err != nil {
+		return preview{err: err}
+	}
+	var p preview
+	if lvl, ok := td.LevelAt(now); ok {
+		p.lines = append(p.lines, i18n.T("Predicted %s now", units.Feet(lvl.Height)))
+	}
+	if next := td.Extremes(now, now.Add(24*time.Hour)); len(next) > 0 {
+		n, mark := next[0], "▼"
+		if n.Type == "high" {
+			mark = "▲"
+		}
+		p.lines = append(p.lines, i18n.T("Next %s %s at %s", mark, units.Feet(n.Height), i18n.Time(n.Time.Local())))
+	}
+	return p
+}
+
+// View renders the list with the selected station's preview below it.
+func (m *Model) View() string {
+	if m == nil {
+		return ""
+	}
+	b := &strings.Builder{}
+	if m.err != nil {
+		fmt.Fprintln(b, titleStyle.Render(i18n.T("Stations")))
+		fmt.Fprintln(b, errStyle.Render(layout.Truncate(m.err.Error(), max(20, m.width-4))))
+		if hint := netclient.Hint(m.err); hint != "" {
+			fmt.Fprintln(b, faintStyle.Render(hint))
+		}
+		fmt.Fprint(b, faintStyle.Render(i18n.T("press %s to retry", Key)))
+		return b.String()
+	}
+	if m.loading || !m.ready {
+		fmt.Fprintln(b, titleStyle.Render(i18n.T("Stations")))
+		fmt.Fprint(b, faintStyle.Render(i18n.T("Loading station list...")))
+		return b.String()
+	}
+	fmt.Fprintln(b, m.list.View())
+	fmt.Fprintln(b)
+	fmt.Fprint(b, m.previewView())
+	return b.String()
+}
+
+func (m *Model) previewView() string {
+	st, ok := m.selected()
+	if !ok {
+		return faintStyle.Render(i18n.T("/ to search by name or ID"))
+	}
+	w := max(20, m.width-4)
+	lines := []string{titleStyle.Render(layout.Truncate(st.ID+" "+st.Name, w))}
+	p, fetched := m.previews[st.ID]
+	switch {
+	case !fetched || p.loading:
+		lines = append(lines, faintStyle.Render(i18n.T("Loading latest reading...")))
+	case p.err != nil:
+		lines = append(lines, errStyle.Render(layout.Truncate(p.err.Error(), w)))
+	case len(p.lines) == 0:
+		lines = append(lines, faintStyle.Render(i18n.T("no recent reading")))
+	default:
+		for _, l := range p.lines {
+			lines = append(lines, infoStyle.Render(layout.Truncate(l, w)))
+		}
+	}
+	lines = append(lines, faintStyle.Render(i18n.T("enter: use this station · /: search")))
+	return strings.Join(lines, "\n")
+}
+
+// item is a station in the list.
+type item struct{ buoy.StationInfo }
+
+func (i item) Title() string { return i.ID + "  " + i.Name }
+
+// Description gives the kind and position, marking the configured stations.
+func (i item) Description() string {
+	kind := i18n.T("NDBC station")
+	switch {
+	case i.Tide:
+		kind = i18n.T("tide station")
+	case i.Moored:
+		kind = i18n.T("wave buoy")
+	}
+	desc := fmt.Sprintf("%s · %.2f, %.2f", kind, i.Lat, i.Lon)
+	if i.Tide && i.ID == buoy.ConfiguredTideStation() || !i.Tide && strings.EqualFold(i.ID, buoy.ConfiguredBuoyStation()) {
+		desc += " · " + activeStyle.Render(i18n.T("configured"))
+	}
+	return desc
+}
+
+func (i item) FilterValue() string { return i.ID + " " + i.Name }
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/spots"
+)
+
+// stationsCmd groups station lookup subcommands.
+var stationsCmd = &cobra.Command{
+	Use:   "stations",
+	Short: "Look up NOAA buoy and tide stations",
+}
+
+var stationsNearCmd = &cobra.Command{
+	Use:   "near <lat> <lon>",
+	Short: "Find the wave buoys and tide stations nearest a location",
+	Long: `Looks up the nearest active NDBC wave buoys and NOAA tide prediction stations
+to a location, from NOAA's station lists (cached for a month). Flags go
+before the coordinates; put -- before a negative latitude.`,
+	Example: `  surflog stations near --limit 3 37.76 -122.51
+  surflog stations near -- -33.89 151.27`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lat, err := strconv.ParseFloat(args[0], 64)
+		if err != nil || lat < -90 || lat > 90 {
+			return fmt.Errorf("invalid latitude %q", args[0])
+		}
+		lon, err := strconv.ParseFloat(args[1], 64)
+		if err != nil || lon < -180 || lon > 180 {
+			return fmt.Errorf("invalid longitude %q", args[1])
+		}
+		limit, _ := cmd.Flags().GetInt("limit")
+		if limit < 1 {
+			return errors.New("--limit must be at least 1")
+		}
+		stations, err := loadStationCatalog(cmd.Context())
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		printNear(out, "Wave buoys", buoy.Nearest(stations, lat, lon, false, limit))
+		fmt.Fprintln(out)
+		printNear(out, "Tide stations", buoy.Nearest(stations, lat, lon, true, limit))
+		return nil
+	},
+}
+
+// loadStationCatalog fetches the station lists, bounded so an unreachable
+// NOAA does not hang the command.
+func loadStationCatalog(ctx context.Context) ([]buoy.StationInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	stations, err := buoy.StationCatalog(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load station list: %w", err)
+	}
+	return stations, nil
+}
+
+func printNear(w io.Writer, title string, near []buoy.NearStation) {
+	fmt.Fprintln(w, title+":")
+	if len(near) == 0 {
+		fmt.Fprintln(w, "  none found")
+	}
+	for _, st := range near {
+		fmt.Fprintf(w, "  %-7s %6.1f km  %s\n", st.ID, st.Km, st.Name)
+	}
+}
+
+// fillNearestStations sets sp's buoy and tide stations, where it has none, to
+// the ones nearest its coordinates, reporting each choice on w.
+func fillNearestStations(ctx context.Context, w io.Writer, sp *spots.Spot) error {
+	if !sp.HasCoords() || (sp.Station != "" && sp.TideStation != "") {
+		return nil
+	}
+	stations, err := loadStationCatalog(ctx)
+	if err != nil {
+		return err
+	}
+	for _, tide := range []bool{false, true} {
+		id, kind := &sp.Station, "buoy"
+		if tide {
+			id, kind = &sp.TideStation, "tide station"
+		}
+		if *id != "" {
+			continue
+		}
+		near := buoy.Nearest(stations, sp.Lat, sp.Lon, tide, 1)
+		if len(near) == 0 {
+			continue
+		}
+		*id = near[0].ID
+		fmt.Fprintf(w, "%s: nearest %s %s %s (%.1f km)\n", sp.Name, kind, near[0].ID, near[0].Name, near[0].Km)
+	}
+	return nil
+}
+
+func init() {
+	stationsNearCmd.Flags().IntP("limit", "n", 1, "how many of each kind to list")
+	// stop at the latitude so a negative longitude isn't read as a flag
+	stationsNearCmd.Flags().SetInterspersed(false)
+	stationsCmd.AddCommand(stationsNearCmd)
+	rootCmd.AddCommand(stationsCmd)
+}
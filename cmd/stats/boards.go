@@ -0,0 +1,156 @@
+package stats
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/sumwatshade/surflog/cmd/create"
+)
+
+// Condition buckets that board ratings are split by. They are shown as-is.
+const (
+	BucketShortPeriod = "< 12s"
+	BucketLongPeriod  = "≥ 12s"
+	BucketSmall       = "< 1m"
+	BucketMid         = "1–2m"
+	BucketBig         = "≥ 2m"
+)
+
+// Buckets lists the condition buckets in display order.
+var Buckets = []string{BucketShortPeriod, BucketLongPeriod, BucketSmall, BucketMid, BucketBig}
+
+const (
+	// minBucketSessions is how many rated sessions a board needs in a
+	// bucket before it is compared with the board's overall average.
+	minBucketSessions = 2
+	// insightStars is how far a bucket's average must be from the board's
+	// overall average, in stars, to be worth calling out.
+	insightStars = 0.75
+)
+
+// BucketRating is a board's average rating in one condition bucket.
+type BucketRating struct {
+	Bucket   string
+	Avg      float64
+	Sessions int
+}
+
+// BoardRating is a board's average rating over its rated sessions, and split
+// by the conditions (from the stored buoy snapshot) it was ridden in.
+type BoardRating struct {
+	BoardID  string
+	Avg      float64
+	Sessions int
+	Buckets  []BucketRating // in Buckets order, only those ridden
+}
+
+// BoardInsight calls out a bucket where a board rates well above or below
+// its overall average, e.g. "the 6'2 underperforms on ≥ 12s days".
+type BoardInsight struct {
+	BoardID  string
+	Bucket   string
+	Avg      float64 // in the bucket
+	BoardAvg float64 // overall
+	Sessions int     // in the bucket
+}
+
+// Under reports whether the board rates worse than usual in the bucket.
+func (i BoardInsight) Under() bool { return i.Avg < i.BoardAvg }
+
+// conditionBuckets returns the buckets e's buoy snapshot falls into: one by
+// swell period (falling back to average period) and one by significant height.
+func conditionBuckets(e create.Entry) []string {
+	ws := e.WaveSummary
+	if ws.IsZero() {
+		return nil
+	}
+	var out []string
+	period := ws.SwellPeriod()
+	if period <= 0 {
+		period = ws.AveragePeriod()
+	}
+	switch {
+	case period <= 0:
+	case period < 12:
+		out = append(out, BucketShortPeriod)
+	default:
+		out = append(out, BucketLongPeriod)
+	}
+	switch h := ws.SignificantHeight(); {
+	case h <= 0:
+	case h < 1:
+		out = append(out, BucketSmall)
+	case h < 2:
+		out = append(out, BucketMid)
+	default:
+		out = append(out, BucketBig)
+	}
+	return out
+}
+
+// boardStats aggregates rated sessions with a board into per-board ratings,
+// most ridden first, and the insights worth showing, biggest gap first.
+func boardStats(entries []create.Entry) ([]BoardRating, []BoardInsight) {
+	type acc struct {
+		total float64
+		n     int
+	}
+	overall := map[string]*acc{}
+	buckets := map[string]map[string]*acc{}
+	for _, e := range entries {
+		id := strings.TrimSpace(e.BoardID)
+		if id == "" || e.Rating <= 0 {
+			continue
+		}
+		if overall[id] == nil {
+			overall[id] = &acc{}
+			buckets[id] = map[string]*acc{}
+		}
+		overall[id].total += float64(e.Rating)
+		overall[id].n++
+		for _, b := range conditionBuckets(e) {
+			if buckets[id][b] == nil {
+				buckets[id][b] = &acc{}
+			}
+			buckets[id][b].total += float64(e.Rating)
+			buckets[id][b].n++
+		}
+	}
+
+	var boards []BoardRating
+	var insights []BoardInsight
+	for id, o := range overall {
+		br := BoardRating{BoardID: id, Avg: o.total / float64(o.n), Sessions: o.n}
+		for _, name := range Buckets {
+			a := buckets[id][name]
+			if a == nil {
+				continue
+			}
+			avg := a.total / float64(a.n)
+			br.Buckets = append(br.Buckets, BucketRating{Bucket: name, Avg: avg, Sessions: a.n})
+			// A bucket holding every session can't differ from the overall.
+			if a.n >= minBucketSessions && a.n < o.n && math.Abs(avg-br.Avg) >= insightStars {
+				insights = append(insights, BoardInsight{BoardID: id, Bucket: name, Avg: avg, BoardAvg: br.Avg, Sessions: a.n})
+			}
+		}
+		boards = append(boards, br)
+	}
+	sort.Slice(boards, func(i, j int) bool {
+		if boards[i].Sessions != boards[j].Sessions {
+			return boards[i].Sessions > boards[j].Sessions
+		}
+		return boards[i].BoardID < boards[j].BoardID
+	})
+	sort.Slice(insights, func(i, j int) bool {
+		a, b := math.Abs(insights[i].Avg-insights[i].BoardAvg), math.Abs(insights[j].Avg-insights[j].BoardAvg)
+		if a != b {
+			return a > b
+		}
+		if insights[i].BoardID != insights[j].BoardID {
+			return insights[i].BoardID < insights[j].BoardID
+		}
+		return insights[i].Bucket < insights[j].Bucket
+	})
+	return boards, insights
+}
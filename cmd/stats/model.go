@@ -0,0 +1,203 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NimbleMarkets/ntcharts/barchart"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/layout"
+	"github.com/sumwatshade/surflog/cmd/quiver"
+	"github.com/sumwatshade/surflog/cmd/units"
+)
+
+// topN caps the per-spot charts.
+const topN = 5
+
+var (
+	titleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("44"))
+	faintStyle = lipgloss.NewStyle().Faint(true).Foreground(lipgloss.Color("245"))
+	infoStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
+	axisStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("239"))
+	barStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("44"))
+	starStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+	buoyStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("36"))
+)
+
+// Model is the stats dashboard right-pane view: sessions per month, most
+// surfed spots, average rating per spot, measured wave height per perceived
+// height, journal writing and board ratings by conditions.
+type Model struct {
+	stats Stats
+}
+
+// NewModel aggregates existing entries.
+func NewModel(entries []create.Entry) *Model {
+	m := &Model{}
+	m.SetEntries(entries)
+	return m
+}
+
+// SetEntries re-aggregates after the journal changes.
+func (m *Model) SetEntries(entries []create.Entry) {
+	if m != nil {
+		m.stats = Build(entries, time.Now())
+	}
+}
+
+// View renders the dashboard at the given width.
+func (m *Model) View(width int) string {
+	b := &strings.Builder{}
+	fmt.Fprintln(b, titleStyle.Render(i18n.T("Stats")))
+	if m == nil || m.stats.Sessions == 0 {
+		fmt.Fprintln(b, faintStyle.Render(i18n.T("No sessions logged yet.")))
+		return b.String()
+	}
+	width = max(20, width)
+	s := m.stats
+
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, titleStyle.Render(i18n.T("Sessions per month")))
+	fmt.Fprintln(b, monthChart(s.Monthly, width))
+
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, titleStyle.Render(i18n.T("Most surfed spots")))
+	var rows []barRow
+	for _, sc := range s.Spots[:min(topN, len(s.Spots))] {
+		rows = append(rows, barRow{sc.Spot, float64(sc.Sessions), fmt.Sprint(sc.Sessions)})
+	}
+	fmt.Fprintln(b, barRows(rows, 0, barStyle, width))
+
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, titleStyle.Render(i18n.T("Average rating")))
+	if len(s.Ratings) == 0 {
+		fmt.Fprintln(b, faintStyle.Render(i18n.T("No rated sessions yet.")))
+	} else {
+		rows = nil
+		for _, r := range s.Ratings[:min(topN, len(s.Ratings))] {
+			rows = append(rows, barRow{r.Spot, r.Avg, fmt.Sprintf("%.1f★ (%d)", r.Avg, r.Sessions)})
+		}
+		fmt.Fprintln(b, barRows(rows, 5, starStyle, width))
+	}
+
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, titleStyle.Render(i18n.T("Buoy height by perceived height")))
+	if len(s.Heights) == 0 {
+		fmt.Fprintln(b, faintStyle.Render(i18n.T("No sessions with buoy data yet.")))
+	} else {
+		rows = nil
+		for _, h := range s.Heights {
+			rows = append(rows, barRow{create.HeightLabel(h.Perceived), h.AvgWVHT, fmt.Sprintf("%s (%d)", units.Height(h.AvgWVHT), h.Sessions)})
+		}
+		fmt.Fprintln(b, barRows(rows, 0, buoyStyle, width))
+	}
+
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, titleStyle.Render(i18n.T("Journal writing")))
+	fmt.Fprintln(b, writingView(s.Writing, s.Sessions))
+
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, titleStyle.Render(i18n.T("Boards by conditions")))
+	if len(s.Boards) == 0 {
+		fmt.Fprint(b, faintStyle.Render(i18n.T("No rated sessions with a board yet.")))
+		return b.String()
+	}
+	rows = nil
+	for _, br := range s.Boards[:min(topN, len(s.Boards))] {
+		rows = append(rows, barRow{boardName(br.BoardID), br.Avg, fmt.Sprintf("%.1f★ (%d)", br.Avg, br.Sessions)})
+	}
+	fmt.Fprint(b, barRows(rows, 5, starStyle, width))
+	if len(s.Insights) == 0 {
+		fmt.Fprint(b, "\n"+faintStyle.Render(i18n.T("No board stands out in any conditions yet.")))
+	}
+	for _, in := range s.Insights[:min(topN, len(s.Insights))] {
+		line := i18n.T("▲ %s shines on %s days: %.1f★ vs %.1f★ (%d)", boardName(in.BoardID), in.Bucket, in.Avg, in.BoardAvg, in.Sessions)
+		if in.Under() {
+			line = i18n.T("▼ %s underperforms on %s days: %.1f★ vs %.1f★ (%d)", boardName(in.BoardID), in.Bucket, in.Avg, in.BoardAvg, in.Sessions)
+		}
+		fmt.Fprint(b, "\n"+infoStyle.Render(line))
+	}
+	return b.String()
+}
+
+// writingView summarises comment lengths and streaks, nudging when this
+// month's sessions went unwritten.
+func writingView(w Writing, sessions int) string {
+	if w.Commented == 0 {
+		return faintStyle.Render(i18n.T("No comments written yet."))
+	}
+	lines := []string{
+		infoStyle.Render(i18n.T("%d words over %d of %d sessions (avg %.0f)", w.Words, w.Commented, sessions, w.AvgWords())),
+		infoStyle.Render(i18n.T("writing streak: %d sessions (best %d)", w.Streak, w.BestStreak)),
+	}
+	switch w.EmptyThisMonth {
+	case 0:
+	case 1:
+		lines = append(lines, faintStyle.Render(i18n.T("1 session this month has an empty comment")))
+	default:
+		lines = append(lines, faintStyle.Render(i18n.T("%d sessions this month have empty comments", w.EmptyThisMonth)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// boardName labels a board from the quiver, which may have been deleted since.
+func boardName(id string) string {
+	if name := quiver.Name(id); name != "" {
+		return name
+	}
+	return i18n.T("(deleted board)")
+}
+
+// monthChart draws sessions per month as vertical bars labelled with the
+// month's abbreviation.
+func monthChart(months []MonthCount, width int) string {
+	chart := barchart.New(min(width, 6*len(months)), 8, barchart.WithStyles(axisStyle, infoStyle))
+	for _, mc := range months {
+		label := i18n.T(mc.Month.Format("Jan"))
+		chart.Push(barchart.BarData{Label: label, Values: []barchart.BarValue{{Name: label, Value: float64(mc.Sessions), Style: barStyle}}})
+	}
+	chart.Draw()
+	peak := 0
+	for _, mc := range months {
+		peak = max(peak, mc.Sessions)
+	}
+	return chart.View() + "\n" + faintStyle.Render(i18n.T("busiest month: %d sessions", peak))
+}
+
+// barRow is one labelled horizontal bar.
+type barRow struct {
+	label string
+	value float64
+	text  string // shown after the bar
+}
+
+// barRows draws one horizontal bar per row between a label column and a
+// value column. maxValue fixes the scale; 0 scales to the largest value.
+func barRows(rows []barRow, maxValue float64, style lipgloss.Style, width int) string {
+	labelW, textW := 0, 0
+	for _, r := range rows {
+		labelW = max(labelW, layout.Width(r.label))
+		textW = max(textW, layout.Width(r.text))
+	}
+	labelW = min(labelW, width/3)
+	opts := []barchart.Option{barchart.WithHorizontalBars(), barchart.WithNoAxis(), barchart.WithNoAutoBarWidth(), barchart.WithBarWidth(1), barchart.WithBarGap(0)}
+	if maxValue > 0 {
+		opts = append(opts, barchart.WithNoAutoMaxValue(), barchart.WithMaxValue(maxValue))
+	}
+	chart := barchart.New(max(4, width-labelW-textW-2), len(rows), opts...)
+	labels := make([]string, len(rows))
+	texts := make([]string, len(rows))
+	for i, r := range rows {
+		chart.Push(barchart.BarData{Label: r.label, Values: []barchart.BarValue{{Name: r.label, Value: r.value, Style: style}}})
+		labels[i] = layout.Truncate(r.label, labelW)
+		texts[i] = infoStyle.Render(r.text)
+	}
+	chart.Draw()
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(labelW+1).Render(strings.Join(labels, "\n")),
+		chart.View(),
+		" "+strings.Join(texts, "\n "))
+}
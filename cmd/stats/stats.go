@@ -0,0 +1,118 @@
+package stats
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/recap"
+)
+
+// Months is how many calendar months the sessions chart covers.
+const Months = 12
+
+// MonthCount is the number of sessions in one calendar month.
+type MonthCount struct {
+	Month    time.Time // first day of the month (local time)
+	Sessions int
+}
+
+// SpotRating is a spot's average star rating over its rated sessions.
+type SpotRating struct {
+	Spot     string
+	Avg      float64
+	Sessions int
+}
+
+// HeightWVHT is the average measured significant wave height for one
+// perceived height.
+type HeightWVHT struct {
+	Perceived string
+	AvgWVHT   float64 // meters
+	Sessions  int
+}
+
+// Stats aggregates the whole journal for the stats view.
+type Stats struct {
+	Sessions int
+	Monthly  []MonthCount      // the last Months months, oldest first
+	Spots    []recap.SpotCount // most surfed first
+	Ratings  []SpotRating      // best rated first
+	Heights  []HeightWVHT      // in create.HeightOptions order, only those logged with buoy data
+	Boards   []BoardRating     // most ridden first
+	Insights []BoardInsight    // biggest gap first
+	Writing  Writing
+}
+
+// Build aggregates entries for the Months months up to and including now's.
+func Build(entries []create.Entry, now time.Time) Stats {
+	s := Stats{Sessions: len(entries), Spots: recap.Leaderboard(entries)}
+	s.Boards, s.Insights = boardStats(entries)
+	s.Writing = writingStats(entries, now)
+
+	first := recap.MonthStart(now).AddDate(0, 1-Months, 0)
+	for i := range Months {
+		s.Monthly = append(s.Monthly, MonthCount{Month: first.AddDate(0, i, 0)})
+	}
+	type sum struct {
+		name  string
+		total float64
+		n     int
+	}
+	ratings := map[string]*sum{}
+	heights := map[string]*sum{}
+	for _, e := range entries {
+		at := sessionTime(e).In(now.Location())
+		if !at.Before(first) {
+			months := (at.Year()-first.Year())*12 + int(at.Month()-first.Month())
+			if months < Months {
+				s.Monthly[months].Sessions++
+			}
+		}
+		if name := strings.TrimSpace(e.Spot); name != "" && e.Rating > 0 {
+			k := strings.ToLower(name)
+			if ratings[k] == nil {
+				ratings[k] = &sum{name: name}
+			}
+			ratings[k].total += float64(e.Rating)
+			ratings[k].n++
+		}
+		if ws := e.WaveSummary; !ws.IsZero() && ws.SignificantHeight() > 0 {
+			k := strings.ToLower(strings.TrimSpace(e.WaveHeight))
+			if heights[k] == nil {
+				heights[k] = &sum{}
+			}
+			heights[k].total += ws.SignificantHeight()
+			heights[k].n++
+		}
+	}
+	for _, r := range ratings {
+		s.Ratings = append(s.Ratings, SpotRating{Spot: r.name, Avg: r.total / float64(r.n), Sessions: r.n})
+	}
+	sort.Slice(s.Ratings, func(i, j int) bool {
+		a, b := s.Ratings[i], s.Ratings[j]
+		if a.Avg != b.Avg {
+			return a.Avg > b.Avg
+		}
+		if a.Sessions != b.Sessions {
+			return a.Sessions > b.Sessions
+		}
+		return a.Spot < b.Spot
+	})
+	for _, h := range create.HeightOptions {
+		if acc := heights[strings.ToLower(h)]; acc != nil {
+			s.Heights = append(s.Heights, HeightWVHT{Perceived: h, AvgWVHT: acc.total / float64(acc.n), Sessions: acc.n})
+		}
+	}
+	return s
+}
+
+// sessionTime falls back to CreatedAt for entries without a session time.
+func sessionTime(e create.Entry) time.Time {
+	if !e.SessionAt.IsZero() {
+		return e.SessionAt
+	}
+	t, _ := time.Parse(time.RFC3339, strings.TrimSpace(e.CreatedAt))
+	return t
+}
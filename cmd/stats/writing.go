@@ -0,0 +1,54 @@
+package stats
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/recap"
+)
+
+// Writing summarises the journal's comments, for those keeping it as a
+// reflective log rather than just a session count.
+type Writing struct {
+	Words     int // across all comments
+	Commented int // sessions with a comment
+	// Streak is how many of the latest sessions in a row have a comment;
+	// BestStreak is the longest such run.
+	Streak     int
+	BestStreak int
+	// EmptyThisMonth counts this month's sessions without a comment.
+	EmptyThisMonth int
+}
+
+// AvgWords is the mean comment length over commented sessions.
+func (w Writing) AvgWords() float64 {
+	if w.Commented == 0 {
+		return 0
+	}
+	return float64(w.Words) / float64(w.Commented)
+}
+
+// writingStats counts comment words and streaks, walking sessions in order.
+func writingStats(entries []create.Entry, now time.Time) Writing {
+	sorted := append([]create.Entry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sessionTime(sorted[i]).Before(sessionTime(sorted[j])) })
+	month := recap.MonthStart(now)
+	var w Writing
+	for _, e := range sorted {
+		words := len(strings.Fields(e.Comments))
+		if words == 0 {
+			w.Streak = 0
+			if !sessionTime(e).In(now.Location()).Before(month) {
+				w.EmptyThisMonth++
+			}
+			continue
+		}
+		w.Words += words
+		w.Commented++
+		w.Streak++
+		w.BestStreak = max(w.BestStreak, w.Streak)
+	}
+	return w
+}
@@ -1,30 +1,49 @@
 package cmd
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+)
 
 // Centralized styles for consistent UX across views.
 // Ocean palette
 // Deep Blue: 25, Teal: 30/36, Cyan accents: 44/51, Soft Grey: 243-247, Dark Grey: 238, Light Foam: 159
 var (
-	appTitle       = "surflog"
-	headerStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("51")).Background(lipgloss.Color("24")).Padding(0, 1)
-	tabStyle       = lipgloss.NewStyle().Padding(0, 1).Foreground(lipgloss.Color("245"))
-	activeTabStyle = tabStyle.Bold(true).Foreground(lipgloss.Color("159")).Background(lipgloss.Color("24"))
-	contentStyle   = lipgloss.NewStyle().Padding(1, 2)
-	footerStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("243")).Padding(0, 1)
-	dividerStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("24"))
-	helpBoxStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("246")).Padding(0, 1).Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("24"))
+	appTitle            = "surflog"
+	headerStyle         = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("51")).Background(lipgloss.Color("24")).Padding(0, 1)
+	tabStyle            = lipgloss.NewStyle().Padding(0, 1).Foreground(lipgloss.Color("245"))
+	activeTabStyle      = tabStyle.Bold(true).Foreground(lipgloss.Color("159")).Background(lipgloss.Color("24"))
+	contentStyle        = lipgloss.NewStyle().Padding(1, 2)
+	footerStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("243")).Padding(0, 1)
+	dividerStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("24"))
+	timerStyle          = lipgloss.NewStyle().Foreground(lipgloss.Color("159")).Bold(true)
+	pendingStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Bold(true)
+	toastStyle          = lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true)
+	spotNotesTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("44"))
+	spotNotesStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
+	helpBoxStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("246")).Padding(0, 1).Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("24"))
 )
 
+// rightTabs lists the switchable right-pane views (id, label); buoy data is
+// always visible on the left.
+var rightTabs = []struct{ id, label string }{
+	{"journal", "journal"},
+	{"create", "create"},
+	{"bets", "best bets"},
+	{"spots", "spot report"},
+	{"quiver", "quiver"},
+	{"stats", "stats"},
+	{"week", "week"},
+	{"stations", "stations"},
+}
+
 func tabs(current string, width int) string {
-	// Only journal/create are switchable; buoy data always visible left.
-	names := []string{"journal", "create"}
 	var rendered []string
-	for _, n := range names {
-		if n == current {
-			rendered = append(rendered, activeTabStyle.Render(n))
+	for _, t := range rightTabs {
+		if t.id == current {
+			rendered = append(rendered, activeTabStyle.Render(i18n.T(t.label)))
 		} else {
-			rendered = append(rendered, tabStyle.Render(n))
+			rendered = append(rendered, tabStyle.Render(i18n.T(t.label)))
 		}
 	}
 	line := lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
@@ -0,0 +1,46 @@
+// Package swell estimates when swell energy seen at a distant buoy (or in a
+// forecast) reaches the coast, using linear deep-water wave theory.
+package swell
+
+import (
+	"math"
+	"time"
+)
+
+// g is gravitational acceleration in m/s².
+const g = 9.81
+
+// earthRadiusKm is the mean Earth radius used for great-circle distances.
+const earthRadiusKm = 6371.0
+
+// GroupVelocity returns the deep-water group velocity in m/s for a wave
+// period in seconds: Cg = gT / 4π. Swell energy travels at this speed, half
+// the speed of the individual wave crests.
+func GroupVelocity(periodS float64) float64 {
+	return g * periodS / (4 * math.Pi)
+}
+
+// TravelTime returns how long energy of the given period takes to cover
+// distanceKm. It is zero for non-positive periods or distances.
+func TravelTime(periodS, distanceKm float64) time.Duration {
+	if periodS <= 0 || distanceKm <= 0 {
+		return 0
+	}
+	seconds := distanceKm * 1000 / GroupVelocity(periodS)
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// Arrival returns when energy observed at observedAt, distanceKm away, should
+// reach the spot.
+func Arrival(observedAt time.Time, periodS, distanceKm float64) time.Time {
+	return observedAt.Add(TravelTime(periodS, distanceKm))
+}
+
+// DistanceKm returns the great-circle (haversine) distance between two points.
+func DistanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	rad := math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLon := (lon2 - lon1) * rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
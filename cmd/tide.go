@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/units"
+)
+
+// maxTideDays keeps requests within what CO-OPS serves at 6-minute interval.
+const maxTideDays = 31
+
+var tideCmd = &cobra.Command{
+	Use:   "tide",
+	Short: "Print tide predictions for a date range",
+	Long: `Fetches NOAA tide predictions for a tide station (--station, default the
+configured tide.station) starting at local midnight on --date for --days days.
+
+Formats:
+  chart  the TUI's tide chart with highs and lows marked (default)
+  csv    time,height_ft,type rows (height_m with units: metric); highs and
+         lows are tagged in type
+  json   {"station", "predictions", "extremes"}`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		station, _ := cmd.Flags().GetString("station")
+		if station = strings.TrimSpace(station); station == "" {
+			station = buoy.ConfiguredTideStation()
+		}
+		if err := buoy.ValidateTideStation(station); err != nil {
+			return err
+		}
+		begin := time.Now()
+		if s, _ := cmd.Flags().GetString("date"); s != "" {
+			d, err := time.ParseInLocation("2006-01-02", s, time.Local)
+			if err != nil {
+				return fmt.Errorf("invalid --date %q (want YYYY-MM-DD)", s)
+			}
+			begin = d
+		}
+		begin = time.Date(begin.Year(), begin.Month(), begin.Day(), 0, 0, 0, 0, time.Local)
+		days, _ := cmd.Flags().GetInt("days")
+		if days < 1 || days > maxTideDays {
+			return fmt.Errorf("--days must be between 1 and %d", maxTideDays)
+		}
+		end := begin.AddDate(0, 0, days).Add(-time.Minute)
+		format, _ := cmd.Flags().GetString("format")
+		format = strings.ToLower(strings.TrimSpace(format))
+		if format != "chart" && format != "csv" && format != "json" {
+			return fmt.Errorf("unknown --format %q (want json, csv or chart)", format)
+		}
+
+		td, err := buoy.NewServiceForStations(buoy.ConfiguredBuoyStation(), station).GetTideData(cmd.Context(), begin, end)
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		switch format {
+		case "json":
+			data, err := json.MarshalIndent(struct {
+				Station     string                `json:"station"`
+				Predictions []buoy.TidePrediction `json:"predictions"`
+				Extremes    []buoy.TidePrediction `json:"extremes"`
+			}{td.Station(), td.Predictions(), td.Extremes(begin, end)}, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(out, string(data))
+		case "csv":
+			w := csv.NewWriter(out)
+			_ = w.Write([]string{"time", "height_" + units.HeightUnit(), "type"})
+			rows := td.Predictions()
+			if len(rows) == 0 {
+				rows = td.Extremes(begin, end) // low-bandwidth mode has only highs and lows
+			} else {
+				types := map[time.Time]string{}
+				for _, e := range td.Extremes(begin, end) {
+					types[e.Time] = e.Type
+				}
+				for i := range rows {
+					rows[i].Type = types[rows[i].Time]
+				}
+			}
+			for _, p := range rows {
+				_ = w.Write([]string{p.Time.Format(time.RFC3339), strconv.FormatFloat(units.FeetValue(p.Height), 'f', 3, 64), p.Type})
+			}
+			w.Flush()
+			return w.Error()
+		default:
+			fmt.Fprintln(out, buoy.RenderTideChart(td, begin, end, min(42*days, 120), 12))
+		}
+		return nil
+	},
+}
+
+func init() {
+	tideCmd.Flags().String("station", "", "NOAA tide station ID (default tide.station)")
+	tideCmd.Flags().String("date", "", "first day to print, YYYY-MM-DD (default today)")
+	tideCmd.Flags().Int("days", 1, "number of days to print")
+	tideCmd.Flags().String("format", "chart", "output format: json, csv or chart")
+	rootCmd.AddCommand(tideCmd)
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/config"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/journal"
+	"github.com/sumwatshade/surflog/cmd/timer"
+)
+
+// timerCmd groups the session timer subcommands.
+var timerCmd = &cobra.Command{
+	Use:   "timer",
+	Short: "Time a session from paddle-out to exit",
+}
+
+var timerStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the session timer",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := timer.NewStore(config.StateDir())
+		if err != nil {
+			return err
+		}
+		spot, _ := cmd.Flags().GetString("spot")
+		sess, err := store.Start(spot, time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "timer started at %s\n", sess.StartedAt.Format("15:04"))
+		return nil
+	},
+}
+
+var timerStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the running session timer",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := timer.NewStore(config.StateDir())
+		if err != nil {
+			return err
+		}
+		sess, ok, err := store.Current()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(cmd.OutOrStdout(), "no timer running")
+			return nil
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "running since %s (%s)\n", sess.StartedAt.Format("15:04"),
+			create.FormatDuration(int(sess.Elapsed(time.Now()).Minutes())))
+		return nil
+	},
+}
+
+var timerStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the session timer and log the session",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := timer.NewStore(config.StateDir())
+		if err != nil {
+			return err
+		}
+		sess, ok, err := store.Current()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return timer.ErrNotRunning
+		}
+		entry := timerEntry(sess, time.Now())
+		if spot, _ := cmd.Flags().GetString("spot"); spot != "" {
+			entry.Spot = spot
+		}
+		if entry.Spot == "" {
+			return errors.New("spot required: pass --spot or start the timer with one")
+		}
+		height, _ := cmd.Flags().GetString("height")
+		if entry.WaveHeight, ok = create.NormalizeHeight(height); !ok {
+			return fmt.Errorf("unknown --height %q (want one of %s)", height, strings.Join(create.HeightLabels(), ", "))
+		}
+		entry.Comments, _ = cmd.Flags().GetString("comments")
+		if entry.WaveCount, _ = cmd.Flags().GetInt("waves"); entry.WaveCount < 0 {
+			return errors.New("--waves must not be negative")
+		}
+		if ws, err := buoy.NewService().GetWaveSummary(cmd.Context()); err == nil {
+			entry.WaveSummary = ws
+			entry.SetSource(create.WaveSource(ws, time.Now()))
+		}
+		if w, err := buoy.NewService().GetWindSummary(cmd.Context()); err == nil {
+			entry.Wind = &w
+			entry.SetSource(create.WindSource(w, time.Now()))
+		}
+		if t, err := buoy.NewService().GetTemperatures(cmd.Context()); err == nil {
+			if c, ok := t.Water(); ok {
+				entry.WaterTempC = &c
+				entry.SetSource(create.WaterSource(buoy.ConfiguredBuoyStation(), time.Now()))
+			}
+		}
+		svc, err := journal.NewDefaultService()
+		if err != nil {
+			return err
+		}
+		saved, err := svc.Create(entry)
+		if err != nil {
+			return err
+		}
+		if _, err := store.Stop(); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "logged %s session (%s): %s\n", saved.Spot, create.FormatDuration(saved.DurationMin), saved.ID)
+		return nil
+	},
+}
+
+// timerEntry builds a pre-filled entry from a finished timer session.
+func timerEntry(sess timer.Session, end time.Time) create.Entry {
+	return create.Entry{
+		Spot:        sess.Spot,
+		SessionAt:   sess.StartedAt,
+		DurationMin: int(sess.Elapsed(end).Round(time.Minute).Minutes()),
+		WaveHeight:  create.HeightOptions[0],
+	}
+}
+
+func init() {
+	timerStartCmd.Flags().String("spot", "", "spot being surfed")
+	timerStopCmd.Flags().String("spot", "", "spot surfed (overrides the one given at start)")
+	timerStopCmd.Flags().String("height", create.HeightOptions[0], "perceived wave height (value or configured label)")
+	timerStopCmd.Flags().String("comments", "", "session comments")
+	timerStopCmd.Flags().Int("waves", 0, "waves caught")
+	timerCmd.AddCommand(timerStartCmd, timerStatusCmd, timerStopCmd)
+	rootCmd.AddCommand(timerCmd)
+}
@@ -0,0 +1,87 @@
+package timer
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrNotRunning is returned when stopping without an active timer.
+var ErrNotRunning = errors.New("no session timer running")
+
+// ErrRunning is returned when starting while a timer is already active.
+var ErrRunning = errors.New("session timer already running")
+
+// Session is a running session timer.
+type Session struct {
+	StartedAt time.Time `json:"started_at"`
+	Spot      string    `json:"spot,omitempty"`
+}
+
+// Elapsed returns time since the session started.
+func (s Session) Elapsed(now time.Time) time.Duration { return now.Sub(s.StartedAt) }
+
+// Store persists the single active timer so it survives across CLI and TUI runs.
+type Store struct {
+	path string
+}
+
+// NewStore creates a timer store under dir (created if missing).
+func NewStore(dir string) (*Store, error) {
+	if dir == "" {
+		return nil, errors.New("empty state dir")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Store{path: filepath.Join(dir, "timer.json")}, nil
+}
+
+// Current returns the running session; ok is false when none.
+func (s *Store) Current() (Session, bool, error) {
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Session{}, false, nil
+	}
+	if err != nil {
+		return Session{}, false, err
+	}
+	var sess Session
+	if err := json.Unmarshal(b, &sess); err != nil {
+		return Session{}, false, err
+	}
+	return sess, true, nil
+}
+
+// Start records a new session start.
+func (s *Store) Start(spot string, at time.Time) (Session, error) {
+	if _, ok, err := s.Current(); err != nil {
+		return Session{}, err
+	} else if ok {
+		return Session{}, ErrRunning
+	}
+	sess := Session{StartedAt: at.Truncate(time.Second), Spot: spot}
+	data, err := json.MarshalIndent(sess, "", "  ")
+	if err != nil {
+		return Session{}, err
+	}
+	return sess, os.WriteFile(s.path, data, 0o644)
+}
+
+// Stop clears the running session and returns it.
+func (s *Store) Stop() (Session, error) {
+	sess, ok, err := s.Current()
+	if err != nil {
+		return Session{}, err
+	}
+	if !ok {
+		return Session{}, ErrNotRunning
+	}
+	if err := os.Remove(s.path); err != nil {
+		return Session{}, err
+	}
+	return sess, nil
+}
@@ -1,40 +1,122 @@
 package cmd
 
 import (
+	"context"
+	"path/filepath"
 	"strings"
+	"time"
 
 	bhelp "github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sumwatshade/surflog/cmd/analysis"
 	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/config"
 	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/htmlview"
+	"github.com/sumwatshade/surflog/cmd/i18n"
 	"github.com/sumwatshade/surflog/cmd/journal"
+	"github.com/sumwatshade/surflog/cmd/layout"
+	"github.com/sumwatshade/surflog/cmd/quiver"
+	"github.com/sumwatshade/surflog/cmd/recap"
+	"github.com/sumwatshade/surflog/cmd/recommend"
+	"github.com/sumwatshade/surflog/cmd/spots"
+	"github.com/sumwatshade/surflog/cmd/stationpicker"
+	"github.com/sumwatshade/surflog/cmd/stats"
+	"github.com/sumwatshade/surflog/cmd/timer"
+	"github.com/sumwatshade/surflog/cmd/week"
 )
 
 type model struct {
-	rightView  string // "journal" or "create"
+	ctx        context.Context // cancelled on quit to abort in-flight fetches
+	cancel     context.CancelFunc
+	rightView  string // "journal", "create", "bets", "spots", "quiver", "stats", "week", "stations" or "recap"
 	buoyData   *buoy.BuoyData
 	journal    *journal.Journal
 	createForm *create.Model
-	width      int
-	height     int
+	bets       *recommend.Model
+	report     *analysis.Model
+	quiver     *quiver.Model
+	stats      *stats.Model
+	week       *week.Model
+	stations   *stationpicker.Model
+	recap      *recap.Model // monthly recap card, set on the first launch of a month
+	spotSvc    spots.Service
+	activeSpot *spots.Spot // active spot (from config, cycled with the spot key)
+	timer      *timer.Session
+	// toast is a transient header notice, e.g. a big jump in conditions
+	// found by a background refresh; toastSeq drops expiries of older ones.
+	toast    string
+	toastSeq int
+	width    int
+	height   int
 	// help / key bindings
 	keys keyMap
 	help bhelp.Model
 }
 
-func initialModel() model {
-	m := model{rightView: "journal", buoyData: nil, journal: journal.NewJournal(), createForm: create.NewModel(), keys: keys, help: bhelp.New()}
+func initialModel(ctx context.Context) model {
+	ctx, cancel := context.WithCancel(ctx)
+	m := model{ctx: ctx, cancel: cancel, rightView: "journal", buoyData: nil, journal: journal.NewJournal(), createForm: create.NewModel(), keys: newKeyMap(), help: bhelp.New()}
+	m.journal.SetContext(ctx)
+	m.createForm.SetContext(ctx)
+	m.createForm.SetPastSessions(m.journal.Entries)
+	m.bets = recommend.NewModel(m.journal.Entries)
+	m.report = analysis.NewModel(m.journal.Entries)
+	m.report.SetOpener(openReport)
+	m.quiver = quiver.NewModel()
+	m.stats = stats.NewModel(m.journal.Entries)
+	m.week = week.NewModel(m.bets, m.journal.Entries)
+	m.stations = stationpicker.NewModel()
+	if store, err := recap.NewStore(config.StateDir()); err == nil && store.Due(time.Now()) {
+		r := recap.Build(m.journal.Entries, recap.PreviousMonth(time.Now()))
+		if !r.Empty() {
+			m.recap = recap.NewModel(r, filepath.Join(config.JournalDir(), "recaps"))
+			m.rightView = "recap"
+		}
+		_ = store.MarkShown(time.Now())
+	}
+	if store, err := timer.NewStore(config.StateDir()); err == nil {
+		if sess, ok, _ := store.Current(); ok {
+			m.timer = &sess
+		}
+	}
+	if svc, err := spots.NewDefaultService(); err == nil {
+		m.spotSvc = svc
+		if sp, ok := spots.Active(svc); ok {
+			m.activeSpot = &sp
+		}
+	}
 	if m.createForm != nil {
+		if draft, ok := m.journal.LoadDraft(); ok {
+			m.createForm.ApplyDraft(draft)
+		}
 		m.createForm.Focus()
 	}
 	return m
 }
 
+// shutdown cancels outstanding fetches and flushes any unsaved draft so it can
+// be restored next launch. Entry writes are synchronous; failed ones already
+// wait in the outbox and are retried next launch. Entry hooks still running
+// get a few seconds to finish.
+func (m model) shutdown() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	if m.journal == nil {
+		return
+	}
+	if draft, ok := m.createForm.Draft(); ok {
+		_ = m.journal.SaveDraft(draft)
+	}
+	m.journal.WaitHooks()
+}
+
 func (m model) Init() tea.Cmd {
-	// Just return `nil`, which means "no I/O right now, please."
-	return nil
+	// retry entries left in the outbox by an earlier run
+	return tea.Batch(m.journal.OutboxCmd(), m.journal.HookFailuresCmd())
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -44,6 +126,20 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width, m.height = msg.Width, msg.Height
+	case buoy.ChangedMsg:
+		return m, m.showToast(strings.Join(msg.Lines, "; "))
+	case journal.HookFailedMsg:
+		return m, tea.Batch(m.showToast(msg.Err.Error()), m.journal.HookFailuresCmd())
+	case stationpicker.StationSetMsg:
+		// a new default station shows unless the active spot has its own
+		var cmd tea.Cmd
+		m.buoyData, cmd = buoy.Reload(m.ctx, m.buoyData)
+		return m, cmd
+	case toastExpiredMsg:
+		if msg.seq == m.toastSeq {
+			m.toast = ""
+		}
+		return m, nil
 	case tea.KeyMsg:
 		// When in create view and actively editing the draft form, suppress
 		// global navigation keybindings so characters like 'q' and 'j' go into
@@ -51,26 +147,119 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.rightView == "create" && m.createForm != nil && m.createForm.IsDraft() {
 			// Allow Ctrl+C as an immediate quit escape hatch.
 			if msg.String() == "ctrl+c" {
+				m.cancel()
 				return m, tea.Quit
 			}
 			// Esc cancels draft: clear form and return to journal view
 			if msg.String() == "esc" {
+				if m.journal != nil {
+					m.journal.ClearDraft()
+					m.bets.SetEntries(m.journal.Entries)
+					m.week.SetEntries(m.journal.Entries)
+				}
 				m.createForm = nil
 				m.rightView = "journal"
 				return m, nil
 			}
 			break
 		}
+		// likewise while a journal entry is being edited or searched
+		if m.rightView == "journal" && m.journal.Editing() {
+			if msg.String() == "ctrl+c" {
+				m.cancel()
+				return m, tea.Quit
+			}
+			break
+		}
+		// and while a board is being added or edited
+		if m.rightView == "quiver" && m.quiver.Editing() {
+			if msg.String() == "ctrl+c" {
+				m.cancel()
+				return m, tea.Quit
+			}
+			break
+		}
+		// and while the station list is being searched
+		if m.rightView == "stations" && m.stations.Filtering() {
+			if msg.String() == "ctrl+c" {
+				m.cancel()
+				return m, tea.Quit
+			}
+			break
+		}
+		// the journal list sorts with 's'; S still opens the spot report there
+		if m.rightView == "journal" && msg.String() == "s" {
+			break
+		}
+		if m.rightView == "recap" && msg.String() != "ctrl+c" {
+			if m.recap.Update(msg) {
+				m.recap = nil
+				m.rightView = "journal"
+				// the journal list has not been sized yet while the card was up
+				m.journal.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height}, rightPaneWidth(m.width), m.height)
+			}
+			return m, nil
+		}
 		switch {
 		case key.Matches(msg, m.keys.Quit):
+			m.cancel()
 			return m, tea.Quit
 		case key.Matches(msg, m.keys.Journal):
 			m.rightView = "journal"
+		case key.Matches(msg, m.keys.Timer):
+			return m.toggleTimer()
+		case key.Matches(msg, m.keys.Spot):
+			return m.nextSpot()
+		case key.Matches(msg, m.keys.Refresh):
+			return m, m.buoyData.Refresh(m.ctx)
+		case key.Matches(msg, m.keys.RetryWaves):
+			return m, buoy.Retry(buoy.SectionWaves)
+		case key.Matches(msg, m.keys.RetryWind):
+			return m, buoy.Retry(buoy.SectionWind)
+		case key.Matches(msg, m.keys.RetryTide):
+			return m, buoy.Retry(buoy.SectionTide)
+		case key.Matches(msg, m.keys.RetryForecast):
+			return m, m.bets.Retry(m.ctx)
+		case key.Matches(msg, m.keys.Tide):
+			m.buoyData.ToggleTideTable()
+			return m, nil
+		case key.Matches(msg, m.keys.Outlook):
+			m.buoyData.ToggleOutlook()
+			return m, nil
+		case m.rightView != "spots" && m.rightView != "stations" && key.Matches(msg, m.keys.TideDay): // the spot report and station list page with ←/→
+			delta := 1
+			if msg.String() == "left" {
+				delta = -1
+			}
+			return m, m.buoyData.ShiftTideDay(m.ctx, delta)
+		case key.Matches(msg, m.keys.Bets):
+			m.rightView = "bets"
+			return m, m.bets.Load(m.ctx)
+		case key.Matches(msg, m.keys.Report):
+			m.rightView = "spots"
+			return m, nil
+		case key.Matches(msg, m.keys.Quiver):
+			m.rightView = "quiver"
+			m.quiver.SetRides(boardRides(m.journal.Entries))
+			return m, nil
+		case key.Matches(msg, m.keys.Stats):
+			m.rightView = "stats"
+			m.stats.SetEntries(m.journal.Entries)
+			return m, nil
+		case key.Matches(msg, m.keys.Week):
+			m.rightView = "week"
+			return m, m.week.Load(m.ctx)
+		case key.Matches(msg, m.keys.Stations):
+			m.rightView = "stations"
+			return m, tea.Batch(m.stations.Load(m.ctx), m.stations.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height}, rightPaneWidth(m.width), m.height))
 		case key.Matches(msg, m.keys.Create):
 			m.rightView = "create"
-			if m.createForm != nil {
-				m.createForm.Focus()
+			if m.createForm == nil {
+				m.createForm = create.NewModel()
+				m.createForm.SetContext(m.ctx)
+				m.createForm.SetPastSessions(m.journal.Entries)
 			}
+			m.createForm.Focus()
 
 			return m, func() tea.Msg {
 				return create.InitFormMsg{}
@@ -79,11 +268,29 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	var cmd tea.Cmd
-	m.buoyData, cmd = buoy.HandleUpdate(m.buoyData, msg)
+	m.buoyData, cmd = buoy.HandleUpdate(m.ctx, m.buoyData, msg)
 	if cmd != nil {
 		cmds = append(cmds, cmd)
 	}
 
+	// forecast results may land while another view is active
+	if cmd = m.bets.Update(msg); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+	if cmd = m.week.Update(msg); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+	// and station list loads and previews; keys only go to it while shown
+	if _, isKey := msg.(tea.KeyMsg); m.rightView == "stations" || !isKey {
+		if cmd = m.stations.Update(msg, rightPaneWidth(m.width), m.height); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	// as may outbox retries
+	if cmd = m.journal.UpdateOutbox(msg); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+
 	// propagate updates to active right pane
 	if m.rightView == "journal" && m.journal != nil {
 		cmd = m.journal.Update(msg, rightPaneWidth(m.width), m.height)
@@ -91,6 +298,16 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 		}
 	}
+	if m.rightView == "spots" {
+		if cmd = m.report.Update(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	if m.rightView == "quiver" {
+		if cmd = m.quiver.Update(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
 	if m.rightView == "create" {
 		m.createForm, cmd = create.UpdateModel(m.createForm, msg)
 		if cmd != nil {
@@ -98,10 +315,18 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if m.createForm != nil && m.createForm.IsDoneAndUnpersisted() {
 			if m.journal != nil {
-				if _, err := m.journal.Persist(m.createForm.Entry); err == nil {
-					// After successful creation, clear form and return to journal.
+				if _, queued, err := m.journal.Persist(m.createForm.Entry); err == nil {
+					// After successful creation (or queueing a failed write for
+					// retry), clear form and return to journal.
+					m.journal.ClearDraft()
+					m.bets.SetEntries(m.journal.Entries)
+					m.week.SetEntries(m.journal.Entries)
+					m.report.SetEntries(m.journal.Entries)
 					m.createForm = nil
 					m.rightView = "journal"
+					if queued {
+						return m, m.journal.OutboxCmd()
+					}
 					return m, nil
 				}
 			}
@@ -118,28 +343,69 @@ func (m model) View() string {
 	// compute widths first so buoy view can center artwork
 	leftW := max(24, int(float64(m.width)*0.3))
 	rightW := max(20, m.width-leftW-1)
-	left := buoy.ViewSized(m.buoyData, leftW)
 	var right string
 	switch m.rightView {
 	case "journal":
 		if m.journal != nil {
 			right = m.journal.View()
 		} else {
-			right = "journal unavailable"
+			right = i18n.T("journal unavailable")
 		}
 	case "create":
 		right = create.View(m.createForm)
+	case "bets":
+		right = m.bets.View()
+	case "spots":
+		right = m.report.View()
+	case "quiver":
+		right = m.quiver.View()
+	case "stats":
+		right = m.stats.View(rightW - contentStyle.GetHorizontalFrameSize())
+	case "week":
+		right = m.week.View(rightW - contentStyle.GetHorizontalFrameSize())
+	case "stations":
+		right = m.stations.View()
+	case "recap":
+		right = m.recap.View()
 	default:
 		right = "unknown"
 	}
 
-	// determine split sizes (already computed) (30% left min width 24)
-	leftRendered := lipgloss.NewStyle().Width(leftW).Render(contentStyle.Render(left))
-	rightRendered := lipgloss.NewStyle().Width(rightW).Render(contentStyle.Render(right))
-	columns := lipgloss.JoinHorizontal(lipgloss.Top, leftRendered, dividerStyle.Render("│"), rightRendered)
+	sep := dividerStyle.Render(lipgloss.NewStyle().Width(m.width).Render(strings.Repeat("─", max(0, m.width))))
+	var columns string
+	if m.rightView == "create" {
+		// the form gets the full width; the buoy pane shrinks to a summary
+		inner := m.width - contentStyle.GetHorizontalFrameSize()
+		mini := lipgloss.NewStyle().Width(m.width).Render(contentStyle.Render(layout.TruncateLines(buoy.MiniView(m.buoyData), inner)))
+		form := lipgloss.NewStyle().Width(m.width).Render(contentStyle.Render(right))
+		columns = lipgloss.JoinVertical(lipgloss.Left, mini, sep, form)
+	} else {
+		left := buoy.ViewSized(m.buoyData, leftW-contentStyle.GetHorizontalFrameSize())
+		if m.activeSpot != nil && strings.TrimSpace(m.activeSpot.Notes) != "" {
+			left += "\n\n" + spotNotesView(*m.activeSpot)
+		}
+		// determine split sizes (already computed) (30% left min width 24)
+		leftRendered := lipgloss.NewStyle().Width(leftW).Render(contentStyle.Render(left))
+		rightRendered := lipgloss.NewStyle().Width(rightW).Render(contentStyle.Render(right))
+		columns = lipgloss.JoinHorizontal(lipgloss.Top, leftRendered, dividerStyle.Render("│"), rightRendered)
+	}
 
 	header := headerStyle.Render(appTitle) + " " + tabs(m.rightView, max(0, m.width-10))
-	sep := dividerStyle.Render(lipgloss.NewStyle().Width(m.width).Render(strings.Repeat("─", max(0, m.width))))
+	if m.activeSpot != nil {
+		header += " " + spotNotesTitleStyle.Render("@ "+m.activeSpot.Name)
+	}
+	if m.timer != nil {
+		header += " " + timerStyle.Render("⏱ "+create.FormatDuration(int(m.timer.Elapsed(time.Now()).Minutes())))
+	}
+	if m.toast != "" {
+		header += " " + toastStyle.Render("⚡ "+m.toast)
+	}
+	if n := m.journal.PendingSaves(); n > 0 {
+		header += " " + pendingStyle.Render(i18n.T("⚠ %d unsaved, retrying", n))
+	}
+	if m.width > 0 {
+		header = layout.Truncate(header, m.width)
+	}
 	foot := m.help.View(m.keys)
 	layout := lipgloss.JoinVertical(lipgloss.Left, header, sep, columns, sep, foot)
 	if m.width > 0 {
@@ -148,6 +414,97 @@ func (m model) View() string {
 	return layout
 }
 
+// showToast puts text in the header until toastDuration passes or another
+// toast replaces it.
+func (m *model) showToast(text string) tea.Cmd {
+	m.toast = text
+	m.toastSeq++
+	seq := m.toastSeq
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg { return toastExpiredMsg{seq: seq} })
+}
+
+// toastDuration is how long a toast stays in the header.
+const toastDuration = time.Minute
+
+type toastExpiredMsg struct{ seq int }
+
+// toggleTimer starts a session timer, or stops the running one and opens a
+// create form pre-filled with the session start and duration.
+func (m model) toggleTimer() (tea.Model, tea.Cmd) {
+	store, err := timer.NewStore(config.StateDir())
+	if err != nil {
+		return m, nil
+	}
+	if m.timer == nil {
+		spot := ""
+		if m.activeSpot != nil {
+			spot = m.activeSpot.Name
+		}
+		if sess, err := store.Start(spot, time.Now()); err == nil {
+			m.timer = &sess
+		}
+		return m, nil
+	}
+	sess, err := store.Stop()
+	m.timer = nil
+	if err != nil {
+		return m, nil
+	}
+	m.createForm = create.NewModel()
+	m.createForm.SetContext(m.ctx)
+	m.createForm.ApplyDraft(timerEntry(sess, time.Now()))
+	m.createForm.Focus()
+	m.rightView = "create"
+	return m, func() tea.Msg { return create.InitFormMsg{} }
+}
+
+// nextSpot makes the next saved spot active, nearest first (wrapping back to
+// none), and reloads the buoy pane for that spot's stations.
+func (m model) nextSpot() (tea.Model, tea.Cmd) {
+	if m.spotSvc == nil {
+		return m, nil
+	}
+	list, err := m.spotSvc.List()
+	if err != nil || len(list) == 0 {
+		return m, nil
+	}
+	spots.ByTravel(list)
+	next := 0
+	if m.activeSpot != nil {
+		next = len(list) // past the end means "no active spot"
+		for i, sp := range list {
+			if strings.EqualFold(sp.Name, m.activeSpot.Name) {
+				next = i + 1
+			}
+		}
+	}
+	if next < len(list) {
+		sp := list[next]
+		m.activeSpot = &sp
+		spots.SetActive(sp.Name)
+	} else {
+		m.activeSpot = nil
+		spots.SetActive("")
+	}
+	var cmd tea.Cmd
+	m.buoyData, cmd = buoy.Reload(m.ctx, m.buoyData)
+	return m, cmd
+}
+
+// openReport renders a spot report as HTML and opens it in the browser.
+func openReport(r analysis.SpotReport) (string, error) {
+	page, err := htmlview.Report(r)
+	if err != nil {
+		return "", err
+	}
+	return htmlview.Open(page)
+}
+
+// spotNotesView renders the active spot's standing notes for the buoy pane.
+func spotNotesView(sp spots.Spot) string {
+	return spotNotesTitleStyle.Render(i18n.T("Notes: %s", sp.Name)) + "\n" + spotNotesStyle.Render(sp.Notes)
+}
+
 // small helper until Go 1.21+ min/max generics maybe
 func max(a, b int) int {
 	if a > b {
@@ -156,6 +513,17 @@ func max(a, b int) int {
 	return b
 }
 
+// boardRides counts sessions per board for the quiver view.
+func boardRides(entries []create.Entry) map[string]int {
+	rides := map[string]int{}
+	for _, e := range entries {
+		if e.BoardID != "" {
+			rides[e.BoardID]++
+		}
+	}
+	return rides
+}
+
 // helper to compute right pane width for updates
 func rightPaneWidth(total int) int {
 	leftW := max(24, int(float64(total)*0.3))
@@ -0,0 +1,56 @@
+// Package units renders heights and temperatures in the unit system chosen
+// by `units`: "imperial" (feet and °F, the default) or "metric" (metres and
+// °C). Values are stored in metres and Celsius everywhere else; tide
+// predictions, which NOAA publishes in feet, are the one exception.
+package units
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// FeetPerMeter converts metres to feet.
+const FeetPerMeter = 3.28084
+
+// Metric reports whether `units` is "metric".
+func Metric() bool {
+	return strings.EqualFold(strings.TrimSpace(viper.GetString("units")), "metric")
+}
+
+// Height formats a height given in metres, e.g. "4.3ft" or "1.3m".
+func Height(m float64) string {
+	return fmt.Sprintf("%.1f%s", HeightValue(m), HeightUnit())
+}
+
+// HeightValue converts a height in metres to the display unit, for charts.
+func HeightValue(m float64) float64 {
+	if Metric() {
+		return m
+	}
+	return m * FeetPerMeter
+}
+
+// HeightUnit returns "m" or "ft".
+func HeightUnit() string {
+	if Metric() {
+		return "m"
+	}
+	return "ft"
+}
+
+// Feet formats a height given in feet, such as a tide level.
+func Feet(ft float64) string { return Height(ft / FeetPerMeter) }
+
+// FeetValue converts a height in feet to the display unit, for charts.
+func FeetValue(ft float64) float64 { return HeightValue(ft / FeetPerMeter) }
+
+// Celsius reports whether temperatures are shown in °C: `display.temperature`
+// ("C" or "F") when set, otherwise the unit system's.
+func Celsius() bool {
+	if t := strings.TrimSpace(viper.GetString("display.temperature")); t != "" {
+		return strings.EqualFold(t, "C")
+	}
+	return Metric()
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/sumwatshade/surflog/cmd/alerts"
+	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/hooks"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Poll the buoy and fire alerts when conditions match your rules",
+	Long: `Polls the configured buoy (or --station) every --interval and evaluates the
+rules under alerts.rules in the config file:
+
+  alerts:
+    rules:
+      - name: long-period-west
+        when: period > 13 AND wvht > 1.5m AND swell_dir in W-NW
+        hook: notify-send surf "$SURFLOG_MESSAGE"   # optional
+
+A rule fires once when its conditions start matching, and again only after
+they stop and match anew. Firing raises a desktop notification and runs the
+rule's hook, if any, with SURFLOG_RULE, SURFLOG_MESSAGE and SURFLOG_<FIELD>
+(e.g. SURFLOG_WVHT) in its environment. Quiet hours and snoozes
+(surflog alerts) silence the notification but not the log line. A global
+hooks.on_alert command also runs for every rule, with the rule, message and
+reading as JSON on stdin.
+
+Conditions join "field op value" terms with AND, where op is one of
+> >= < <= =, or "field in A-B" for a clockwise direction range. Heights are
+meters unless given in ft, speeds knots. Fields: ` + strings.Join(alerts.Fields(), ", ") + `.`,
+	Example: `  surflog watch --interval 20m
+  surflog watch --once   # evaluate once, e.g. from cron`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rules, err := alerts.ConfiguredRules()
+		if err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return errors.New("no alerts.rules configured; see surflog watch --help")
+		}
+		station := buoy.ConfiguredBuoyStation()
+		if err := buoy.ValidateBuoyStation(station); err != nil {
+			return err
+		}
+		interval, _ := cmd.Flags().GetDuration("interval")
+		if interval < time.Minute {
+			return errors.New("--interval must be at least 1m")
+		}
+		once, _ := cmd.Flags().GetBool("once")
+		w := &watcher{rules: rules, svc: buoy.NewService(), out: cmd.OutOrStdout(), errOut: cmd.ErrOrStderr(), firing: map[string]bool{}}
+		ctx := cmd.Context()
+		for {
+			w.poll(ctx, time.Now())
+			if once {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(interval):
+			}
+		}
+	},
+}
+
+// watcher evaluates rules on each poll, remembering which currently match so
+// each fires once per spell of matching conditions.
+type watcher struct {
+	rules       []alerts.Rule
+	svc         buoy.Service
+	out, errOut io.Writer
+	firing      map[string]bool
+}
+
+func (w *watcher) poll(ctx context.Context, now time.Time) {
+	r, summary, err := watchReading(ctx, w.svc)
+	if err != nil {
+		fmt.Fprintf(w.errOut, "%s  fetch failed: %v\n", now.Format("15:04"), err)
+		return
+	}
+	for _, rule := range w.rules {
+		match := rule.Match(r)
+		fire := match && !w.firing[rule.Name]
+		w.firing[rule.Name] = match
+		if !fire {
+			continue
+		}
+		msg := rule.Name + ": " + summary
+		fmt.Fprintf(w.out, "%s  %s\n", now.Format("15:04"), msg)
+		if alerts.ShouldNotify(rule.Name, now) {
+			alerts.Notify(w.errOut, "surflog", msg)
+		}
+		if rule.Hook != "" {
+			if err := alerts.RunHook(ctx, rule.Hook, hookEnv(rule.Name, msg, r), w.out); err != nil {
+				fmt.Fprintf(w.errOut, "%s  %s hook: %v\n", now.Format("15:04"), rule.Name, err)
+			}
+		}
+		event := hooks.AlertEvent{Rule: rule.Name, Message: msg, Reading: r, Time: now}
+		if err := hooks.Run(ctx, hooks.Alert, event, hookEnv(rule.Name, msg, r)...); err != nil {
+			fmt.Fprintf(w.errOut, "%s  %v\n", now.Format("15:04"), err)
+		}
+	}
+}
+
+// watchReading fetches the latest waves, wind and water temperature as a
+// rule reading, with the wave summary line for messages. Only the waves are
+// required; wind and temperature fields are left out when unavailable.
+func watchReading(ctx context.Context, svc buoy.Service) (alerts.Reading, string, error) {
+	ws, err := svc.GetWaveSummary(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	r := alerts.Reading{
+		"wvht":         ws.SignificantHeight(),
+		"swell_height": ws.SwellHeight(),
+		"period":       ws.SwellPeriod(),
+		"avg_period":   ws.AveragePeriod(),
+	}
+	if d, ok := ws.SwellDirectionDeg(); ok {
+		r["swell_dir"] = d
+	}
+	if wind, err := svc.GetWindSummary(ctx); err == nil {
+		r["wind_speed"] = wind.Speed() * buoy.MSToKnots
+		if g, ok := wind.Gust(); ok {
+			r["wind_gust"] = g * buoy.MSToKnots
+		}
+		if d, ok := wind.Direction(); ok {
+			r["wind_dir"] = d
+		}
+	}
+	if t, err := svc.GetTemperatures(ctx); err == nil {
+		if c, ok := t.Water(); ok {
+			r["water_temp"] = c
+		}
+	}
+	return r, ws.String(), nil
+}
+
+// hookEnv is the environment a rule's hook runs with.
+func hookEnv(rule, msg string, r alerts.Reading) []string {
+	env := []string{"SURFLOG_RULE=" + rule, "SURFLOG_MESSAGE=" + msg}
+	for k, v := range r {
+		env = append(env, "SURFLOG_"+strings.ToUpper(k)+"="+strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	return env
+}
+
+func init() {
+	watchCmd.Flags().Duration("interval", 30*time.Minute, "how often to poll the buoy")
+	watchCmd.Flags().Bool("once", false, "evaluate the rules once and exit")
+	rootCmd.AddCommand(watchCmd)
+}
@@ -0,0 +1,68 @@
+package week
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/sumwatshade/surflog/cmd/recommend"
+)
+
+// Availability is when the user is free to surf: the session windows (by
+// recommend.Window name) open on each weekday.
+type Availability map[time.Weekday]map[string]bool
+
+// weekdays maps config keys to weekdays.
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// windowAliases lets availability name windows by part of day.
+var windowAliases = map[string]string{"morning": "am", "mornings": "am", "evening": "pm", "evenings": "pm"}
+
+// ConfiguredAvailability reads `week.free`, which lists the free windows per
+// day, e.g.
+//
+//	week:
+//	  free:
+//	    mon: [evening]
+//	    sat: [am, pm]
+//
+// Days left out are busy. Without `week.free` every window is free.
+func ConfiguredAvailability() Availability {
+	if !viper.IsSet("week.free") {
+		return nil
+	}
+	a := Availability{}
+	for day, windows := range viper.GetStringMapStringSlice("week.free") {
+		key := strings.ToLower(strings.TrimSpace(day))
+		if len(key) > 3 {
+			key = key[:3] // "monday" -> "mon"
+		}
+		wd, ok := weekdays[key]
+		if !ok {
+			continue
+		}
+		if a[wd] == nil {
+			a[wd] = map[string]bool{}
+		}
+		for _, w := range windows {
+			w = strings.ToLower(strings.TrimSpace(w))
+			if alias, ok := windowAliases[w]; ok {
+				w = alias
+			}
+			a[wd][strings.ToUpper(w)] = true
+		}
+	}
+	return a
+}
+
+// Free reports whether window is open on day. A nil Availability is always
+// free.
+func (a Availability) Free(day time.Time, window recommend.Window) bool {
+	if a == nil {
+		return true
+	}
+	return a[day.Weekday()][strings.ToUpper(window.Name)]
+}
@@ -0,0 +1,213 @@
+package week
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/create"
+	"github.com/sumwatshade/surflog/cmd/forecast"
+	"github.com/sumwatshade/surflog/cmd/i18n"
+	"github.com/sumwatshade/surflog/cmd/layout"
+	"github.com/sumwatshade/surflog/cmd/netclient"
+	"github.com/sumwatshade/surflog/cmd/recommend"
+	"github.com/sumwatshade/surflog/cmd/spots"
+	"github.com/sumwatshade/surflog/cmd/units"
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("44"))
+	faintStyle = lipgloss.NewStyle().Faint(true).Foreground(lipgloss.Color("245"))
+	errStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("203"))
+	goodStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("159")).Bold(true)
+	infoStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
+	tideStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("36"))
+)
+
+// Model is the "week" right-pane view: forecasts, tides and availability
+// combined into a planning grid.
+type Model struct {
+	bets      *recommend.Model // fetches forecasts for the same spots
+	prefs     recommend.Preferences
+	forecasts map[string]forecast.Forecast
+	travel    map[string]int // minutes from home by spot
+	fcErr     error
+	tides     *buoy.TideData
+	tideErr   error
+	loading   int // fetches in flight
+	loaded    bool
+}
+
+// forecastsMsg carries the week's forecasts keyed by spot name.
+type forecastsMsg struct {
+	forecasts map[string]forecast.Forecast
+	err       error
+}
+
+// tidesMsg carries the week's tide predictions for the active spot.
+type tidesMsg struct {
+	tides buoy.TideData
+	err   error
+}
+
+// NewModel builds the view over the best bets' spots, learning preferences
+// from existing entries.
+func NewModel(bets *recommend.Model, entries []create.Entry) *Model {
+	return &Model{bets: bets, prefs: recommend.Learn(entries)}
+}
+
+// SetEntries re-learns preferences (e.g. after a new entry is saved).
+func (m *Model) SetEntries(entries []create.Entry) {
+	if m != nil {
+		m.prefs = recommend.Learn(entries)
+	}
+}
+
+// Load fetches the week's forecasts and tides unless already loaded. A load
+// that failed is retried.
+func (m *Model) Load(ctx context.Context) tea.Cmd {
+	if m == nil || m.loading > 0 || (m.loaded && m.fcErr == nil && m.tideErr == nil) {
+		return nil
+	}
+	m.loading = 2
+	bets := m.bets
+	buoyStation, tideStation := spots.ActiveStations()
+	svc := buoy.NewSpotService(buoyStation, tideStation)
+	now := time.Now()
+	begin := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	end := begin.AddDate(0, 0, Days).Add(-time.Minute)
+	return tea.Batch(
+		func() tea.Msg {
+			out, err := bets.Fetch(ctx, Days)
+			return forecastsMsg{forecasts: out, err: err}
+		},
+		func() tea.Msg {
+			td, err := svc.GetTideData(ctx, begin, end)
+			return tidesMsg{tides: td, err: err}
+		},
+	)
+}
+
+// Update handles fetch results.
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	if m == nil {
+		return nil
+	}
+	switch msg := msg.(type) {
+	case forecastsMsg:
+		m.loading--
+		m.loaded = true
+		m.fcErr = msg.err
+		if msg.err == nil {
+			m.forecasts = msg.forecasts
+			m.travel = m.bets.Travel()
+		}
+	case tidesMsg:
+		m.loading--
+		m.loaded = true
+		m.tideErr = msg.err
+		if msg.err == nil {
+			m.tides = &msg.tides
+		}
+	}
+	return nil
+}
+
+// Key is the key the TUI binds to the view. Pressing it again after a failed
+// load retries.
+const Key = "w"
+
+// dayWidth is the width of the day column, e.g. "Fri 16".
+const dayWidth = 7
+
+// View renders the planning grid at the given width: a row per day with a
+// column per session window and the day's tide turns.
+func (m *Model) View(width int) string {
+	b := &strings.Builder{}
+	fmt.Fprintln(b, titleStyle.Render(i18n.T("Week")))
+	if m == nil {
+		return b.String()
+	}
+	if !m.loaded && m.loading > 0 {
+		fmt.Fprintln(b, faintStyle.Render(i18n.T("Loading...")))
+		return b.String()
+	}
+	for _, err := range []error{m.fcErr, m.tideErr} {
+		if err == nil {
+			continue
+		}
+		fmt.Fprintln(b, errStyle.Render(layout.Truncate(err.Error(), width)))
+		if hint := netclient.Hint(err); hint != "" {
+			fmt.Fprintln(b, faintStyle.Render(hint))
+		}
+	}
+	if m.fcErr != nil || m.tideErr != nil {
+		fmt.Fprintln(b, faintStyle.Render(i18n.T("press %s to retry", Key)))
+	}
+	fmt.Fprintln(b)
+
+	avail := ConfiguredAvailability()
+	days := Build(m.forecasts, m.prefs, m.travel, m.tides, avail, time.Now())
+	width = max(40, width)
+	tideW := min(30, width/3)
+	slotW := (width - dayWidth - tideW - 2*len(recommend.Windows)) / len(recommend.Windows)
+	cell := func(s string, w int) string { return lipgloss.NewStyle().Width(w).Render(layout.Truncate(s, w)) }
+
+	header := []string{cell("", dayWidth)}
+	for _, w := range recommend.Windows {
+		header = append(header, "  "+cell(i18n.T(w.Name), slotW))
+	}
+	header = append(header, "  "+cell(i18n.T("Tides"), tideW))
+	fmt.Fprintln(b, titleStyle.Render(strings.Join(header, "")))
+	for _, d := range days {
+		row := []string{cell(i18n.T(d.Date.Format("Mon"))+d.Date.Format(" 02"), dayWidth)}
+		for _, s := range d.Slots {
+			row = append(row, "  "+cell(slotText(s), slotW))
+		}
+		row = append(row, "  "+cell(tideText(d.Tides), tideW))
+		fmt.Fprintln(b, strings.Join(row, ""))
+	}
+	fmt.Fprintln(b)
+	if avail == nil {
+		fmt.Fprint(b, faintStyle.Render(i18n.T("Set week.free in the config to plan around your free mornings and evenings.")))
+	} else {
+		fmt.Fprint(b, faintStyle.Render(i18n.T("Best spot per free window, scored against the conditions you usually log.")))
+	}
+	return b.String()
+}
+
+// slotText renders a window's cell: busy, no forecast, or the best spot with
+// its score and swell.
+func slotText(s Slot) string {
+	switch {
+	case !s.Free:
+		return faintStyle.Render(i18n.T("busy"))
+	case s.Best == nil:
+		return faintStyle.Render("—")
+	}
+	score := fmt.Sprintf("%d/10", s.Best.Score)
+	if s.Best.Score >= 7 {
+		score = goodStyle.Render(score)
+	}
+	return s.Best.Spot + " " + score + " " + infoStyle.Render(i18n.T("%s @ %.0fs", units.Height(s.Best.SwellHeight), s.Best.SwellPeriod))
+}
+
+// tideText lists a day's highs and lows, e.g. "▲05:12 ▼11:40".
+func tideText(tides []buoy.TidePrediction) string {
+	if len(tides) == 0 {
+		return faintStyle.Render("—")
+	}
+	var parts []string
+	for _, t := range tides {
+		mark := "▼"
+		if t.Type == "high" {
+			mark = "▲"
+		}
+		parts = append(parts, mark+i18n.Time(t.Time))
+	}
+	return tideStyle.Render(strings.Join(parts, " "))
+}
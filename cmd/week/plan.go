@@ -0,0 +1,63 @@
+// Package week is the weekly planning view: a grid of the next Days days with
+// each day's tide turns and, per session window, the best forecast spot when
+// the user is free.
+package week
+
+import (
+	"time"
+
+	"github.com/sumwatshade/surflog/cmd/buoy"
+	"github.com/sumwatshade/surflog/cmd/forecast"
+	"github.com/sumwatshade/surflog/cmd/recommend"
+)
+
+// Days is how many days the plan covers, starting today.
+const Days = 7
+
+// Slot is one session window on a day.
+type Slot struct {
+	Window recommend.Window
+	Free   bool
+	Best   *recommend.Bet // best scoring spot; nil without a forecast
+}
+
+// Day is one row of the plan.
+type Day struct {
+	Date  time.Time // local midnight
+	Tides []buoy.TidePrediction
+	Slots []Slot // in recommend.Windows order
+}
+
+// Build lays out the Days days from now's: the highs and lows in tides (nil
+// when unavailable) and the best bet per free window from forecasts, with
+// travel minutes per spot counted against it (see recommend.Rank).
+func Build(forecasts map[string]forecast.Forecast, prefs recommend.Preferences, travel map[string]int, tides *buoy.TideData, avail Availability, now time.Time) []Day {
+	loc := now.Location()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	best := map[time.Time]map[string]recommend.Bet{}
+	// Rank returns best first, so the first bet per day and window wins
+	for _, b := range recommend.Rank(forecasts, prefs, travel, loc) {
+		if best[b.Day] == nil {
+			best[b.Day] = map[string]recommend.Bet{}
+		}
+		if _, ok := best[b.Day][b.Window.Name]; !ok {
+			best[b.Day][b.Window.Name] = b
+		}
+	}
+	days := make([]Day, Days)
+	for i := range days {
+		d := &days[i]
+		d.Date = today.AddDate(0, 0, i)
+		if tides != nil {
+			d.Tides = tides.Extremes(d.Date, d.Date.AddDate(0, 0, 1).Add(-time.Minute))
+		}
+		for _, w := range recommend.Windows {
+			slot := Slot{Window: w, Free: avail.Free(d.Date, w)}
+			if b, ok := best[d.Date][w.Name]; ok {
+				slot.Best = &b
+			}
+			d.Slots = append(d.Slots, slot)
+		}
+	}
+	return days
+}